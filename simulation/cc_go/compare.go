@@ -0,0 +1,97 @@
+// compare.go - Side-by-side comparison of every scheduler
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"cc_go/pkg/benchmark"
+	"cc_go/pkg/config"
+	"cc_go/pkg/metrics"
+	"cc_go/pkg/scheduler"
+)
+
+// runComparison runs each registered scheduler in turn for the configured
+// duration. Every run gets a fresh cluster and a workload generator seeded
+// identically, so all schedulers see the same container sequence.
+func runComparison(cfg *config.Config) {
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	names := scheduler.List()
+	fmt.Printf("Comparing %d schedulers for %d seconds each (seed %d)...\n",
+		len(names), cfg.Duration, seed)
+
+	runs := make([]metrics.SchedulerResults, 0, len(names))
+	for _, name := range names {
+		runCfg := *cfg
+		runCfg.Scheduler = name
+		runCfg.Seed = seed
+		// Learned state would give the adaptive scheduler a head start
+		runCfg.AdaptiveState = ""
+
+		sched, err := newScheduler(&runCfg)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+
+		workloadGen, stream, err := newWorkload(&runCfg)
+		if err != nil {
+			log.Fatalf("Failed to initialize workload: %v", err)
+		}
+
+		// Every scenario builds its own fresh cluster
+		scenario, err := newScenario(&runCfg, sched)
+		if err != nil {
+			log.Fatalf("Failed to load cluster: %v", err)
+		}
+		scenario.Generator = workloadGen
+		if runCfg.MaxEvents > 0 {
+			scenario.Collector = metrics.NewCappedCollector(runCfg.MaxEvents)
+		}
+		scenario.Setup = func(b *benchmark.Benchmark) error {
+			return configureBenchmark(b, &runCfg, sched)
+		}
+
+		fmt.Printf("  Running %s...\n", sched.Name())
+		results, err := benchmark.RunScenario(scenario)
+		if stream != nil {
+			stream.Close()
+		}
+		if err != nil {
+			log.Fatalf("Benchmark failed: %v", err)
+		}
+		runs = append(runs, metrics.SchedulerResults{Scheduler: sched.Name(), Results: results})
+	}
+
+	fmt.Println("Comparison of schedulers:")
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(table, "Scheduler\tScheduled\tAvg latency (ms)\tP95 latency (ms)\tP95 queue delay (ms)\tUtilization\tFailures\t")
+	for _, run := range runs {
+		fmt.Fprintf(table, "%s\t%d\t%.3f\t%.3f\t%.1f\t%.2f%%\t%d\t\n",
+			run.Scheduler,
+			run.Results.ContainersScheduled,
+			run.Results.AverageLatency,
+			run.Results.LatencyPercentile(95),
+			run.Results.QueueDelayPercentile(95),
+			run.Results.ResourceUtilization*100,
+			run.Results.SchedulingFailures)
+	}
+	table.Flush()
+	if cfg.Autoscale {
+		fmt.Println("Autoscaling:")
+		for _, run := range runs {
+			fmt.Printf("  %s: peak %d nodes (%d added, %d removed), cost $%.4f\n",
+				run.Scheduler, run.Results.PeakNodes, run.Results.ScaleUps, run.Results.ScaleDowns, run.Results.TotalCost)
+		}
+	}
+
+	if err := metrics.SaveComparisonToFile(cfg.Output, runs); err != nil {
+		log.Fatalf("Failed to save comparison: %v", err)
+	}
+	fmt.Printf("Combined results saved to %s\n", cfg.Output)
+}
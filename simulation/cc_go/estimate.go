@@ -0,0 +1,60 @@
+// estimate.go - Capacity estimate printed instead of a run
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"cc_go/pkg/benchmark"
+	"cc_go/pkg/config"
+	"cc_go/pkg/workLoad"
+)
+
+// runEstimate prints how many containers of the workload the cluster should
+// hold and which resource runs out first, without simulating anything
+func runEstimate(cfg *config.Config) {
+	definition, err := workLoad.LoadDefinition(cfg.Workload)
+	if err != nil {
+		log.Fatalf("Failed to load workload: %v", err)
+	}
+
+	cluster := benchmark.DefaultClusterDefinition()
+	if cfg.Cluster != "" {
+		cluster, err = benchmark.LoadClusterDefinition(cfg.Cluster)
+		if err != nil {
+			log.Fatalf("Failed to load cluster: %v", err)
+		}
+	}
+	nodes, err := benchmark.NewCluster(cluster)
+	if err != nil {
+		log.Fatalf("Failed to load cluster: %v", err)
+	}
+
+	estimate := benchmark.EstimateCapacity(nodes, definition)
+
+	fmt.Printf("Capacity estimate for %s on %d nodes:\n", cfg.Workload, len(nodes))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Resource\tPer container\tCluster capacity\tContainers")
+	for _, resource := range benchmark.EstimateResources {
+		capacity, ok := estimate.Capacity[resource]
+		if !ok {
+			fmt.Fprintf(w, "%s\t%.2f\tunconstrained\t-\n", resource, estimate.Demand[resource])
+			continue
+		}
+		limit, ok := estimate.Limits[resource]
+		if !ok {
+			fmt.Fprintf(w, "%s\t%.2f\t%.2f\t-\n", resource, estimate.Demand[resource], capacity)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%.2f\t%.2f\t%.1f\n", resource, estimate.Demand[resource], capacity, limit)
+	}
+	w.Flush()
+
+	if estimate.Bottleneck == "" {
+		fmt.Println("No resource constrains this workload")
+		return
+	}
+	fmt.Printf("Estimated capacity: %d containers, limited by %s\n", estimate.Containers, estimate.Bottleneck)
+}
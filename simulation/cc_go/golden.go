@@ -0,0 +1,137 @@
+// golden.go - Recorded placement decisions for regression checks
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"cc_go/pkg/config"
+	"cc_go/pkg/metrics"
+)
+
+// maxReportedDiffs caps how many diverging containers verify prints
+const maxReportedDiffs = 20
+
+// Golden is every placement decision of a run, recorded with -record and
+// compared with a new run of the same seeded workload by -verify. Only
+// reproducible runs can match: they need --accelerate, so arrivals do not
+// depend on wall-clock timing.
+type Golden struct {
+	Scheduler  string      `json:"scheduler"`
+	Seed       int64       `json:"seed"`
+	Placements []Placement `json:"placements"`
+}
+
+// Placement is one scheduling attempt; Node is empty when it failed
+type Placement struct {
+	Container string `json:"container"`
+	Node      string `json:"node,omitempty"`
+}
+
+// newGolden collects the decisions of a finished run in event order
+func newGolden(cfg *config.Config, results *metrics.Results) *Golden {
+	golden := &Golden{
+		Scheduler:  cfg.Scheduler,
+		Seed:       cfg.Seed,
+		Placements: make([]Placement, 0, len(results.Events)),
+	}
+	for _, event := range results.Events {
+		placement := Placement{Container: event.ContainerID}
+		if event.ScheduleSuccess {
+			placement.Node = event.NodeID
+		}
+		golden.Placements = append(golden.Placements, placement)
+	}
+	return golden
+}
+
+func loadGolden(path string) (*Golden, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var golden Golden
+	if err := json.Unmarshal(data, &golden); err != nil {
+		return nil, fmt.Errorf("golden file %s: %v", path, err)
+	}
+	return &golden, nil
+}
+
+func (g *Golden) Save(path string) error {
+	data, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// diffGolden describes each container whose sequence of placements in got
+// differs from want, in the order the containers first appear, e.g.
+// "container-7: golden node-2, now node-3 -> unplaced"
+func diffGolden(want, got *Golden) []string {
+	wantNodes, order := placementsByContainer(want, nil)
+	gotNodes, order := placementsByContainer(got, order)
+
+	diffs := make([]string, 0)
+	for _, id := range order {
+		w, g := wantNodes[id], gotNodes[id]
+		if strings.Join(w, ",") != strings.Join(g, ",") {
+			diffs = append(diffs, fmt.Sprintf("%s: golden %s, now %s", id, describePlacements(w), describePlacements(g)))
+		}
+	}
+	return diffs
+}
+
+// placementsByContainer returns each container's nodes in the order it was
+// placed, appending containers not seen before to order
+func placementsByContainer(g *Golden, order []string) (map[string][]string, []string) {
+	seen := make(map[string]bool, len(order))
+	for _, id := range order {
+		seen[id] = true
+	}
+	nodes := make(map[string][]string)
+	for _, p := range g.Placements {
+		if !seen[p.Container] {
+			seen[p.Container] = true
+			order = append(order, p.Container)
+		}
+		nodes[p.Container] = append(nodes[p.Container], p.Node)
+	}
+	return nodes, order
+}
+
+func describePlacements(nodes []string) string {
+	if len(nodes) == 0 {
+		return "never scheduled"
+	}
+	names := make([]string, len(nodes))
+	for i, n := range nodes {
+		names[i] = n
+		if n == "" {
+			names[i] = "unplaced"
+		}
+	}
+	return strings.Join(names, " -> ")
+}
+
+// verifyGolden prints how the run's placements compare with the golden
+// file and reports whether they all match
+func verifyGolden(path string, want, got *Golden) bool {
+	diffs := diffGolden(want, got)
+	if len(diffs) == 0 {
+		fmt.Printf("Placements match %s (%d decisions)\n", path, len(want.Placements))
+		return true
+	}
+
+	fmt.Printf("Placements differ from %s for %d containers:\n", path, len(diffs))
+	for i, diff := range diffs {
+		if i == maxReportedDiffs {
+			fmt.Printf("  ... and %d more\n", len(diffs)-maxReportedDiffs)
+			break
+		}
+		fmt.Printf("  %s\n", diff)
+	}
+	return false
+}
@@ -4,80 +4,857 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"runtime"
+	"sort"
+	"strings"
+	"syscall"
 	"time"
 
+	"cc_go/pkg/api"
 	"cc_go/pkg/benchmark"
+	"cc_go/pkg/config"
+	"cc_go/pkg/container"
+	"cc_go/pkg/logging"
 	"cc_go/pkg/metrics"
 	"cc_go/pkg/scheduler"
 	"cc_go/pkg/workLoad"
 )
 
 func main() {
-	schedulerType := flag.String("scheduler", "adaptive", "Scheduler type: 'binpack', 'spread', or 'adaptive'")
-	workloadFile := flag.String("workload", "workloads/mixed_workload.json", "Path to workload definition file")
-	outputFile := flag.String("output", "results.csv", "Path to output results file")
-	duration := flag.Int("duration", 300, "Duration of simulation in seconds")
-	verbose := flag.Bool("verbose", false, "Enable verbose logging")
+	cfg := config.Default()
+	configFile := flag.String("config", "", "Path to a JSON experiment config; flags given on the command line override it")
+	flag.StringVar(&cfg.Scheduler, "scheduler", cfg.Scheduler, "Scheduler type, one of: "+strings.Join(scheduler.List(), ", "))
+	flag.StringVar(&cfg.Cluster, "cluster", cfg.Cluster, "Path to cluster definition file (defaults to the built-in heterogeneous cluster)")
+	flag.StringVar(&cfg.Workload, "workload", cfg.Workload, "Path to workload definition file, or a JSON Lines file of container specs (.jsonl, .ndjson, or - for stdin)")
+	flag.StringVar(&cfg.Output, "output", cfg.Output, "Path to output results file")
+	flag.StringVar(&cfg.OutputDir, "output-dir", cfg.OutputDir, "Write every result file, under conventional names, into this directory instead of next to -output")
+	flag.IntVar(&cfg.Duration, "duration", cfg.Duration, "Duration of simulation in seconds")
+	flag.Var(&cfg.Warmup, "warmup", "Schedule normally for this long (e.g. 30s) but leave it out of the results, so they reflect steady state")
+	flag.Var(&cfg.GenerateFor, "generate-for", "Stop generating containers after this long (e.g. 2m) while the run continues; 0 generates for the whole run")
+	flag.Int64Var(&cfg.Seed, "seed", cfg.Seed, "Seed for the workload generator; 0 seeds from the clock")
+	flag.BoolVar(&cfg.Stream, "stream", cfg.Stream, "Write each scheduling event to the output file as it happens instead of at the end")
+	flag.IntVar(&cfg.MaxEvents, "max-events", cfg.MaxEvents, "Keep only the last N scheduling events in memory and in the output file; summary statistics still cover all events (0 keeps all)")
+	flag.BoolVar(&cfg.Verbose, "verbose", cfg.Verbose, "Log at debug level, including every placement; otherwise only info, warnings and errors are logged")
+	flag.StringVar(&cfg.LogFile, "log-file", cfg.LogFile, "File to write the log to; - writes it to stdout")
+	flag.StringVar(&cfg.AdaptiveState, "adaptive-state", cfg.AdaptiveState, "Path to adaptive scheduler state file, loaded on startup and saved on shutdown")
+	flag.StringVar(&cfg.Serve, "serve", cfg.Serve, "Address to serve the HTTP API on while the benchmark runs (e.g. :8080)")
+	flag.IntVar(&cfg.BatchSize, "batch-size", cfg.BatchSize, "Buffer arrivals into waves of this many containers scheduled as a batch; 1 disables batching")
+	flag.Float64Var(&cfg.IntensityFraction, "intensity-fraction", cfg.IntensityFraction, "Treat requests above this fraction of the median node capacity as resource-intensive; 0 keeps the fixed defaults")
+	flag.IntVar(&cfg.MaxRetries, "max-retries", cfg.MaxRetries, "Retry containers that fail to schedule up to this many times")
+	flag.Var(&cfg.RetryBackoff, "retry-backoff", "Initial wait before retrying a failed container; doubles after each attempt")
+	flag.BoolVar(&cfg.Dominant, "dominant", cfg.Dominant, "Rank nodes by their bottleneck resource instead of average utilization (binpack and spread)")
+	flag.Var(&cfg.ResourceWeights, "resource-weights", "CPU, memory, network and IO weights of the utilization binpack and spread rank nodes by (e.g. 0,1,0,0 for memory only)")
+	flag.IntVar(&cfg.AnnealIterations, "anneal-iterations", cfg.AnnealIterations, "Simulated annealing iterations per wave (optimizing scheduler)")
+	flag.Float64Var(&cfg.AnnealCooling, "anneal-cooling", cfg.AnnealCooling, "Temperature multiplier applied after each annealing iteration (optimizing scheduler)")
+	flag.Float64Var(&cfg.SaturationKnee, "saturation-knee", cfg.SaturationKnee, "Utilization of a node's busiest resource above which placements are penalized (saturationaware scheduler)")
+	flag.Float64Var(&cfg.SaturationExponent, "saturation-exponent", cfg.SaturationExponent, "Exponent of the saturation penalty curve; higher is steeper near full (saturationaware scheduler)")
+	flag.Float64Var(&cfg.PackThreshold, "pack-threshold", cfg.PackThreshold, "Utilization up to which nodes are packed before spreading to fresh ones (hybrid scheduler)")
+	flag.Float64Var(&cfg.UsageNoise, "usage-noise", cfg.UsageNoise, "Let container usage fluctuate around requests by this fraction (e.g. 0.2); seeded by -seed")
+	flag.Float64Var(&cfg.LoadSmoothing, "load-smoothing", cfg.LoadSmoothing, "Weight of the newest sample in each node's smoothed utilization, in (0, 1]; lower is steadier")
+	flag.BoolVar(&cfg.ImageLocality, "image-locality", cfg.ImageLocality, "Prefer nodes that already cache the container's image, skipping the pull")
+	flag.StringVar(&cfg.Cleanup, "cleanup", cfg.Cleanup, "Container completion policy: 'random' (churn-rate per second), 'lifetime' (template lifetimes) or 'never'")
+	flag.Float64Var(&cfg.ChurnRate, "churn-rate", cfg.ChurnRate, "Fraction of each node's containers removed per second under the random cleanup policy")
+	flag.BoolVar(&cfg.FairQueue, "fair-queue", cfg.FairQueue, "Dequeue pending containers in weighted fair order by type, using the workload's template weights, instead of strictly by priority")
+	flag.BoolVar(&cfg.Accelerate, "accelerate", cfg.Accelerate, "Run the whole duration on a simulated clock as fast as possible; scheduling latency still measures real compute time")
+	flag.BoolVar(&cfg.Preemption, "preemption", cfg.Preemption, "Let a container that fits nowhere evict lower-priority containers, which are then rescheduled")
+	flag.BoolVar(&cfg.Autoscale, "autoscale", cfg.Autoscale, "Add nodes when placements keep failing and remove empty nodes while the cluster is underutilized")
+	flag.StringVar(&cfg.AutoscaleTemplate, "autoscale-template", cfg.AutoscaleTemplate, "Cluster node template the autoscaler adds (defaults to the first)")
+	flag.IntVar(&cfg.AutoscaleFailures, "autoscale-failures", cfg.AutoscaleFailures, "Failed placements within the cooldown that trigger a scale up")
+	flag.Float64Var(&cfg.AutoscaleUtilization, "autoscale-utilization", cfg.AutoscaleUtilization, "Cluster utilization that must persist for a cooldown before an empty node is removed")
+	flag.Var(&cfg.AutoscaleCooldown, "autoscale-cooldown", "Minimum time between scaling actions (e.g. 5s)")
+	flag.IntVar(&cfg.AutoscaleMaxNodes, "autoscale-max-nodes", cfg.AutoscaleMaxNodes, "Largest cluster the autoscaler grows to (0 for no limit)")
+	flag.IntVar(&cfg.AutoscaleMinNodes, "autoscale-min-nodes", cfg.AutoscaleMinNodes, "Smallest cluster the autoscaler shrinks to")
+	flag.Float64Var(&cfg.TargetUtilization, "target-util", cfg.TargetUtilization, "Adjust the arrival rate to hold average node utilization at this fraction (e.g. 0.8) and report the rate it settles at; 0 arrives at a fixed rate")
+	flag.BoolVar(&cfg.Index, "index", cfg.Index, "Index nodes by free capacity so schedulers skip nodes that cannot fit (faster on large clusters)")
+	flag.BoolVar(&cfg.Estimate, "estimate", cfg.Estimate, "Print an analytical estimate of how many containers the cluster holds and its bottleneck resource, then exit")
+	flag.BoolVar(&cfg.Compare, "compare", cfg.Compare, "Run every scheduler on the same seeded workload and print a side-by-side comparison")
+	flag.StringVar(&cfg.Record, "record", cfg.Record, "Write every placement decision of the run to this golden file, for -verify")
+	flag.StringVar(&cfg.Verify, "verify", cfg.Verify, "Replay the run recorded in this golden file, with its seed unless -seed is given, and exit with 1 if any container lands elsewhere")
+	flag.Var(&cfg.RebalanceInterval, "rebalance-interval", "Interval between rebalancing passes (e.g. 10s); 0 disables rebalancing")
 	flag.Parse()
 
-	if *verbose {
-		log.SetOutput(os.Stdout)
-	} else {
-		logFile, err := os.Create("scheduler.log")
+	// Load the config file, then re-apply any flags that were given explicitly
+	if *configFile != "" {
+		explicit := make(map[string]string)
+		flag.Visit(func(f *flag.Flag) {
+			explicit[f.Name] = f.Value.String()
+		})
+
+		loaded, err := config.Load(*configFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+			os.Exit(1)
+		}
+		*cfg = *loaded
+
+		for name, value := range explicit {
+			if err := flag.Set(name, value); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to apply -%s=%s over config %s: %v\n", name, value, *configFile, err)
+				os.Exit(1)
+			}
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid configuration: %v\n", err)
+		os.Exit(1)
+	}
+	if cfg.Autoscale {
+		if _, err := newAutoscalePolicy(cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid configuration: autoscale: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Verbose only lowers the level; where the log goes is up to -log-file
+	logOutput := io.Writer(os.Stdout)
+	if cfg.LogFile != "-" {
+		logFile, err := os.Create(cfg.LogFile)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to create log file: %v\n", err)
 			os.Exit(1)
 		}
 		defer logFile.Close()
-		log.SetOutput(logFile)
+		logOutput = logFile
+	}
+	log.SetOutput(logOutput)
+	level := logging.Info
+	if cfg.Verbose {
+		level = logging.Debug
 	}
+	logging.SetDefault(logging.New(logOutput, level))
 
-	log.Printf("Starting container scheduler with %s algorithm", *schedulerType)
-	log.Printf("Using workload file: %s", *workloadFile)
-	log.Printf("Running on %d CPU cores", runtime.NumCPU())
+	logging.Infof("Starting container scheduler with %s algorithm", cfg.Scheduler)
+	logging.Infof("Using workload file: %s", cfg.Workload)
+	logging.Infof("Running on %d CPU cores", runtime.NumCPU())
+
+	if cfg.Estimate {
+		runEstimate(cfg)
+		return
+	}
 
-	// Initialize the workload generator
-	workloadGen, err := workLoad.NewWorkloadFromFile(*workloadFile)
+	if cfg.Compare {
+		runComparison(cfg)
+		return
+	}
+
+	workloadGen, stream, err := newWorkload(cfg)
 	if err != nil {
 		log.Fatalf("Failed to initialize workload: %v", err)
 	}
+	if stream != nil {
+		defer stream.Close()
+	}
+	// A verified run replays the golden run's seed unless given another
+	var golden *Golden
+	if cfg.Verify != "" {
+		golden, err = loadGolden(cfg.Verify)
+		if err != nil {
+			log.Fatalf("Failed to load golden file: %v", err)
+		}
+		if golden.Scheduler != cfg.Scheduler {
+			log.Fatalf("Golden file %s was recorded with the %s scheduler, not %s", cfg.Verify, golden.Scheduler, cfg.Scheduler)
+		}
+		if cfg.Seed == 0 {
+			cfg.Seed = golden.Seed
+		}
+	}
+	// Draw a clock seed up front so the run manifest can record it
+	if cfg.Seed == 0 {
+		cfg.Seed = time.Now().UnixNano()
+	}
+	logging.Infof("Using workload seed: %d", cfg.Seed)
+
+	// Re-read the workload file on SIGHUP so templates can be edited mid-run
+	if reloadable, ok := workloadGen.(*workLoad.FileWorkloadGenerator); ok {
+		reload := make(chan os.Signal, 1)
+		signal.Notify(reload, syscall.SIGHUP)
+		go func() {
+			for range reload {
+				if err := reloadable.Reload(); err != nil {
+					logging.Warnf("Failed to reload workload, keeping current templates: %v", err)
+				} else {
+					logging.Infof("Reloaded workload file: %s", cfg.Workload)
+				}
+			}
+		}()
+	}
 
 	// Initialize the chosen scheduler
-	var sched scheduler.Scheduler
-	switch *schedulerType {
-	case "binpack":
-		sched = scheduler.NewBinPackScheduler()
-	case "spread":
-		sched = scheduler.NewSpreadScheduler()
-	case "adaptive":
-		sched = scheduler.NewAdaptiveScheduler()
-	default:
-		log.Fatalf("Unknown scheduler type: %s", *schedulerType)
+	sched, err := newScheduler(cfg)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	scenario, err := newScenario(cfg, sched)
+	if err != nil {
+		log.Fatalf("Failed to load cluster: %v", err)
 	}
+	scenario.Generator = workloadGen
+
+	// Create metrics collector, streaming straight to the output file if asked
+	var collector *metrics.MetricsCollector
+	if cfg.Stream {
+		streamFile, err := os.Create(cfg.Output)
+		if err != nil {
+			log.Fatalf("Failed to create output file: %v", err)
+		}
+		defer streamFile.Close()
+		collector = metrics.NewStreamingCollector(streamFile)
+	} else if cfg.MaxEvents > 0 {
+		collector = metrics.NewCappedCollector(cfg.MaxEvents)
+	} else {
+		collector = metrics.NewCollector()
+	}
+
+	// Export live metrics in Prometheus format when serving the API
+	var recorder metrics.Collector = collector
+	var exporter *metrics.PrometheusExporter
+	if cfg.Serve != "" {
+		exporter = metrics.NewPrometheusExporter(collector)
+		recorder = exporter
+	}
+	scenario.Collector = recorder
+
+	// With an output directory every result file goes there under its
+	// conventional name instead of next to the output file
+	outputBase := strings.TrimSuffix(cfg.Output, filepath.Ext(cfg.Output))
+	artifactPath := func(name string) string {
+		if cfg.OutputDir != "" {
+			return filepath.Join(cfg.OutputDir, name)
+		}
+		return outputBase + "_" + name
+	}
+	if cfg.OutputDir != "" {
+		if err := os.MkdirAll(cfg.OutputDir, 0755); err != nil {
+			log.Fatalf("Failed to create output directory: %v", err)
+		}
+	}
+
+	// If the run dies before its results are saved, write whatever was
+	// collected to a recovery file so a long run is not lost entirely
+	recoveryFile := artifactPath("recovery.csv")
+	saved := cfg.Stream // streamed events are already on disk
+	fatalf := func(format string, args ...interface{}) {
+		if !saved {
+			saveRecovery(collector, recoveryFile)
+		}
+		log.Fatalf(format, args...)
+	}
+	defer func() {
+		if saved {
+			return
+		}
+		r := recover()
+		saveRecovery(collector, recoveryFile)
+		if r != nil {
+			panic(r)
+		}
+	}()
+
+	var server *api.Server
+	scenario.Setup = func(b *benchmark.Benchmark) error {
+		if err := configureBenchmark(b, cfg, sched); err != nil {
+			return err
+		}
 
-	// Create metrics collector
-	collector := metrics.NewCollector()
+		// Serve the interactive API alongside the run if requested
+		if cfg.Serve != "" {
+			server = api.NewServer(b)
+			server.SetConfiguredScheduler(cfg.Scheduler, func() (scheduler.Scheduler, error) {
+				return newScheduler(cfg)
+			})
+			exporter.SetNodeInspector(b.InspectNodes)
+			server.Handle("/metrics", exporter)
+			if err := server.Start(cfg.Serve); err != nil {
+				return fmt.Errorf("failed to start API server: %v", err)
+			}
+			fmt.Printf("Serving API on %s\n", cfg.Serve)
+		}
+		return nil
+	}
 
 	// Run benchmark
-	benchmark := benchmark.NewBenchmark(sched, workloadGen, collector)
-	fmt.Printf("Starting benchmark for %d seconds...\n", *duration)
-	benchmark.Run(time.Duration(*duration) * time.Second)
+	fmt.Printf("Starting benchmark for %d seconds...\n", cfg.Duration)
+	started := time.Now()
+	if _, err := benchmark.RunScenario(scenario); err != nil {
+		fatalf("Benchmark failed: %v", err)
+	}
+	finished := time.Now()
+
+	// Stop accepting injected containers before the results are read
+	if server != nil {
+		server.Close()
+	}
+
+	// Persist what the adaptive scheduler learned for the next run
+	if adaptive, ok := scheduler.Unwrap(sched).(*scheduler.AdaptiveScheduler); ok && cfg.AdaptiveState != "" {
+		if err := adaptive.SaveState(cfg.AdaptiveState); err != nil {
+			logging.Errorf("Failed to save adaptive state: %v", err)
+		} else {
+			logging.Infof("Saved adaptive state to %s", cfg.AdaptiveState)
+		}
+	}
 
 	// Output results
 	results := collector.GetResults()
-	fmt.Printf("Benchmark complete. Saving results to %s\n", *outputFile)
-	err = results.SaveToFile(*outputFile)
+	if cfg.OutputDir != "" {
+		fmt.Printf("Benchmark complete. Saving results to %s\n", cfg.OutputDir)
+		if err := results.SaveAll(cfg.OutputDir); err != nil {
+			fatalf("Failed to save results: %v", err)
+		}
+		saved = true
+	} else if cfg.Stream {
+		fmt.Printf("Benchmark complete. Results were streamed to %s\n", cfg.Output)
+		if err := collector.StreamErr(); err != nil {
+			logging.Errorf("Failed to stream results: %v", err)
+		}
+	} else {
+		fmt.Printf("Benchmark complete. Saving results to %s\n", cfg.Output)
+		err = results.SaveToFile(cfg.Output)
+		if err != nil {
+			fatalf("Failed to save results: %v", err)
+		}
+		saved = true
+	}
+	if results.DroppedEvents > 0 {
+		fmt.Printf("Only the last %d scheduling events were kept (%d dropped)\n", len(results.Events), results.DroppedEvents)
+	}
+	if cfg.OutputDir == "" {
+		if err := results.SaveTypeStatsToFile(outputBase + "_types.csv"); err != nil {
+			logging.Errorf("Failed to save per-type results: %v", err)
+		}
+		if !cfg.Stream {
+			if err := results.SaveTimelineCSV(outputBase + "_timeline.csv"); err != nil {
+				logging.Errorf("Failed to save container timeline: %v", err)
+			}
+		}
+		if err := results.SaveThroughputCSV(outputBase + "_throughput.csv"); err != nil {
+			logging.Errorf("Failed to save throughput series: %v", err)
+		}
+		if len(results.StateTransitions) > 0 {
+			if err := results.SaveStateTransitionsCSV(outputBase + "_states.csv"); err != nil {
+				logging.Errorf("Failed to save scheduler state transitions: %v", err)
+			}
+		}
+		if len(results.ReplicaSeries) > 0 {
+			if err := results.SaveReplicaCSV(outputBase + "_replicas.csv"); err != nil {
+				logging.Errorf("Failed to save replica series: %v", err)
+			}
+		}
+		if cfg.Autoscale {
+			if err := results.SaveNodeCountCSV(outputBase + "_nodes.csv"); err != nil {
+				logging.Errorf("Failed to save node count series: %v", err)
+			}
+		}
+		if len(results.LoadTarget.Samples) > 0 {
+			if err := results.SaveLoadTargetCSV(outputBase + "_load.csv"); err != nil {
+				logging.Errorf("Failed to save arrival rate control series: %v", err)
+			}
+		}
+	}
+	learned := learnedInterference(sched)
+	if learned != nil {
+		if err := learned.SaveHistoryCSV(artifactPath("interference.csv")); err != nil {
+			logging.Errorf("Failed to save interference history: %v", err)
+		}
+	}
+	manifestPath := artifactPath("manifest.json")
+	manifest, err := newManifest(cfg, started, finished)
+	if err == nil {
+		err = manifest.Save(manifestPath)
+	}
 	if err != nil {
-		log.Fatalf("Failed to save results: %v", err)
+		logging.Errorf("Failed to save run manifest: %v", err)
+		manifestPath = ""
+	}
+
+	if cfg.Record != "" {
+		if err := newGolden(cfg, results).Save(cfg.Record); err != nil {
+			fatalf("Failed to record placements: %v", err)
+		}
+		fmt.Printf("Recorded %d placement decisions to %s\n", len(results.Events), cfg.Record)
 	}
 
 	fmt.Println("Summary of results:")
-	fmt.Printf("  Scheduler type: %s\n", *schedulerType)
+	fmt.Printf("  Scheduler type: %s\n", cfg.Scheduler)
+	if results.Warmup > 0 {
+		fmt.Printf("  Warmup: first %v excluded (%d placements, %d failures)\n",
+			results.Warmup, results.WarmupPlacements, results.WarmupFailures)
+	}
 	fmt.Printf("  Containers scheduled: %d\n", results.ContainersScheduled)
-	fmt.Printf("  Average scheduling latency: %.2fms\n", results.AverageLatency)
+	fmt.Printf("  Average scheduling latency: %.2fms (scheduler compute time)\n", results.AverageLatency)
+	fmt.Printf("  Queue delay: %.2fms average, %.2fms p95 (arrival or eviction until placement)\n",
+		results.AverageQueueDelay, results.QueueDelayPercentile(95))
+	fmt.Printf("  Average startup time: %.2fms (time to ready: %.2fms)\n", results.AverageStartupTime, results.AverageTimeToReady)
+	fmt.Printf("  Image cache hits: %d, misses: %d\n", results.ImageCacheHits, results.ImageCacheMisses)
 	fmt.Printf("  Resource utilization: %.2f%%\n", results.ResourceUtilization*100)
+	printNodeUtilization("Node utilization at end of run", results.NodeUtilization)
+	printNodeUtilization("Node utilization averaged over the run", results.NodeUtilizationAverage)
 	fmt.Printf("  Scheduling failures: %d\n", results.SchedulingFailures)
+	if stream != nil {
+		fmt.Printf("  Workload lines read: %d (%d malformed, skipped)\n", stream.Lines(), stream.Malformed())
+		if err := stream.Err(); err != nil {
+			fmt.Printf("  Workload stream ended early: %v\n", err)
+		}
+	}
+	if results.OrderedContainers > 0 || results.OrderingCancelled > 0 {
+		fmt.Printf("  Ordered group members: %d, average wait for predecessor: %.2fms, cancelled: %d\n",
+			results.OrderedContainers, results.AverageOrderingDelay, results.OrderingCancelled)
+	}
+	if reclaim := results.MemoryReclaim; reclaim.Events > 0 {
+		fmt.Printf("  Memory reclaimed: %.1f MB in %d reclaims (largest %.1f MB), %d of them sparing the node any OOM kill\n",
+			reclaim.Total, reclaim.Events, reclaim.Largest, reclaim.Relieved)
+	}
+	if results.OOMKills > 0 {
+		fmt.Printf("  OOM kills: %d\n", results.OOMKills)
+	}
+	if target := results.LoadTarget; len(target.Samples) > 0 {
+		utilization, rate := target.Steady()
+		fmt.Printf("  Utilization target %.1f%%: steady state %.1f%% at %.2f arrivals/s (last quarter of the run)\n",
+			target.Target*100, utilization*100, rate)
+	}
+	if results.SidecarOverhead.CPU > 0 || results.SidecarOverhead.Memory > 0 {
+		fmt.Printf("  Sidecar overhead: %.2f%% of scheduled CPU, %.2f%% of scheduled memory\n",
+			results.SidecarOverhead.CPU*100, results.SidecarOverhead.Memory*100)
+	}
+	if results.PinnedPlacements > 0 || results.PinningFailures > 0 {
+		fmt.Printf("  NUMA-pinned placements: %d (%d split across sockets), %d failures despite aggregate capacity\n",
+			results.PinnedPlacements, results.CrossSocketPlacements, results.PinningFailures)
+	}
+	if results.Disruptions > 0 {
+		fmt.Printf("  Disruption: %d evictions, total cost %.2f\n", results.Disruptions, results.DisruptionCost)
+	}
+	if plans := results.PreemptionPlans; plans.Plans > 0 {
+		fmt.Printf("  Preemption plans: %d, evicting %d containers (priority sum %d, cost %.2f)\n",
+			plans.Plans, plans.Victims, plans.Priority, plans.Cost)
+	}
+	if results.Rejections > 0 {
+		fmt.Printf("  Rejected placements: %d (%d rescheduled on another node)\n", results.Rejections, results.RescheduledAfterReject)
+	}
+	if cfg.MaxRetries > 0 {
+		fmt.Printf("  Scheduled after retry: %d\n", results.ScheduledAfterRetry)
+	}
+	if results.SchedulingFailures > 0 {
+		fmt.Printf("  Time to first failure: %v\n", results.TimeToFirstFailure.Round(time.Millisecond))
+		if results.SaturationTime > 0 {
+			fmt.Printf("  Saturation point: %v\n", results.SaturationTime.Round(time.Millisecond))
+		} else {
+			fmt.Println("  Saturation point: not reached")
+		}
+	}
+	if optimizing, ok := scheduler.Unwrap(sched).(*scheduler.OptimizingScheduler); ok {
+		start, final := optimizing.Objective()
+		fmt.Printf("  Optimizer objective per wave: %.4f (first-fit-decreasing: %.4f)\n", final, start)
+	}
+	if len(results.PriorityStats) > 0 {
+		priorities := make([]int, 0, len(results.PriorityStats))
+		for priority := range results.PriorityStats {
+			priorities = append(priorities, priority)
+		}
+		sort.Sort(sort.Reverse(sort.IntSlice(priorities)))
+		fmt.Println("  Placement by priority:")
+		for _, priority := range priorities {
+			stats := results.PriorityStats[priority]
+			fmt.Printf("    priority %d: %d scheduled, %d failed, %d preempted, %d OOM-killed, avg node health %.2f\n",
+				priority, stats.Scheduled, stats.Failures, stats.Preempted, stats.OOMKilled, stats.AverageNodeHealth())
+		}
+	}
+	if len(results.QoSStats) > 0 {
+		fmt.Println("  Placement by QoS class:")
+		for _, class := range container.QoSClasses {
+			stats, ok := results.QoSStats[class]
+			if !ok {
+				continue
+			}
+			fmt.Printf("    %s: %d scheduled, %d failed, %d evicted (%d preempted, %d OOM-killed)\n",
+				class, stats.Scheduled, stats.Failures, stats.Evicted(), stats.Preempted, stats.OOMKilled)
+		}
+	}
+	if len(results.SourceStats) > 0 {
+		sources := make([]string, 0, len(results.SourceStats))
+		for source := range results.SourceStats {
+			sources = append(sources, source)
+		}
+		sort.Strings(sources)
+		fmt.Println("  Placement by workload source:")
+		for _, source := range sources {
+			stats := results.SourceStats[source]
+			fmt.Printf("    %s: %d scheduled, %d failed (%.1f%% success)\n",
+				source, stats.Scheduled, stats.Failures, stats.SuccessRate()*100)
+		}
+	}
+	fmt.Printf("  Fairness index: %.3f (worst served: %s)\n", results.FairnessIndex, results.WorstServedType)
+	if learned != nil {
+		printInterference(learned)
+	}
+	printStateTransitions(results.StateTransitions)
+	printReplicas(results.ReplicaStats)
+	if len(results.NodeHealth) > 0 {
+		names := make([]string, 0, len(results.NodeHealth))
+		for name := range results.NodeHealth {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Println("  Final node health:")
+		for _, name := range names {
+			fmt.Printf("    %s: %.2f\n", name, results.NodeHealth[name])
+		}
+	}
+	fmt.Printf("  Packing efficiency: %.2f%%\n", results.PackingEfficiency*100)
+	fmt.Printf("  Stranded capacity: CPU %.2f%%, memory %.2f%%, network %.2f%%, IO %.2f%%, disk %.2f%%\n",
+		results.Stranded.CPU*100, results.Stranded.Memory*100, results.Stranded.Network*100,
+		results.Stranded.IO*100, results.Stranded.Disk*100)
+	if contention := results.NetworkContention; contention.ContendedTime > 0 {
+		fmt.Printf("  NIC contention: %.2f%% of network demand lost on average (%.1f Mbps), worst node %.2f%%, %v node time contended\n",
+			contention.MeanDegradation*100, contention.LostThroughput, contention.PeakDegradation*100, contention.ContendedTime)
+	}
+	if gpu := results.GPU; gpu.Samples > 0 {
+		fmt.Printf("  GPU memory utilization: %.2f%% average, %.2f%% peak\n", gpu.MeanMemory*100, gpu.PeakMemory*100)
+		fmt.Printf("  GPUs in use: %.2f%% average, %.2f%% peak\n", gpu.MeanAllocated*100, gpu.PeakAllocated*100)
+	}
+	fmt.Printf("  Cluster energy: %.2f Wh\n", results.TotalEnergy/3600.0)
+	fmt.Printf("  Cluster cost: $%.4f\n", results.TotalCost)
+	if cfg.Autoscale {
+		fmt.Printf("  Autoscaling: %d nodes added, %d removed, peak %d nodes\n",
+			results.ScaleUps, results.ScaleDowns, results.PeakNodes)
+	}
+	if cfg.RebalanceInterval > 0 {
+		fmt.Printf("  Container migrations: %d\n", len(results.Migrations))
+	}
+	if manifestPath != "" {
+		fmt.Printf("  Run manifest: %s\n", manifestPath)
+	}
+
+	if golden != nil && !verifyGolden(cfg.Verify, golden, newGolden(cfg, results)) {
+		os.Exit(1)
+	}
+}
+
+// printStateTransitions lists the scheduler's phase changes and counts its
+// other state changes, which are in the _states.csv file
+func printStateTransitions(transitions []metrics.StateTransition) {
+	if len(transitions) == 0 {
+		return
+	}
+	fmt.Printf("  Scheduler state changes: %d\n", len(transitions))
+	for _, t := range transitions {
+		if t.Kind == "phase" {
+			fmt.Printf("    %7.1fs phase %s -> %s\n", t.Offset.Seconds(), t.From, t.To)
+		}
+	}
+}
+
+// printReplicas summarises each type with replica limits; the instance
+// counts over time are in the _replicas.csv file
+func printReplicas(stats map[string]metrics.ReplicaStats) {
+	if len(stats) == 0 {
+		return
+	}
+	types := make([]string, 0, len(stats))
+	for containerType := range stats {
+		types = append(types, containerType)
+	}
+	sort.Strings(types)
+	fmt.Println("  Replicas by type (lowest / highest live):")
+	for _, containerType := range types {
+		s := stats[containerType]
+		fmt.Printf("    %s: %d / %d, %d replacements, %d arrivals turned away at the maximum\n",
+			containerType, s.Lowest, s.Highest, s.Replacements, s.Capped)
+	}
+}
+
+// newWorkload opens the workload named by cfg: a JSON Lines stream, which
+// is also returned on its own so it can be closed and its malformed lines
+// reported, or a template file loaded (rather than passing the definition)
+// so it can be reloaded. Workload sources in cfg are mixed instead.
+func newWorkload(cfg *config.Config) (workLoad.WorkloadGenerator, *workLoad.JSONLinesWorkloadGenerator, error) {
+	if len(cfg.WorkloadSources) > 0 {
+		sources := make([]workLoad.Source, 0, len(cfg.WorkloadSources))
+		for _, source := range cfg.WorkloadSources {
+			generator, err := workLoad.NewWorkloadFromFile(source.File)
+			if err != nil {
+				return nil, nil, err
+			}
+			sources = append(sources, workLoad.Source{
+				Name:      source.SourceName(),
+				Generator: generator,
+				Weight:    source.Weight,
+				Start:     time.Duration(source.Start),
+				Period:    time.Duration(source.Period),
+				Active:    time.Duration(source.Active),
+			})
+		}
+		composite, err := workLoad.NewCompositeWorkload(sources)
+		if err != nil {
+			return nil, nil, err
+		}
+		return composite, nil, nil
+	}
+	if workLoad.IsJSONLines(cfg.Workload) {
+		stream, err := workLoad.NewJSONLinesWorkload(cfg.Workload)
+		if err != nil {
+			return nil, nil, err
+		}
+		return stream, stream, nil
+	}
+	
+	generator, err := workLoad.NewWorkloadFromFile(cfg.Workload)
+	if err != nil {
+		return nil, nil, err
+	}
+	return generator, nil, nil
+}
+
+// learnedInterference returns the interference model of a learning
+// scheduler, or nil for every other scheduler
+func learnedInterference(sched scheduler.Scheduler) *scheduler.InterferenceModel {
+	if adaptive, ok := scheduler.Unwrap(sched).(*scheduler.AdaptiveScheduler); ok {
+		return adaptive.Interference()
+	}
+	return nil
+}
+
+// printInterference lists the most penalized type pairs with their
+// penalty a quarter, half and three quarters into the run and at the end
+func printInterference(model *scheduler.InterferenceModel) {
+	final := model.Estimates()
+	if len(final) == 0 {
+		return
+	}
+	history := model.History()
+	checkpoints := make([]map[string]float64, 0, 4)
+	for quarter := 1; quarter <= 3 && len(history) > 0; quarter++ {
+		checkpoints = append(checkpoints, history[len(history)*quarter/4].Estimates)
+	}
+	checkpoints = append(checkpoints, final)
+	
+	pairs := make([]string, 0, len(final))
+	for pair := range final {
+		pairs = append(pairs, pair)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if final[pairs[i]] != final[pairs[j]] {
+			return final[pairs[i]] > final[pairs[j]]
+		}
+		return pairs[i] < pairs[j]
+	})
+	if len(pairs) > 5 {
+		pairs = pairs[:5]
+	}
+	
+	fmt.Printf("  Learned interference (%d pairs, most penalized; at 25%% / 50%% / 75%% / end of run):\n", len(final))
+	for _, pair := range pairs {
+		values := make([]string, len(checkpoints))
+		for i, estimates := range checkpoints {
+			penalty, ok := estimates[pair]
+			if !ok {
+				penalty = model.Prior()
+			}
+			values[i] = fmt.Sprintf("%.3f", penalty)
+		}
+		fmt.Printf("    %-16s %s\n", pair, strings.Join(values, " -> "))
+	}
+}
+
+// printNodeUtilization prints how utilization is spread across nodes
+func printNodeUtilization(title string, stats metrics.NodeUtilizationStats) {
+	fmt.Printf("  %s (min / median / p90 / max):\n", title)
+	rows := []struct {
+		name         string
+		distribution metrics.Distribution
+	}{
+		{"overall", stats.Overall},
+		{"CPU", stats.CPU},
+		{"memory", stats.Memory},
+		{"network", stats.Network},
+		{"IO", stats.IO},
+		{"disk", stats.Disk},
+	}
+	for _, row := range rows {
+		d := row.distribution
+		fmt.Printf("    %-8s %5.1f%% / %5.1f%% / %5.1f%% / %5.1f%%\n", row.name, d.Min*100, d.Median*100, d.P90*100, d.Max*100)
+	}
+}
+
+// saveRecovery writes the events collected so far to filename, falling back
+// to the working directory if the output directory is not writable. It is
+// best effort: the run is already failing, so errors are only logged.
+func saveRecovery(collector *metrics.MetricsCollector, filename string) {
+	results := collector.GetResults()
+	if err := results.SaveToFile(filename); err != nil {
+		logging.Errorf("Failed to write recovery file %s: %v", filename, err)
+		filename = filepath.Base(filename)
+		if err := results.SaveToFile(filename); err != nil {
+			logging.Errorf("Failed to write recovery file %s: %v", filename, err)
+			return
+		}
+	}
+	logging.Infof("Wrote partial results to recovery file %s", filename)
+	fmt.Fprintf(os.Stderr, "Partial results written to %s\n", filename)
+}
+
+// newScheduler builds the scheduler named by cfg.Scheduler, wrapped in the
+// image-locality option if enabled
+func newScheduler(cfg *config.Config) (scheduler.Scheduler, error) {
+	sched, err := newBaseScheduler(cfg)
+	if err != nil || !cfg.ImageLocality {
+		return sched, err
+	}
+	return scheduler.NewImageLocalityScheduler(sched), nil
+}
+
+// newBaseScheduler creates the registered scheduler named by cfg.Scheduler
+// with its options and applies the run settings that apply to its type
+func newBaseScheduler(cfg *config.Config) (scheduler.Scheduler, error) {
+	sched, err := scheduler.New(cfg.Scheduler, schedulerOptions(cfg))
+	if err != nil {
+		return nil, err
+	}
+
+	switch s := sched.(type) {
+	case *scheduler.AdaptiveScheduler:
+		if cfg.AdaptiveState != "" {
+			if err := s.LoadState(cfg.AdaptiveState); err != nil {
+				logging.Warnf("Could not load adaptive state from %s, starting fresh: %v", cfg.AdaptiveState, err)
+			} else {
+				logging.Infof("Loaded adaptive state from %s", cfg.AdaptiveState)
+			}
+		}
+	case *scheduler.OptimizingScheduler:
+		if cfg.Seed != 0 {
+			s.SetSeed(cfg.Seed)
+		}
+	}
+
+	return sched, nil
+}
+
+// schedulerOptions returns the options of the scheduler named by
+// cfg.Scheduler: the top-level settings (and flags) that apply to it,
+// overridden by its section of scheduler_config
+func schedulerOptions(cfg *config.Config) scheduler.Options {
+	opts := scheduler.Options{}
+	switch cfg.Scheduler {
+	case "binpack", "spread", "hybrid":
+		opts["dominant"] = cfg.Dominant
+		opts["resource_weights"] = cfg.ResourceWeights[:]
+		if cfg.Scheduler == "hybrid" {
+			opts["pack_threshold"] = cfg.PackThreshold
+		}
+	case "saturationaware":
+		opts["knee"] = cfg.SaturationKnee
+		opts["exponent"] = cfg.SaturationExponent
+	case "optimizing":
+		opts["iterations"] = cfg.AnnealIterations
+		opts["cooling"] = cfg.AnnealCooling
+	}
+	for key, value := range cfg.SchedulerConfig[cfg.Scheduler] {
+		opts[key] = value
+	}
+	return opts
+}
+
+// newScenario describes the run in cfg for benchmark.RunScenario, loading
+// the cluster file if one is given; the caller supplies the workload
+func newScenario(cfg *config.Config, sched scheduler.Scheduler) (benchmark.ScenarioConfig, error) {
+	scenario := benchmark.ScenarioConfig{
+		Scheduler:   sched,
+		Duration:    time.Duration(cfg.Duration) * time.Second,
+		GenerateFor: time.Duration(cfg.GenerateFor),
+		Seed:        cfg.Seed,
+		Cleanup:     newCleanupPolicy(cfg),
+	}
+
+	if cfg.Cluster != "" {
+		definition, err := benchmark.LoadClusterDefinition(cfg.Cluster)
+		if err != nil {
+			return scenario, err
+		}
+		scenario.Cluster = definition
+		logging.Infof("Using cluster file: %s", cfg.Cluster)
+	}
+
+	return scenario, nil
+}
+
+// newCleanupPolicy builds the completion policy named by cfg.Cleanup
+func newCleanupPolicy(cfg *config.Config) benchmark.CleanupPolicy {
+	switch cfg.Cleanup {
+	case "lifetime":
+		return benchmark.NewLifetimeBased()
+	case "never":
+		return benchmark.NewNeverRemove()
+	default:
+		churn := benchmark.NewRandomChurn(cfg.ChurnRate)
+		if cfg.Seed != 0 {
+			churn.SetSeed(cfg.Seed)
+		}
+		return churn
+	}
+}
+
+// newAutoscalePolicy builds the autoscaler settings in cfg, taking the node
+// template from the cluster file or the default cluster
+func newAutoscalePolicy(cfg *config.Config) (benchmark.AutoscalePolicy, error) {
+	policy := benchmark.AutoscalePolicy{
+		FailureThreshold: cfg.AutoscaleFailures,
+		LowUtilization:   cfg.AutoscaleUtilization,
+		Cooldown:         time.Duration(cfg.AutoscaleCooldown),
+		MaxNodes:         cfg.AutoscaleMaxNodes,
+		MinNodes:         cfg.AutoscaleMinNodes,
+	}
+
+	definition := benchmark.DefaultClusterDefinition()
+	if cfg.Cluster != "" {
+		var err error
+		definition, err = benchmark.LoadClusterDefinition(cfg.Cluster)
+		if err != nil {
+			return policy, err
+		}
+	}
+
+	template, err := definition.FindTemplate(cfg.AutoscaleTemplate)
+	policy.Template = template
+	return policy, err
+}
+
+// configureBenchmark applies the run settings from cfg to b, failing on
+// the first it cannot apply
+func configureBenchmark(b *benchmark.Benchmark, cfg *config.Config, sched scheduler.Scheduler) error {
+	b.SetRebalanceInterval(time.Duration(cfg.RebalanceInterval))
+	b.SetBatchSize(cfg.BatchSize)
+	b.SetVerbose(cfg.Verbose)
+	b.SetNodeIndex(cfg.Index)
+	b.SetPreemption(cfg.Preemption)
+	b.SetFairQueuing(cfg.FairQueue)
+	b.SetAccelerated(cfg.Accelerate)
+	b.SetWarmup(time.Duration(cfg.Warmup))
+	b.SetUsageNoise(cfg.UsageNoise, cfg.Seed)
+	b.SetLoadSmoothing(cfg.LoadSmoothing)
+	b.SetRetryPolicy(cfg.MaxRetries, time.Duration(cfg.RetryBackoff))
+	if err := b.SetUtilizationTarget(cfg.TargetUtilization); err != nil {
+		return err
+	}
+	if cfg.Autoscale {
+		policy, err := newAutoscalePolicy(cfg)
+		if err == nil {
+			err = b.SetAutoscaler(policy)
+		}
+		if err != nil {
+			logging.Warnf("Autoscaling disabled: %v", err)
+		}
+	}
+	if adaptive, ok := scheduler.Unwrap(sched).(*scheduler.AdaptiveScheduler); ok && cfg.IntensityFraction > 0 {
+		adaptive.UseRelativeIntensity(b.Nodes(), cfg.IntensityFraction)
+		intensity := adaptive.Intensity()
+		logging.Infof("Intensity thresholds: %.2f cores, %.0f MB, %.0f Mbps, %.0f IOPS",
+			intensity.CPU, intensity.Memory, intensity.Network, intensity.IO)
+	}
+	return nil
 }
@@ -0,0 +1,112 @@
+// manifest.go - Run manifest for reproducing results
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"runtime"
+	"time"
+
+	"cc_go/pkg/benchmark"
+	"cc_go/pkg/config"
+)
+
+// Manifest records exactly how a result file was produced, so it can be
+// tied back to its configuration and inputs later. The input files are
+// hashed to detect if they changed since.
+type Manifest struct {
+	Scheduler      string         `json:"scheduler"`
+	Seed           int64          `json:"seed"`
+	Duration       int            `json:"duration"` // seconds
+	Workload       string         `json:"workload"`
+	WorkloadSHA256 string         `json:"workload_sha256"`
+	SourcesSHA256  map[string]string `json:"workload_sources_sha256,omitempty"` // by file, for mixed workloads
+	Cluster        string         `json:"cluster"` // empty for the built-in cluster
+	ClusterSHA256  string         `json:"cluster_sha256"`
+	GoVersion      string         `json:"go_version"`
+	Started        time.Time      `json:"started"`
+	Finished       time.Time      `json:"finished"`
+	Config         *config.Config `json:"config"`
+}
+
+// newManifest describes the run in cfg, hashing its workload and cluster
+// files; the built-in cluster is hashed from its definition
+func newManifest(cfg *config.Config, started, finished time.Time) (*Manifest, error) {
+	workloadHash, err := hashFile(cfg.Workload)
+	if err != nil {
+		return nil, err
+	}
+
+	var sourcesHash map[string]string
+	if len(cfg.WorkloadSources) > 0 {
+		sourcesHash = make(map[string]string, len(cfg.WorkloadSources))
+		for _, source := range cfg.WorkloadSources {
+			if sourcesHash[source.File], err = hashFile(source.File); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var clusterHash string
+	if cfg.Cluster != "" {
+		clusterHash, err = hashFile(cfg.Cluster)
+	} else {
+		var data []byte
+		data, err = json.Marshal(benchmark.DefaultClusterDefinition())
+		clusterHash = hashBytes(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &Manifest{
+		Scheduler:      cfg.Scheduler,
+		Seed:           cfg.Seed,
+		Duration:       cfg.Duration,
+		Workload:       cfg.Workload,
+		WorkloadSHA256: workloadHash,
+		SourcesSHA256:  sourcesHash,
+		Cluster:        cfg.Cluster,
+		ClusterSHA256:  clusterHash,
+		GoVersion:      runtime.Version(),
+		Started:        started,
+		Finished:       finished,
+		Config:         cfg,
+	}, nil
+}
+
+func (m *Manifest) Save(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// hashFile streams the file through the hash, so huge JSON Lines workloads
+// are not read into memory; stdin ("-") cannot be re-read and has no hash
+func hashFile(path string) (string, error) {
+	if path == "-" {
+		return "", nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func hashBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,284 @@
+// pkg/api/api.go - HTTP API for interactive benchmark runs
+package api
+
+import (
+	"cc_go/pkg/benchmark"
+	"cc_go/pkg/container"
+	"cc_go/pkg/logging"
+	"cc_go/pkg/node"
+	"cc_go/pkg/scheduler"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"time"
+)
+
+// ContainerSpec is the payload accepted by POST /containers
+type ContainerSpec struct {
+	Name     string  `json:"name"`
+	Image    string  `json:"image"`
+	CPU      float64 `json:"cpu"`
+	Memory   float64 `json:"memory"`
+	Network  float64 `json:"network"`
+	IO       float64 `json:"io"`
+	Disk     float64 `json:"disk"`
+	GPUMemory float64 `json:"gpu_memory"` // GB on a single GPU
+	NodeName string  `json:"node_name"` // pin to the node with this name or ID
+	Type     string  `json:"type"`
+	Priority int     `json:"priority"`
+}
+
+// container creates the container the spec describes
+func (spec ContainerSpec) container() *container.Container {
+	c := container.NewContainer(
+		spec.Name,
+		spec.Image,
+		spec.CPU,
+		spec.Memory,
+		spec.Network,
+		spec.IO,
+		spec.Type,
+		spec.Priority,
+	)
+	c.SetDiskRequest(spec.Disk)
+	c.SetGPUMemoryRequest(spec.GPUMemory)
+	c.SetNodeName(spec.NodeName)
+	return c
+}
+
+type PlacementResponse struct {
+	ContainerID string `json:"container_id"`
+	NodeID      string `json:"node_id,omitempty"`
+	NodeName    string `json:"node_name,omitempty"`
+	Scheduled   bool   `json:"scheduled"`
+	Error       string `json:"error,omitempty"`
+}
+
+// AdmissionResponse answers POST /admission: whether the container fits on
+// any node right now and where each registered scheduler would put it
+type AdmissionResponse struct {
+	FitsAnywhere bool                 `json:"fits_anywhere"`
+	Placements   []AdmissionPlacement `json:"placements"`
+}
+
+type AdmissionPlacement struct {
+	Scheduler  string `json:"scheduler"`
+	Configured bool   `json:"configured,omitempty"`
+	NodeID     string `json:"node_id,omitempty"`
+	NodeName   string `json:"node_name,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+type NodeStatus struct {
+	ID               string  `json:"id"`
+	Name             string  `json:"name"`
+	Utilization      float64 `json:"utilization"`
+	ContainerCount   int     `json:"container_count"`
+	AvailableCPU     float64 `json:"available_cpu"`
+	AvailableMemory  float64 `json:"available_memory"`
+	AvailableNetwork float64 `json:"available_network"`
+	AvailableIO      float64 `json:"available_io"`
+	AvailableDisk    float64 `json:"available_disk"`
+	HealthScore      float64 `json:"health_score"`
+	Cordoned         bool    `json:"cordoned"`
+	Zone             string  `json:"zone,omitempty"`
+}
+
+type Server struct {
+	benchmark  *benchmark.Benchmark
+	mux        *http.ServeMux
+	httpServer *http.Server
+	
+	// The run's scheduler as configured, for admission queries
+	configured    string
+	newConfigured func() (scheduler.Scheduler, error)
+}
+
+func NewServer(b *benchmark.Benchmark) *Server {
+	s := &Server{
+		benchmark: b,
+		mux:       http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("/containers", s.handleContainers)
+	s.mux.HandleFunc("/admission", s.handleAdmission)
+	s.mux.HandleFunc("/nodes", s.handleNodes)
+	s.mux.HandleFunc("/results", s.handleResults)
+	s.httpServer = &http.Server{Handler: s.mux}
+
+	return s
+}
+
+func (s *Server) Handler() http.Handler {
+	return s.httpServer.Handler
+}
+
+// Handle mounts an additional handler, e.g. the Prometheus exporter on
+// /metrics. It must be called before Start.
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// Start begins serving on addr in the background. It returns once the
+// listener is bound so callers can report bind errors immediately.
+func (s *Server) Start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		if err := s.httpServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+			logging.Errorf("API server stopped: %v", err)
+		}
+	}()
+
+	return nil
+}
+
+func (s *Server) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.httpServer.Shutdown(ctx)
+}
+
+func (s *Server) handleContainers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var spec ContainerSpec
+	if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
+		http.Error(w, "invalid container spec: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// The run numbers the container as it is submitted
+	c := spec.container()
+	n, err := s.benchmark.Submit(c)
+	response := PlacementResponse{ContainerID: c.ID()}
+	if err != nil {
+		response.Error = err.Error()
+		writeJSON(w, http.StatusConflict, response)
+		return
+	}
+
+	response.NodeID = n.ID()
+	response.NodeName = n.Name()
+	response.Scheduled = true
+	writeJSON(w, http.StatusCreated, response)
+}
+
+// SetConfiguredScheduler has admission queries answer for the scheduler
+// registered under name with a fresh instance from newScheduler, which
+// should configure it as the run's scheduler is, instead of with defaults
+func (s *Server) SetConfiguredScheduler(name string, newScheduler func() (scheduler.Scheduler, error)) {
+	s.configured = name
+	s.newConfigured = newScheduler
+}
+
+// handleAdmission answers where the container would go without placing it.
+// The cluster is cloned under the benchmark lock and every scheduler then
+// works on the clones, so the run is neither touched nor held up. Each
+// scheduler is a fresh instance, so the run's scheduler does not learn from
+// the query; the configured one is set up like it but without what it has
+// learned during the run.
+func (s *Server) handleAdmission(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var spec ContainerSpec
+	if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
+		http.Error(w, "invalid container spec: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var nodes []*node.Node
+	s.benchmark.InspectNodes(func(live []*node.Node) {
+		nodes = node.CloneCluster(live)
+	})
+
+	c := spec.container()
+	response := AdmissionResponse{
+		FitsAnywhere: scheduler.CanFitAnywhere(c, nodes),
+		Placements:   make([]AdmissionPlacement, 0),
+	}
+	for _, name := range scheduler.List() {
+		placement := AdmissionPlacement{Scheduler: name, Configured: name == s.configured}
+		n, err := s.dryRun(name, c, nodes)
+		if err != nil {
+			placement.Error = err.Error()
+		} else {
+			placement.NodeID = n.ID()
+			placement.NodeName = n.Name()
+		}
+		response.Placements = append(response.Placements, placement)
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// dryRun places c on nodes with a fresh scheduler registered under name,
+// configured as the run's if it is the run's scheduler
+func (s *Server) dryRun(name string, c *container.Container, nodes []*node.Node) (*node.Node, error) {
+	var sched scheduler.Scheduler
+	var err error
+	if name == s.configured && s.newConfigured != nil {
+		sched, err = s.newConfigured()
+	} else {
+		sched, err = scheduler.New(name, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return scheduler.DryRunSchedule(sched, c, nodes)
+}
+
+func (s *Server) handleNodes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	snapshot := s.benchmark.Snapshot()
+	statuses := make([]NodeStatus, 0, len(snapshot.Nodes))
+	for _, n := range snapshot.Nodes {
+		statuses = append(statuses, NodeStatus{
+			ID:               n.ID,
+			Name:             n.Name,
+			Utilization:      n.Utilization,
+			ContainerCount:   n.ContainerCount,
+			AvailableCPU:     n.CPU.Available(),
+			AvailableMemory:  n.Memory.Available(),
+			AvailableNetwork: n.Network.Available(),
+			AvailableIO:      n.IO.Available(),
+			AvailableDisk:    n.Disk.Available(),
+			HealthScore:      n.HealthScore,
+			Cordoned:         n.Cordoned,
+			Zone:             n.Zone,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, statuses)
+}
+
+func (s *Server) handleResults(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.benchmark.Results())
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logging.Errorf("Failed to encode API response: %v", err)
+	}
+}
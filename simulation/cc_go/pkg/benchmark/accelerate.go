@@ -0,0 +1,111 @@
+// pkg/benchmark/accelerate.go - Real-time and simulated-clock run loops
+package benchmark
+
+import (
+	"cc_go/pkg/clock"
+	"time"
+)
+
+// periodicTask is one of the benchmark's recurring activities; tick reports
+// false once the task has nothing left to do
+type periodicTask struct {
+	interval time.Duration
+	tick     func() bool
+}
+
+// SetAccelerated runs the benchmark on a simulated clock that jumps straight
+// to the next due task instead of sleeping, so the whole duration completes
+// as fast as the ticks can execute. Scheduling latency is still measured on
+// the wall clock and reflects real scheduler compute time.
+func (b *Benchmark) SetAccelerated(enabled bool) {
+	b.accelerated = enabled
+}
+
+// tasks lists the periodic activities in the order they run when due at
+// the same instant
+func (b *Benchmark) tasks() []periodicTask {
+	tasks := []periodicTask{
+		{arrivalInterval, b.scheduleContainers}, // rate limiting - don't flood with containers
+		{1 * time.Second, b.cleanupContainers},
+		{clusterSampleInterval, b.sampleCluster},
+	}
+	if b.noiseAmplitude > 0 {
+		tasks = append(tasks, periodicTask{noiseInterval, b.fluctuateUsage})
+	}
+	tasks = append(tasks, periodicTask{healthInterval, b.updateHealth})
+	if b.rebalanceInterval > 0 {
+		tasks = append(tasks, periodicTask{b.rebalanceInterval, b.rebalanceContainers})
+	}
+	if b.loadTarget != nil {
+		tasks = append(tasks, periodicTask{loadControlInterval, b.controlLoad})
+	}
+	return tasks
+}
+
+func (b *Benchmark) runRealTime(duration time.Duration) {
+	for _, task := range b.tasks() {
+		b.wg.Add(1)
+		go b.runPeriodic(task)
+	}
+	
+	// Wait for the specified duration
+	<-b.clock.After(duration)
+	
+	// Signal to stop
+	close(b.stopChan)
+	
+	// Wait for goroutines to complete
+	b.wg.Wait()
+}
+
+func (b *Benchmark) runPeriodic(task periodicTask) {
+	defer b.wg.Done()
+	
+	ticker := time.NewTicker(task.interval)
+	defer ticker.Stop()
+	
+	for {
+		select {
+		case <-ticker.C:
+			if !task.tick() {
+				return
+			}
+		case <-b.stopChan:
+			return
+		}
+	}
+}
+
+// runAccelerated executes the same tasks single-threaded in simulated time
+// on sim, the run's clock, always running the task that is due next
+func (b *Benchmark) runAccelerated(sim *clock.Simulated, duration time.Duration) {
+	start := sim.Now()
+	end := start.Add(duration)
+	tasks := b.tasks()
+	next := make([]time.Time, len(tasks))
+	active := make([]bool, len(tasks))
+	for i, task := range tasks {
+		next[i] = start.Add(task.interval)
+		active[i] = true
+	}
+	
+	for {
+		due := -1
+		for i := range tasks {
+			if active[i] && (due < 0 || next[i].Before(next[due])) {
+				due = i
+			}
+		}
+		if due < 0 || next[due].After(end) {
+			break
+		}
+		
+		sim.AdvanceTo(next[due])
+		if !tasks[due].tick() {
+			active[due] = false
+		}
+		next[due] = next[due].Add(tasks[due].interval)
+	}
+	
+	sim.AdvanceTo(end)
+}
@@ -0,0 +1,181 @@
+// pkg/benchmark/autoscale.go - Cluster autoscaler
+package benchmark
+
+import (
+	"cc_go/pkg/node"
+	"fmt"
+	"time"
+)
+
+// AutoscalePolicy configures the cluster autoscaler. Nodes are added from
+// Template when placements keep failing and empty nodes are removed when
+// cluster utilization stays low.
+type AutoscalePolicy struct {
+	Template         NodeTemplate  // shape of added nodes; Count is ignored
+	FailureThreshold int           // failed placements within Cooldown that trigger a scale up
+	LowUtilization   float64       // cluster utilization below which empty nodes are removed
+	Cooldown         time.Duration // minimum time between scaling actions, and how long utilization must stay low
+	MaxNodes         int           // cluster size limit; 0 for none
+	MinNodes         int           // scale down never goes below this many nodes
+}
+
+type autoscaler struct {
+	policy    AutoscalePolicy
+	failures  []time.Time // recent failed placements
+	lastScale time.Time
+	lowSince  time.Time // zero while utilization is above the threshold
+	added     int       // nodes created so far, for naming
+}
+
+// SetAutoscaler enables the cluster autoscaler with policy
+func (b *Benchmark) SetAutoscaler(policy AutoscalePolicy) error {
+	if err := validateTemplate(policy.Template); err != nil {
+		return err
+	}
+	if policy.FailureThreshold < 1 {
+		return fmt.Errorf("autoscale failure threshold must be at least 1, got %d", policy.FailureThreshold)
+	}
+	
+	b.autoscaler = &autoscaler{policy: policy}
+	return nil
+}
+
+// ScaleUp adds a node from the autoscale template and returns it, or nil if
+// autoscaling is disabled or the cluster is at its size limit
+func (b *Benchmark) ScaleUp() *node.Node {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	
+	return b.scaleUp()
+}
+
+// ScaleDown removes an empty node and returns it, or nil if autoscaling is
+// disabled, no node is empty or the cluster is at its minimum size. Nodes
+// running containers are never removed.
+func (b *Benchmark) ScaleDown() *node.Node {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	
+	return b.scaleDown()
+}
+
+func (b *Benchmark) scaleUp() *node.Node {
+	a := b.autoscaler
+	if a == nil || (a.policy.MaxNodes > 0 && len(b.nodes) >= a.policy.MaxNodes) {
+		return nil
+	}
+	
+	template := a.policy.Template
+	n := newTemplateNode(template, fmt.Sprintf("%s-auto-%d", template.Name, a.added), a.added)
+	a.added++
+	b.enrollNode(n)
+	if b.loadSmoothing > 0 {
+		n.SetLoadSmoothing(b.loadSmoothing)
+	}
+	
+	b.nodes = append(b.nodes, n)
+	if b.pool != nil {
+		b.pool.Add(n)
+	}
+	
+	b.logger.Infof("Scaled up: added node %s (%d nodes)", n.Name(), len(b.nodes))
+	b.metricsCollector.RecordScaling(n, true)
+	return n
+}
+
+func (b *Benchmark) scaleDown() *node.Node {
+	a := b.autoscaler
+	if a == nil || len(b.nodes) <= a.policy.MinNodes {
+		return nil
+	}
+	
+	// Release the most expensive empty node, the newest on ties
+	victim := -1
+	for i, n := range b.nodes {
+		if n.ContainerCount() > 0 {
+			continue
+		}
+		if victim < 0 || n.HourlyCost() >= b.nodes[victim].HourlyCost() {
+			victim = i
+		}
+	}
+	if victim < 0 {
+		return nil
+	}
+	
+	n := b.nodes[victim]
+	b.nodes = append(b.nodes[:victim:victim], b.nodes[victim+1:]...)
+	if b.pool != nil {
+		b.pool.Remove(n)
+	}
+	
+	b.logger.Infof("Scaled down: removed empty node %s (%d nodes)", n.Name(), len(b.nodes))
+	b.metricsCollector.RecordScaling(n, false)
+	return n
+}
+
+// noteFailure counts a failed placement towards the scale up threshold and
+// the saturation check of the arrival rate controller
+func (b *Benchmark) noteFailure(now time.Time) {
+	if b.autoscaler != nil {
+		b.autoscaler.failures = append(b.autoscaler.failures, now)
+	}
+	if b.loadTarget != nil {
+		b.loadTarget.failed++
+	}
+}
+
+// clusterUtilization is the average utilization of the nodes
+func (b *Benchmark) clusterUtilization() float64 {
+	if len(b.nodes) == 0 {
+		return 0
+	}
+	utilization := 0.0
+	for _, n := range b.nodes {
+		utilization += n.Utilization()
+	}
+	return utilization / float64(len(b.nodes))
+}
+
+// autoscale takes at most one scaling action: a scale up once enough
+// placements failed within the cooldown, otherwise a scale down once
+// utilization has stayed low for the cooldown
+func (b *Benchmark) autoscale(now time.Time) {
+	a := b.autoscaler
+	if a == nil {
+		return
+	}
+	
+	recent := a.failures[:0]
+	for _, failed := range a.failures {
+		if now.Sub(failed) < a.policy.Cooldown {
+			recent = append(recent, failed)
+		}
+	}
+	a.failures = recent
+	
+	if b.clusterUtilization() >= a.policy.LowUtilization {
+		a.lowSince = time.Time{}
+	} else if a.lowSince.IsZero() {
+		a.lowSince = now
+	}
+	
+	if now.Sub(a.lastScale) < a.policy.Cooldown {
+		return
+	}
+	
+	if len(a.failures) >= a.policy.FailureThreshold {
+		if b.scaleUp() != nil {
+			a.failures = a.failures[:0]
+			a.lastScale = now
+		}
+		return
+	}
+	
+	if !a.lowSince.IsZero() && now.Sub(a.lowSince) >= a.policy.Cooldown {
+		if b.scaleDown() != nil {
+			a.lastScale = now
+			a.lowSince = now
+		}
+	}
+}
@@ -2,16 +2,30 @@
 package benchmark
 
 import (
+	"cc_go/pkg/clock"
+	"cc_go/pkg/container"
+	"cc_go/pkg/logging"
 	"cc_go/pkg/metrics"
 	"cc_go/pkg/node"
 	"cc_go/pkg/scheduler"
 	"cc_go/pkg/workLoad"
+	"errors"
 	"fmt"
-	"log"
+	"math"
+	"math/rand"
 	"sync"
 	"time"
 )
 
+// ErrNoNodeChosen is returned when a scheduler reports success but does not
+// name a node to place the container on
+var ErrNoNodeChosen = errors.New("scheduler returned no node")
+
+// clusterSampleInterval is how often cluster power draw and cost are
+// integrated; it matches the arrival tick so short-lived placements are
+// not missed
+const clusterSampleInterval = 100 * time.Millisecond
+
 type Benchmark struct {
 	scheduler       scheduler.Scheduler
 	workloadGen     workLoad.WorkloadGenerator
@@ -19,170 +33,663 @@ type Benchmark struct {
 	nodes           []*node.Node
 	stopChan        chan struct{}
 	wg              sync.WaitGroup
+	mu              sync.Mutex // guards node state shared by the worker goroutines
+	rebalancer      *Rebalancer
+	healthModel     *HealthModel
+	cleanup         CleanupPolicy
+	noiseAmplitude  float64 // 0 disables usage fluctuation
+	noiseRng        *rand.Rand
+	loadSmoothing   float64 // EMA factor applied to every node; 0 keeps theirs
+	rebalanceInterval time.Duration
+	batchSize       int
+	maxRetries      int
+	retryBackoff    time.Duration
+	retryQueue      []pendingRetry
+	submitting      bool // an API caller is waiting on the outcome; failures are not retried
+	pending         pendingQueue // containers to place this tick, by priority
+	fair            *fairQueue   // replaces pending when fair queuing is enabled
+	groups          map[string]*orderedGroup // rollout state of ordered container groups
+	arrivals        int          // containers arrived since the last cluster sample
+	placements      int          // containers placed since the last cluster sample
+	verbose         bool
+	indexNodes      bool
+	preemption      bool
+	pool            *node.Pool // capacity index over nodes while running, if enabled
+	autoscaler      *autoscaler // adds and removes nodes while running, if enabled
+	loadTarget      *loadController // steers the arrival rate, if a utilization target is set
+	accelerated     bool
+	clock           clock.Clock // the run's clock: the wall clock, or simulated when accelerated
+	lastContainerID uint64      // number of the last container that entered the run
+	lastNodeID      uint64      // number of the last node added to the run
+	wave            []*container.Container // arrivals buffered for the next batch
+	warmup          time.Duration
+	warmupEnd       time.Time
+	measured        metrics.Collector // the run's collector, set aside while warming up
+	logger          logging.Logger
 }
 
+// NewBenchmark creates a benchmark on the default cluster. A nil cleanup
+// policy removes 10% of each node's containers per second.
 func NewBenchmark(
 	scheduler scheduler.Scheduler,
 	workloadGen workLoad.WorkloadGenerator,
 	collector metrics.Collector,
+	cleanup CleanupPolicy,
 ) *Benchmark {
-	// Create a simulated cluster of nodes
-	nodes := createNodes()
+	if cleanup == nil {
+		cleanup = NewRandomChurn(0.1)
+	}
 	
-	return &Benchmark{
+	b := &Benchmark{
 		scheduler:       scheduler,
 		workloadGen:     workloadGen,
 		metricsCollector: collector,
-		nodes:           nodes,
+		clock:           clock.Real{},
 		stopChan:        make(chan struct{}),
+		rebalancer:      NewRebalancer(),
+		healthModel:     NewHealthModel(),
+		cleanup:         cleanup,
+		retryBackoff:    1 * time.Second,
+		retryQueue:      make([]pendingRetry, 0),
+		groups:          make(map[string]*orderedGroup),
+		logger:          logging.Default(),
 	}
+	// Create a simulated cluster of nodes
+	b.SetNodes(createNodes())
+	return b
+}
+
+// SetLogger replaces the logger, logging.Default() unless set; scheduling
+// failures are logged at warn and every placement at debug
+func (b *Benchmark) SetLogger(logger logging.Logger) {
+	b.logger = logger
+}
+
+// SetRebalanceInterval enables periodic rebalancing; zero disables it.
+func (b *Benchmark) SetRebalanceInterval(interval time.Duration) {
+	b.rebalanceInterval = interval
+}
+
+// SetBatchSize buffers arrivals into waves of size containers that are
+// scheduled together through ScheduleBatch; 1 or less schedules each
+// container as it arrives.
+func (b *Benchmark) SetBatchSize(size int) {
+	b.batchSize = size
+}
+
+// SetVerbose logs, for schedulers that can explain themselves, the top
+// candidate nodes behind every placement
+func (b *Benchmark) SetVerbose(enabled bool) {
+	b.verbose = enabled
+}
+
+// SetNodeIndex makes single-container placements consult a node.Pool so
+// only nodes with enough free CPU are offered to the scheduler, which
+// speeds up scheduling on large clusters
+func (b *Benchmark) SetNodeIndex(enabled bool) {
+	b.indexNodes = enabled
+}
+
+func (b *Benchmark) Rebalancer() *Rebalancer {
+	return b.rebalancer
 }
 
 func createNodes() []*node.Node {
-	nodes := make([]*node.Node, 0)
-	
-	// Create a heterogeneous cluster
-	// Small nodes
-	for i := 0; i < 3; i++ {
-		nodes = append(nodes, node.NewNode(
-			fmt.Sprintf("small-node-%d", i),
-			2.0,  // 2 CPU cores
-			4096, // 4GB memory
-			1000, // 1Gbps network
-			5000, // 5K IOPS
-		))
-	}
-	
-	// Medium nodes
-	for i := 0; i < 5; i++ {
-		nodes = append(nodes, node.NewNode(
-			fmt.Sprintf("medium-node-%d", i),
-			4.0,   // 4 CPU cores
-			8192,  // 8GB memory
-			2000,  // 2Gbps network
-			10000, // 10K IOPS
-		))
-	}
-	
-	// Large nodes
-	for i := 0; i < 2; i++ {
-		nodes = append(nodes, node.NewNode(
-			fmt.Sprintf("large-node-%d", i),
-			8.0,   // 8 CPU cores
-			16384, // 16GB memory
-			5000,  // 5Gbps network
-			20000, // 20K IOPS
-		))
+	nodes, err := NewCluster(DefaultClusterDefinition())
+	if err != nil {
+		panic(fmt.Sprintf("invalid default cluster: %v", err))
 	}
 	
 	return nodes
 }
 
+// DefaultClusterDefinition describes the heterogeneous cluster used when no
+// cluster file is given.
+func DefaultClusterDefinition() ClusterDefinition {
+	return ClusterDefinition{
+		Nodes: []NodeTemplate{
+			// Small nodes
+			{
+				Name:         "small-node",
+				Count:        3,
+				CPU:          2.0,  // 2 CPU cores
+				Memory:       4096, // 4GB memory
+				Network:      1000, // 1Gbps network
+				IO:           5000, // 5K IOPS
+				Disk:         100,  // 100GB disk
+				IdleWatts:    60,
+				WattsPerUtil: 90,
+				HourlyCost:   0.05,
+			},
+			// Medium nodes
+			{
+				Name:         "medium-node",
+				Count:        5,
+				CPU:          4.0,   // 4 CPU cores
+				Memory:       8192,  // 8GB memory
+				Network:      2000,  // 2Gbps network
+				IO:           10000, // 10K IOPS
+				Disk:         250,   // 250GB disk
+				IdleWatts:    100,
+				WattsPerUtil: 160,
+				HourlyCost:   0.10,
+			},
+			// Large nodes
+			{
+				Name:         "large-node",
+				Count:        2,
+				CPU:          8.0,   // 8 CPU cores
+				Memory:       16384, // 16GB memory
+				Network:      5000,  // 5Gbps network
+				IO:           20000, // 20K IOPS
+				Disk:         500,   // 500GB disk
+				IdleWatts:    150,
+				WattsPerUtil: 300,
+				HourlyCost:   0.20,
+			},
+		},
+	}
+}
+
+// SetNodes replaces the simulated cluster, e.g. with one loaded from a
+// cluster file, numbering its nodes from node-1. It must be called before
+// Run.
+func (b *Benchmark) SetNodes(nodes []*node.Node) {
+	b.nodes = nodes
+	b.lastNodeID = 0
+	for _, n := range nodes {
+		b.enrollNode(n)
+	}
+}
+
+func (b *Benchmark) Nodes() []*node.Node {
+	return b.nodes
+}
+
 func (b *Benchmark) Run(duration time.Duration) {
-	log.Printf("Starting benchmark with %s scheduler for %v", b.scheduler.Name(), duration)
-	log.Printf("Simulating cluster with %d nodes", len(b.nodes))
+	b.logger.Infof("Starting benchmark with %s scheduler for %v", b.scheduler.Name(), duration)
+	b.logger.Infof("Simulating cluster with %d nodes", len(b.nodes))
 	
-	// Start the container scheduler
-	b.wg.Add(1)
-	go b.scheduleContainers()
+	// Everything in the run tells time by the run's own clock
+	var sim *clock.Simulated
+	b.mu.Lock()
+	if b.accelerated {
+		sim = clock.NewSimulated(time.Now())
+		b.useClock(sim)
+	} else {
+		b.useClock(clock.Real{})
+	}
+	b.mu.Unlock()
+	
+	if b.indexNodes {
+		b.pool = node.NewPool(b.nodes)
+		defer b.pool.Close()
+	}
+	
+	// Record the scheduler's state changes next to its placements
+	defer b.observeScheduler()()
 	
-	// Start the cleanup routine
-	b.wg.Add(1)
-	go b.cleanupContainers()
+	if b.loadSmoothing > 0 {
+		for _, n := range b.nodes {
+			n.SetLoadSmoothing(b.loadSmoothing)
+		}
+	}
 	
-	// Wait for the specified duration
-	time.Sleep(duration)
+	// Types with a replica minimum start out at it
+	b.mu.Lock()
+	b.startWarmup()
+	b.replenishReplicas()
+	b.mu.Unlock()
 	
-	// Signal to stop
-	close(b.stopChan)
+	if b.accelerated {
+		b.runAccelerated(sim, duration)
+	} else {
+		b.runRealTime(duration)
+	}
 	
-	// Wait for goroutines to complete
-	b.wg.Wait()
+	// Containers still waiting for a retry never made it onto a node
+	b.mu.Lock()
+	b.endWarmup(b.clock.Now())
+	b.abandonRetries()
+	b.abandonHeld()
+	b.mu.Unlock()
 	
-	log.Println("Benchmark complete")
+	b.logger.Infof("Benchmark complete")
 }
 
-func (b *Benchmark) scheduleContainers() {
-	defer b.wg.Done()
+// scheduleContainers handles one arrival tick; it reports false once the
+// workload is exhausted and nothing is waiting for a retry
+func (b *Benchmark) scheduleContainers() bool {
+	// Give previously failed containers another chance; they are
+	// placed together with this tick's arrivals in priority order
+	b.mu.Lock()
+	b.endWarmup(b.clock.Now())
+	b.retryPending(b.clock.Now())
+	pendingRetries := len(b.retryQueue)
+	due := b.arrivalsDue()
+	b.mu.Unlock()
 	
-	// Rate limiting - don't flood with containers
-	ticker := time.NewTicker(100 * time.Millisecond)
-	defer ticker.Stop()
+	if !b.workloadGen.HasNext() {
+		b.mu.Lock()
+		b.drainPending()
+		// Flush the partially filled wave before finishing
+		if len(b.wave) > 0 {
+			b.scheduleWave(b.wave)
+			b.wave = b.wave[:0]
+			pendingRetries = len(b.retryQueue)
+		}
+		b.mu.Unlock()
+		if pendingRetries > 0 {
+			return true
+		}
+		return false
+	}
 	
-	for {
-		select {
-		case <-ticker.C:
-			if !b.workloadGen.HasNext() {
-				return
-			}
-			
-			container := b.workloadGen.NextContainer()
-			if container == nil {
-				continue
-			}
-			
-			startTime := time.Now()
-			node, err := b.scheduler.Schedule(container, b.nodes)
-			latency := time.Since(startTime)
-			
-			if err != nil {
-				log.Printf("Failed to schedule container %s: %v", container.ID(), err)
-				b.metricsCollector.RecordSchedulingEvent(container, nil, latency, false)
-				continue
-			}
-			
-			// Add container to the node
-			if node.AddContainer(container) {
-				log.Printf("Scheduled container %s on node %s (latency: %v)", 
-					container.ID(), node.Name(), latency)
-				b.metricsCollector.RecordSchedulingEvent(container, node, latency, true)
-			} else {
-				log.Printf("Node %s rejected container %s", node.Name(), container.ID())
-				b.metricsCollector.RecordSchedulingEvent(container, node, latency, false)
-			}
-			
-		case <-b.stopChan:
-			return
+	if due == 0 {
+		b.mu.Lock()
+		b.drainPending()
+		b.mu.Unlock()
+		return true
+	}
+	for i := 0; i < due && b.workloadGen.HasNext(); i++ {
+		b.arrive()
+	}
+	return true
+}
+
+// arrive takes the next container from the workload and queues it for
+// placement, or adds it to the wave being batched
+func (b *Benchmark) arrive() {
+	container := b.workloadGen.NextContainer()
+	if container == nil {
+		b.mu.Lock()
+		b.drainPending()
+		b.mu.Unlock()
+		return
+	}
+	b.enroll(container)
+	
+	// Arrivals beyond their type's replica maximum are turned away
+	b.mu.Lock()
+	if b.atReplicaMax(container) {
+		b.drainPending()
+		b.mu.Unlock()
+		return
+	}
+	
+	// Ordered group members wait for their predecessor
+	if !b.admit(container) {
+		b.arrivals++
+		b.drainPending()
+		b.mu.Unlock()
+		return
+	}
+	b.mu.Unlock()
+	
+	if b.batchSize > 1 {
+		b.mu.Lock()
+		b.wave = append(b.wave, container)
+		b.arrivals++
+		b.drainPending()
+		if len(b.wave) >= b.batchSize {
+			b.scheduleWave(b.wave)
+			b.wave = b.wave[:0]
+		}
+		b.mu.Unlock()
+		return
+	}
+	
+	b.mu.Lock()
+	b.arrivals++
+	b.enqueue(container)
+	b.drainPending()
+	b.mu.Unlock()
+}
+
+// Submit schedules an externally injected container through the same path
+// as generated ones, returning the node it was placed on. A failure is
+// final: the caller is answered now, so the container is not queued for a
+// retry it would never hear about.
+func (b *Benchmark) Submit(container *container.Container) (*node.Node, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	
+	b.enroll(container)
+	b.arrivals++
+	b.submitting = true
+	defer func() { b.submitting = false }()
+	return b.scheduleContainer(container)
+}
+
+// Results returns a snapshot of the metrics collected so far. It is safe to
+// call while the benchmark is running.
+func (b *Benchmark) Results() *metrics.Results {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	
+	return b.collector().GetResults()
+}
+
+// Snapshot captures the cluster state; it is safe to call while the
+// benchmark is running
+func (b *Benchmark) Snapshot() node.ClusterSnapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	
+	return node.Snapshot(b.nodes)
+}
+
+// InspectNodes calls fn with the cluster while holding the benchmark lock,
+// so node state can be read consistently while the benchmark is running.
+func (b *Benchmark) InspectNodes(fn func(nodes []*node.Node)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	
+	fn(b.nodes)
+}
+
+func (b *Benchmark) scheduleContainer(container *container.Container) (*node.Node, error) {
+	container.RecordAttempt()
+	if b.verbose {
+		b.explainPlacement(container)
+	}
+	startTime := time.Now()
+	node, err := b.schedule(container)
+	var plan *preemptionPlan
+	if err != nil && b.preemption {
+		if plan = b.planPreemption(container); plan != nil {
+			node, err = plan.target, nil
 		}
 	}
+	latency := time.Since(startTime)
+	// Evictions are not part of the scheduling decision's latency
+	if plan != nil {
+		b.preempt(container, plan)
+	}
+	
+	if err != nil {
+		err = b.diagnose(container, err)
+		b.logger.Warnf("Failed to schedule container %s: %v", container.ID(), err)
+		b.recordFailure(container, nil, latency)
+		return nil, err
+	}
+	
+	// A misbehaving scheduler may report success without choosing a node
+	if node == nil {
+		b.logger.Errorf("Scheduler %s returned no node and no error for container %s", 
+			b.scheduler.Name(), container.ID())
+		container.SetFailureReason(ErrNoNodeChosen.Error())
+		b.recordFailure(container, nil, latency)
+		return nil, ErrNoNodeChosen
+	}
+	
+	// Add container to the node, trying others if it is rejected
+	node, ok := b.place(container, node)
+	latency = time.Since(startTime)
+	if !ok {
+		err = fmt.Errorf("node %s rejected container %s", node.Name(), container.ID())
+		container.SetFailureReason(err.Error())
+		b.recordFailure(container, node, latency)
+		return nil, err
+	}
+	
+	b.pullImage(container, node)
+	
+	b.placements++
+	b.logger.Debugf("Scheduled container %s on node %s (latency: %v)", 
+		container.ID(), node.Name(), latency)
+	b.metricsCollector.RecordSchedulingEvent(container, node, latency, true)
+	b.recordPinning(container, node)
+	b.orderedPlaced(container)
+	return node, nil
+}
+
+// diagnose turns a bare ErrNoSuitableNode into a NoFitError describing the
+// container and the cluster's free capacity, and keeps the result as the
+// container's failure reason for its failure event
+func (b *Benchmark) diagnose(c *container.Container, err error) error {
+	var noFit *scheduler.NoFitError
+	if errors.Is(err, scheduler.ErrNoSuitableNode) && !errors.As(err, &noFit) {
+		err = scheduler.NewNoFitError(c, b.nodes)
+	}
+	c.SetFailureReason(err.Error())
+	return err
+}
+
+// schedule asks the scheduler for a node, offering it only the indexed
+// candidates when the node index is enabled
+func (b *Benchmark) schedule(c *container.Container) (*node.Node, error) {
+	if b.pool != nil {
+		return scheduler.ScheduleFromPool(b.scheduler, c, b.pool)
+	}
+	return b.scheduler.Schedule(c, b.nodes)
 }
 
-func (b *Benchmark) cleanupContainers() {
-	defer b.wg.Done()
+// scheduleBatch is schedule for a wave
+func (b *Benchmark) scheduleBatch(wave []*container.Container) (map[*container.Container]*node.Node, error) {
+	if b.pool != nil {
+		return scheduler.ScheduleBatchFromPool(b.scheduler, wave, b.pool)
+	}
+	return b.scheduler.ScheduleBatch(wave, b.nodes)
+}
+
+// explainPlacement logs the best candidates the scheduler sees for the
+// container; it runs before Schedule so latency is not affected
+func (b *Benchmark) explainPlacement(container *container.Container) {
+	explainer, ok := b.scheduler.(scheduler.Explainer)
+	if !ok {
+		return
+	}
+	
+	scores, err := explainer.Explain(container, b.nodes)
+	if err != nil {
+		b.logger.Debugf("No candidates for container %s (%s): %v", container.ID(), container.Type(), err)
+		_, rejections := scheduler.FilterExplained(container, b.nodes, scheduler.Predicates()...)
+		for _, r := range rejections {
+			b.logger.Debugf("Predicate %s kept container %s off node %s", r.Predicate, container.ID(), r.Node.Name())
+		}
+		return
+	}
+	
+	if len(scores) > 3 {
+		scores = scores[:3]
+	}
+	for i, score := range scores {
+		b.logger.Debugf("Candidate %d for container %s (%s): %s", i+1, container.ID(), container.Type(), score)
+	}
+}
+
+// scheduleWave places a buffered wave of containers in one ScheduleBatch
+// call, splitting the batch latency evenly across its containers. The wave
+// is offered highest priority first.
+func (b *Benchmark) scheduleWave(wave []*container.Container) {
+	wave = byPriority(wave)
+	startTime := time.Now()
+	placements, err := b.scheduleBatch(wave)
+	latency := time.Since(startTime) / time.Duration(len(wave))
 	
-	// Remove containers periodically to simulate completion
-	ticker := time.NewTicker(1 * time.Second)
-	defer ticker.Stop()
+	if err != nil {
+		b.logger.Warnf("Failed to schedule wave of %d containers: %v", len(wave), err)
+	}
 	
-	for {
-		select {
-		case <-ticker.C:
-			b.removeRandomContainers()
-		case <-b.stopChan:
-			return
+	for _, container := range wave {
+		container.RecordAttempt()
+		node, ok := placements[container]
+		if !ok || node == nil {
+			err := b.diagnose(container, scheduler.ErrNoSuitableNode)
+			b.logger.Warnf("Failed to schedule container %s in wave: %v", container.ID(), err)
+			b.recordFailure(container, nil, latency)
+			continue
+		}
+		
+		node, ok = b.place(container, node)
+		if !ok {
+			container.SetFailureReason(fmt.Sprintf("node %s rejected container %s", node.Name(), container.ID()))
+			b.recordFailure(container, node, latency)
+			continue
 		}
+		
+		b.pullImage(container, node)
+		b.placements++
+		b.logger.Debugf("Scheduled container %s on node %s (wave of %d)", 
+			container.ID(), node.Name(), len(wave))
+		b.metricsCollector.RecordSchedulingEvent(container, node, latency, true)
+		b.recordPinning(container, node)
+		b.orderedPlaced(container)
 	}
 }
 
-func (b *Benchmark) removeRandomContainers() {
-	for _, node := range b.nodes {
-		containers := node.Containers()
+// cleanupContainers removes finished containers to simulate completion
+func (b *Benchmark) cleanupContainers() bool {
+	b.mu.Lock()
+	b.removeCompletedContainers()
+	b.replenishReplicas()
+	// Let learning schedulers judge their past placements
+	if learner, ok := scheduler.Unwrap(b.scheduler).(scheduler.FeedbackReceiver); ok {
+		learner.Feedback(b.nodes)
+	}
+	b.mu.Unlock()
+	return true
+}
+
+func (b *Benchmark) sampleCluster() bool {
+	b.mu.Lock()
+	b.endWarmup(b.clock.Now())
+	b.relieveMemoryPressure()
+	// Replace containers lost to OOM kills, preemption or abandoned retries
+	b.replenishReplicas()
+	b.autoscale(b.clock.Now())
+	watts := 0.0
+	hourlyCost := 0.0
+	occupiedUtilization := 0.0
+	occupied := 0
+	for _, n := range b.nodes {
+		watts += n.PowerDraw()
+		// Only occupied nodes accrue cost; empty ones could be released
+		if n.ContainerCount() > 0 {
+			hourlyCost += n.HourlyCost()
+			occupiedUtilization += n.Utilization()
+			occupied++
+		}
+	}
+	b.metricsCollector.RecordPowerSample(watts, clusterSampleInterval)
+	b.metricsCollector.RecordCostSample(hourlyCost, clusterSampleInterval)
+	if occupied > 0 {
+		b.metricsCollector.RecordPackingSample(occupiedUtilization / float64(occupied))
+		b.metricsCollector.RecordStrandedSample(b.strandedResources())
+	}
+	b.sampleContention()
+	b.sampleGPUs()
+	b.metricsCollector.RecordUnschedulableSample(len(b.retryQueue))
+	b.metricsCollector.RecordThroughputSample(b.arrivals, b.placements,
+		len(b.retryQueue)+b.pendingLen(), clusterSampleInterval)
+	b.metricsCollector.RecordNodeCountSample(len(b.nodes))
+	b.sampleReplicas()
+	b.metricsCollector.RecordNodeUtilizationSample(metrics.ComputeNodeUtilizationStats(b.nodes))
+	b.arrivals, b.placements = 0, 0
+	b.mu.Unlock()
+	return true
+}
+
+// sampleContention records how much network demand the nodes' NICs
+// cannot carry
+func (b *Benchmark) sampleContention() {
+	demand, lost, peak := 0.0, 0.0, 0.0
+	contended := 0
+	for _, n := range b.nodes {
+		nodeDemand, nic := n.NetworkDemand(), n.NICBandwidth()
+		demand += nodeDemand
+		if nic > 0 && nodeDemand > nic {
+			lost += nodeDemand - nic
+			contended++
+			peak = math.Max(peak, 1-nic/nodeDemand)
+		}
+	}
+	b.metricsCollector.RecordContentionSample(demand, lost, peak, contended, clusterSampleInterval)
+}
+
+// sampleGPUs records the share of GPU memory allocated and of GPUs in use,
+// on clusters with GPUs
+func (b *Benchmark) sampleGPUs() {
+	var memory, totalMemory float64
+	var inUse, gpus int
+	for _, n := range b.nodes {
+		memory += n.UsedGPUMemory()
+		totalMemory += n.TotalGPUMemory()
+		inUse += n.GPUsInUse()
+		gpus += n.GPUs()
+	}
+	if gpus == 0 {
+		return
+	}
+	b.metricsCollector.RecordGPUSample(memory/totalMemory, float64(inUse)/float64(gpus))
+}
+
+// strandedResources measures stranded capacity against the median running
+// container, as a fraction of the cluster's total of each resource
+func (b *Benchmark) strandedResources() metrics.StrandedResources {
+	reference := node.MedianContainer(b.nodes)
+	
+	var stranded, totals metrics.StrandedResources
+	for _, n := range b.nodes {
+		stranded.CPU += n.StrandedCPU(reference)
+		stranded.Memory += n.StrandedMemory(reference)
+		stranded.Network += n.StrandedNetwork(reference)
+		stranded.IO += n.StrandedIO(reference)
+		stranded.Disk += n.StrandedDisk(reference)
 		
-		// Remove ~10% of containers from each node
-		for i := 0; i < len(containers)/10+1; i++ {
-			if len(containers) == 0 {
-				break
-			}
-			
-			// Remove a random container
-			containerIdx := time.Now().Nanosecond() % len(containers)
-			containerID := containers[containerIdx].ID()
-			if node.RemoveContainer(containerID) {
-				log.Printf("Removed container %s from node %s", containerID, node.Name())
+		totals.CPU += n.TotalCPU()
+		totals.Memory += n.TotalMemory()
+		totals.Network += n.TotalNetwork()
+		totals.IO += n.TotalIO()
+		totals.Disk += n.TotalDisk()
+	}
+	
+	return metrics.StrandedResources{
+		CPU:     node.Ratio(stranded.CPU, totals.CPU),
+		Memory:  node.Ratio(stranded.Memory, totals.Memory),
+		Network: node.Ratio(stranded.Network, totals.Network),
+		IO:      node.Ratio(stranded.IO, totals.IO),
+		Disk:    node.Ratio(stranded.Disk, totals.Disk),
+	}
+}
+
+func (b *Benchmark) rebalanceContainers() bool {
+	b.mu.Lock()
+	b.rebalance()
+	b.mu.Unlock()
+	return true
+}
+
+func (b *Benchmark) rebalance() {
+	before := node.ClusterLoadVariance(b.nodes)
+	migrations := b.rebalancer.Rebalance(b.nodes)
+	if len(migrations) == 0 {
+		return
+	}
+	
+	after := node.ClusterLoadVariance(b.nodes)
+	for _, m := range migrations {
+		b.pullImage(m.Container, m.To)
+		b.logger.Debugf("Migrated container %s from node %s to node %s", 
+			m.Container.ID(), m.From.Name(), m.To.Name())
+		b.metricsCollector.RecordMigration(m.Container, m.From, m.To, after)
+		b.metricsCollector.RecordDisruption(m.Container)
+	}
+	b.logger.Infof("Rebalanced %d containers, cluster load variance %.3f -> %.3f", 
+		len(migrations), before, after)
+}
+
+// removeCompletedContainers removes the containers the cleanup policy says
+// have completed
+func (b *Benchmark) removeCompletedContainers() {
+	now := b.clock.Now()
+	for _, node := range b.nodes {
+		for _, victim := range b.cleanup.Victims(node, now) {
+			if node.RemoveContainerRef(victim) {
+				b.logger.Debugf("Removed container %s from node %s", victim.ID(), node.Name())
+				b.metricsCollector.RecordRemovalEvent(victim.ID(), node, now)
 			}
-			
-			// Update containers list
-			containers = node.Containers()
 		}
 	}
 }
@@ -0,0 +1,66 @@
+// pkg/benchmark/benchmark_test.go - Benchmark scheduling path tests
+package benchmark
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"cc_go/pkg/container"
+	"cc_go/pkg/logging"
+	"cc_go/pkg/metrics"
+	"cc_go/pkg/node"
+	"cc_go/pkg/scheduler"
+)
+
+// newTestBenchmark returns a benchmark over the given nodes that logs
+// nothing and collects into a fresh collector
+func newTestBenchmark(s scheduler.Scheduler, nodes []*node.Node) *Benchmark {
+	b := NewBenchmark(s, nil, metrics.NewCollector(), NewNeverRemove())
+	b.SetLogger(logging.Discard{})
+	b.SetNodes(nodes)
+	return b
+}
+
+func TestSubmitFailureIsFinal(t *testing.T) {
+	b := newTestBenchmark(scheduler.NewBinPackScheduler(), []*node.Node{node.NewNode("small", 1, 1024, 100, 100)})
+	b.SetRetryPolicy(3, time.Second)
+	
+	n, err := b.Submit(container.NewContainer("too-big", "img", 4, 512, 10, 10, "web", 0))
+	if n != nil || err == nil {
+		t.Fatalf("Submit = %v, %v, want a failure", n, err)
+	}
+	if len(b.retryQueue) != 0 {
+		t.Errorf("submitted container was queued for %d retries", len(b.retryQueue))
+	}
+	if failures := b.Results().SchedulingFailures; failures != 1 {
+		t.Errorf("recorded %d failures, want the submission's one", failures)
+	}
+}
+
+// nilScheduler reports success without choosing a node
+type nilScheduler struct{}
+
+func (nilScheduler) Name() string { return "nil" }
+
+func (nilScheduler) Schedule(c *container.Container, nodes []*node.Node) (*node.Node, error) {
+	return nil, nil
+}
+
+func (nilScheduler) ScheduleBatch(containers []*container.Container, nodes []*node.Node) (map[*container.Container]*node.Node, error) {
+	return nil, nil
+}
+
+func TestSchedulerWithoutNodeOrErrorIsAFailure(t *testing.T) {
+	b := newTestBenchmark(nilScheduler{}, []*node.Node{node.NewNode("n", 10, 10000, 1000, 1000)})
+	
+	b.mu.Lock()
+	n, err := b.scheduleContainer(container.NewContainer("c", "img", 1, 100, 1, 1, "web", 0))
+	b.mu.Unlock()
+	if n != nil || !errors.Is(err, ErrNoNodeChosen) {
+		t.Errorf("scheduleContainer = %v, %v, want ErrNoNodeChosen", n, err)
+	}
+	if failures := b.Results().SchedulingFailures; failures != 1 {
+		t.Errorf("recorded %d failures, want 1", failures)
+	}
+}
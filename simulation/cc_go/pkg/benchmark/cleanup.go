@@ -0,0 +1,105 @@
+// pkg/benchmark/cleanup.go - Container completion policies
+package benchmark
+
+import (
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+	"math/rand"
+	"time"
+)
+
+// CleanupPolicy decides which running containers complete. The benchmark
+// asks it about every node once per cleanup tick and removes the containers
+// it returns.
+type CleanupPolicy interface {
+	Name() string
+	
+	// Victims returns the containers on n that complete at now
+	Victims(n *node.Node, now time.Time) []*container.Container
+}
+
+// RandomChurn removes a fixed fraction of each node's containers, at least
+// one, every tick. Victims are distinct and drawn uniformly from the node's
+// containers using the policy's own seedable source, so which containers
+// (and so which types) survive does not depend on timing.
+type RandomChurn struct {
+	rate float64
+	rng  *rand.Rand
+}
+
+func NewRandomChurn(rate float64) *RandomChurn {
+	return &RandomChurn{
+		rate: rate,
+		rng:  rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (p *RandomChurn) SetSeed(seed int64) {
+	p.rng = rand.New(rand.NewSource(seed))
+}
+
+func (p *RandomChurn) Name() string {
+	return "RandomChurn"
+}
+
+func (p *RandomChurn) Victims(n *node.Node, now time.Time) []*container.Container {
+	running := n.Containers()
+	if len(running) == 0 || p.rate <= 0 {
+		return nil
+	}
+	
+	count := int(float64(len(running))*p.rate) + 1
+	if count > len(running) {
+		count = len(running)
+	}
+	
+	// Partial Fisher-Yates shuffle: the first count slots end up holding a
+	// uniform sample without repeats
+	victims := make([]*container.Container, len(running))
+	copy(victims, running)
+	for i := 0; i < count; i++ {
+		j := i + p.rng.Intn(len(victims)-i)
+		victims[i], victims[j] = victims[j], victims[i]
+	}
+	
+	return victims[:count]
+}
+
+// LifetimeBased removes containers once they have run for their lifetime,
+// counted from placement. Containers without a lifetime never complete.
+type LifetimeBased struct{}
+
+func NewLifetimeBased() *LifetimeBased {
+	return &LifetimeBased{}
+}
+
+func (p *LifetimeBased) Name() string {
+	return "LifetimeBased"
+}
+
+func (p *LifetimeBased) Victims(n *node.Node, now time.Time) []*container.Container {
+	var victims []*container.Container
+	for _, c := range n.Containers() {
+		if c.Lifetime() > 0 && !c.PlacedAt().IsZero() && now.Sub(c.PlacedAt()) >= c.Lifetime() {
+			victims = append(victims, c)
+		}
+	}
+	
+	return victims
+}
+
+// NeverRemove keeps every container until the run ends, for arrival-only
+// experiments
+type NeverRemove struct{}
+
+func NewNeverRemove() *NeverRemove {
+	return &NeverRemove{}
+}
+
+func (p *NeverRemove) Name() string {
+	return "NeverRemove"
+}
+
+func (p *NeverRemove) Victims(n *node.Node, now time.Time) []*container.Container {
+	return nil
+}
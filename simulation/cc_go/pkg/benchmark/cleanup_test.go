@@ -0,0 +1,54 @@
+// pkg/benchmark/cleanup_test.go - Completion policy tests
+package benchmark
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+)
+
+func TestRandomChurnVictimsAreUniform(t *testing.T) {
+	const size, trials = 10, 20000
+	n := node.NewNode("n", 100, 100000, 10000, 10000)
+	position := make(map[*container.Container]int)
+	for i := 0; i < size; i++ {
+		c := container.NewContainer(fmt.Sprintf("c-%d", i), "img", 1, 100, 1, 1, "web", 0)
+		n.AddContainer(c)
+		position[c] = i
+	}
+	
+	policy := NewRandomChurn(0.2)
+	policy.SetSeed(1)
+	counts := make([]int, size)
+	perTrial := 0
+	for trial := 0; trial < trials; trial++ {
+		victims := policy.Victims(n, time.Now())
+		perTrial = len(victims)
+		seen := make(map[*container.Container]bool)
+		for _, c := range victims {
+			if seen[c] {
+				t.Fatalf("trial %d: %s chosen twice", trial, c.Name())
+			}
+			seen[c] = true
+			counts[position[c]]++
+		}
+	}
+	if perTrial != 3 {
+		t.Fatalf("%d victims per tick, want 3 (20%% of 10, plus one)", perTrial)
+	}
+	
+	// Pearson's chi-squared test against a uniform choice; 27.88 is the
+	// critical value for 9 degrees of freedom at p = 0.001
+	expected := float64(trials*perTrial) / size
+	chiSquared := 0.0
+	for _, count := range counts {
+		d := float64(count) - expected
+		chiSquared += d * d / expected
+	}
+	if chiSquared > 27.88 {
+		t.Errorf("chi-squared %.2f over removal counts %v, not uniform", chiSquared, counts)
+	}
+}
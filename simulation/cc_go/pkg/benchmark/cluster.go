@@ -0,0 +1,207 @@
+// pkg/benchmark/cluster.go - Cluster definition loading
+package benchmark
+
+import (
+	"cc_go/pkg/node"
+	"cc_go/pkg/workLoad"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// NodeTemplate describes a group of identical nodes. Count nodes are
+// created, named "<name>-<index>".
+type NodeTemplate struct {
+	Name         string  `json:"name"`
+	Count        int     `json:"count"`
+	CPU          float64 `json:"cpu"`
+	Memory       float64 `json:"memory"`
+	Network      float64 `json:"network"`
+	IO           float64 `json:"io"`
+	Disk         float64 `json:"disk"` // GB of storage; zero leaves disk unconstrained
+	IdleWatts    float64 `json:"idle_watts"`
+	WattsPerUtil float64 `json:"watts_per_util"`
+	HourlyCost   float64 `json:"hourly_cost"`
+	MaxContainers int    `json:"max_containers"` // 0 for no limit
+	Zone         string   `json:"zone"`  // failure domain of every node in the group
+	Zones        []string `json:"zones"` // assigned to the nodes round-robin instead of Zone
+	ImagePullRate float64 `json:"image_pull_rate"` // MB/s; 0 keeps the default
+	ReserveFraction float64 `json:"reserve_fraction"` // share of each resource kept free; 0 for none
+	Sockets      int     `json:"sockets"` // NUMA sockets sharing the CPU and memory evenly; 0 or 1 for one
+	Reserved     Reservation `json:"reserved"` // held on every node for system daemons
+	NICBandwidth float64 `json:"nic_bandwidth"` // Mbps the NIC carries; 0 for the network capacity
+	GPUs         int     `json:"gpus"`
+	GPUMemory    float64 `json:"gpu_memory"` // GB on each GPU, shared by containers' GPU memory requests
+}
+
+// Reservation is capacity held on a node for processes that are not
+// containers, such as the kubelet or a log shipper
+type Reservation struct {
+	CPU     float64 `json:"cpu"`
+	Memory  float64 `json:"memory"`
+	Network float64 `json:"network"`
+	IO      float64 `json:"io"`
+}
+
+type ClusterDefinition struct {
+	Nodes []NodeTemplate `json:"nodes"`
+}
+
+func LoadClusterFromFile(filename string) ([]*node.Node, error) {
+	definition, err := LoadClusterDefinition(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCluster(definition)
+}
+
+// LoadClusterDefinition reads a cluster file without building its nodes
+func LoadClusterDefinition(filename string) (ClusterDefinition, error) {
+	var definition ClusterDefinition
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return definition, err
+	}
+
+	if err := json.Unmarshal(data, &definition); err != nil {
+		return definition, err
+	}
+
+	return definition, nil
+}
+
+func NewCluster(definition ClusterDefinition) ([]*node.Node, error) {
+	nodes := make([]*node.Node, 0)
+
+	for _, template := range definition.Nodes {
+		if template.Count <= 0 {
+			return nil, fmt.Errorf("node template %q: count must be positive", template.Name)
+		}
+		if err := validateTemplate(template); err != nil {
+			return nil, err
+		}
+
+		for i := 0; i < template.Count; i++ {
+			nodes = append(nodes, newTemplateNode(template, fmt.Sprintf("%s-%d", template.Name, i), i))
+		}
+	}
+
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("cluster definition contains no nodes")
+	}
+
+	return nodes, nil
+}
+
+// validateTemplate checks everything about a template except its count
+func validateTemplate(template NodeTemplate) error {
+	if template.MaxContainers < 0 {
+		return fmt.Errorf("node template %q: max_containers must not be negative", template.Name)
+	}
+	if template.CPU < 0 || template.Memory < 0 || template.Network < 0 || template.IO < 0 || template.Disk < 0 || template.ImagePullRate < 0 || template.NICBandwidth < 0 {
+		return fmt.Errorf("node template %q: resource capacities must not be negative", template.Name)
+	}
+	if template.ReserveFraction < 0 || template.ReserveFraction >= 1 {
+		return fmt.Errorf("node template %q: reserve_fraction must be in [0, 1)", template.Name)
+	}
+	if template.Sockets < 0 {
+		return fmt.Errorf("node template %q: sockets must not be negative", template.Name)
+	}
+	if template.GPUs < 0 || template.GPUMemory < 0 || (template.GPUs > 0) != (template.GPUMemory > 0) {
+		return fmt.Errorf("node template %q: gpus and gpu_memory must be set together and not be negative", template.Name)
+	}
+	reserved := template.Reserved
+	if reserved.CPU < 0 || reserved.Memory < 0 || reserved.Network < 0 || reserved.IO < 0 {
+		return fmt.Errorf("node template %q: reservations must not be negative", template.Name)
+	}
+	if exceeds(reserved.CPU, template.CPU) || exceeds(reserved.Memory, template.Memory) ||
+		exceeds(reserved.Network, template.Network) || exceeds(reserved.IO, template.IO) {
+		return fmt.Errorf("node template %q: reservations must not exceed the node's capacity", template.Name)
+	}
+	return nil
+}
+
+// exceeds reports whether a reservation is larger than a constrained
+// capacity; reservations of unconstrained (zero) resources are harmless
+func exceeds(reserved, capacity float64) bool {
+	return capacity > 0 && reserved > capacity
+}
+
+// newTemplateNode builds the index-th node of a template
+func newTemplateNode(template NodeTemplate, name string, index int) *node.Node {
+	n := node.NewNode(
+		name,
+		template.CPU,
+		template.Memory,
+		template.Network,
+		template.IO,
+	)
+	n.SetDiskCapacity(template.Disk)
+	n.SetPowerModel(template.IdleWatts, template.WattsPerUtil)
+	n.SetHourlyCost(template.HourlyCost)
+	n.SetMaxContainers(template.MaxContainers)
+	n.SetReserveFraction(template.ReserveFraction)
+	n.SetSockets(template.Sockets)
+	n.SetGPUs(template.GPUs, template.GPUMemory)
+	n.SetNICBandwidth(template.NICBandwidth)
+	n.Reserve(template.Reserved.CPU, template.Reserved.Memory, template.Reserved.Network, template.Reserved.IO)
+	if template.ImagePullRate > 0 {
+		n.SetImagePullRate(template.ImagePullRate)
+	}
+	if len(template.Zones) > 0 {
+		n.SetZone(template.Zones[index%len(template.Zones)])
+	} else {
+		n.SetZone(template.Zone)
+	}
+	return n
+}
+
+// FindTemplate returns the template called name, or the first one if name
+// is empty
+func (d ClusterDefinition) FindTemplate(name string) (NodeTemplate, error) {
+	for _, template := range d.Nodes {
+		if name == "" || template.Name == name {
+			return template, nil
+		}
+	}
+	if name == "" {
+		return NodeTemplate{}, fmt.Errorf("cluster definition contains no node templates")
+	}
+	return NodeTemplate{}, fmt.Errorf("cluster definition has no node template %q", name)
+}
+
+// allocatable is the capacity left to containers once reserved is held
+// back; an unconstrained (zero) capacity stays unconstrained
+func allocatable(total, reserved float64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return total - reserved
+}
+
+// SmallestNode is the per-resource minimum capacity of the nodes, which
+// percentage requests are resolved against so that a container asking for
+// 50% of a node fits on every node. Nodes with unconstrained disk are
+// ignored for disk, and reserved capacity is not counted.
+func SmallestNode(nodes []*node.Node) workLoad.NodeSize {
+	var size workLoad.NodeSize
+	smallest := func(current, capacity float64, first bool) float64 {
+		if first || capacity < current {
+			return capacity
+		}
+		return current
+	}
+	for i, n := range nodes {
+		cpu, memory, network, io := n.Reserved()
+		size.CPU = smallest(size.CPU, allocatable(n.TotalCPU(), cpu), i == 0)
+		size.Memory = smallest(size.Memory, allocatable(n.TotalMemory(), memory), i == 0)
+		size.Network = smallest(size.Network, allocatable(n.TotalNetwork(), network), i == 0)
+		size.IO = smallest(size.IO, allocatable(n.TotalIO(), io), i == 0)
+		if n.TotalDisk() > 0 {
+			size.Disk = smallest(size.Disk, n.TotalDisk(), size.Disk == 0)
+		}
+	}
+	return size
+}
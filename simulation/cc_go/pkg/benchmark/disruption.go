@@ -0,0 +1,50 @@
+// pkg/benchmark/disruption.go - Disruption budgets for evictions
+package benchmark
+
+import (
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+)
+
+// disruptionBudgets counts the running containers of every name that has a
+// disruption budget, so evictions can be checked against it
+type disruptionBudgets map[string]int
+
+func newDisruptionBudgets(nodes []*node.Node) disruptionBudgets {
+	budgets := make(disruptionBudgets)
+	for _, n := range nodes {
+		for _, c := range n.Containers() {
+			if c.MinAvailable() > 0 {
+				budgets[c.Name()]++
+			}
+		}
+	}
+	return budgets
+}
+
+func (d disruptionBudgets) clone() disruptionBudgets {
+	copied := make(disruptionBudgets, len(d))
+	for name, running := range d {
+		copied[name] = running
+	}
+	return copied
+}
+
+// allows reports whether c can be evicted without breaking its budget
+func (d disruptionBudgets) allows(c *container.Container) bool {
+	return c.MinAvailable() <= 0 || d[c.Name()]-1 >= c.MinAvailable()
+}
+
+// evict counts c as no longer running
+func (d disruptionBudgets) evict(c *container.Container) {
+	if c.MinAvailable() > 0 {
+		d[c.Name()]--
+	}
+}
+
+// readmit undoes evict
+func (d disruptionBudgets) readmit(c *container.Container) {
+	if c.MinAvailable() > 0 {
+		d[c.Name()]++
+	}
+}
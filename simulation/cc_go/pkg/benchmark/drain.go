@@ -0,0 +1,61 @@
+// pkg/benchmark/drain.go - Node drain for maintenance simulation
+package benchmark
+
+import (
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+	"time"
+)
+
+// DrainNode cordons n and moves each of its containers elsewhere through the
+// scheduler, as a rolling upgrade would. Successful moves are recorded as
+// migrations. Containers that no other node can take are evicted anyway,
+// recorded as scheduling failures and returned, unless that would break
+// their disruption budget, in which case they stay on the cordoned node.
+func (b *Benchmark) DrainNode(n *node.Node) []*container.Container {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	
+	n.Cordon()
+	
+	evicted := make([]*container.Container, len(n.Containers()))
+	copy(evicted, n.Containers())
+	
+	budgets := newDisruptionBudgets(b.nodes)
+	stranded := make([]*container.Container, 0)
+	kept := 0
+	for _, c := range evicted {
+		n.RemoveContainerRef(c)
+		
+		startTime := time.Now()
+		target, err := b.scheduler.Schedule(c, b.nodes)
+		latency := time.Since(startTime)
+		
+		if err != nil || target == nil || !target.AddContainer(c) {
+			if !budgets.allows(c) {
+				n.Uncordon()
+				n.AddContainer(c)
+				n.Cordon()
+				b.logger.Debugf("Container %s kept on draining node %s by its disruption budget", c.ID(), n.Name())
+				kept++
+				continue
+			}
+			budgets.evict(c)
+			b.logger.Warnf("Container %s could not be rescheduled while draining node %s", c.ID(), n.Name())
+			b.metricsCollector.RecordRemovalEvent(c.ID(), n, b.clock.Now())
+			b.metricsCollector.RecordSchedulingEvent(c, nil, latency, false)
+			b.metricsCollector.RecordDisruption(c)
+			stranded = append(stranded, c)
+			continue
+		}
+		
+		b.pullImage(c, target)
+		b.logger.Debugf("Rescheduled container %s from draining node %s to node %s", c.ID(), n.Name(), target.Name())
+		b.metricsCollector.RecordMigration(c, n, target, node.ClusterLoadVariance(b.nodes))
+		b.metricsCollector.RecordDisruption(c)
+	}
+	
+	b.logger.Infof("Drained node %s: %d rescheduled, %d stranded, %d kept by disruption budgets",
+		n.Name(), len(evicted)-len(stranded)-kept, len(stranded), kept)
+	return stranded
+}
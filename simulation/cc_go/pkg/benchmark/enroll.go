@@ -0,0 +1,44 @@
+// pkg/benchmark/enroll.go - Per-run IDs and clock
+package benchmark
+
+import (
+	"cc_go/pkg/clock"
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+	"cc_go/pkg/scheduler"
+	"fmt"
+	"sync/atomic"
+)
+
+// enrollNode numbers n as the run's next node and gives it the run's clock
+func (b *Benchmark) enrollNode(n *node.Node) {
+	b.lastNodeID++
+	n.SetID(fmt.Sprintf("node-%d", b.lastNodeID))
+	n.SetClock(b.clock)
+}
+
+// enroll numbers c and its sidecars as the run's next containers and stamps
+// their arrival on the run's clock. Runs with the same seed thus assign the
+// same IDs, however many other runs share the process.
+func (b *Benchmark) enroll(c *container.Container) {
+	now := b.clock.Now()
+	for _, member := range append([]*container.Container{c}, c.Sidecars()...) {
+		member.SetID(fmt.Sprintf("container-%d", atomic.AddUint64(&b.lastContainerID, 1)))
+		member.SetCreationTime(now)
+	}
+}
+
+// useClock makes c the run's clock and hands it to everything that tells
+// time: the nodes, the collector, the workload and the scheduler. The
+// caller holds b.mu.
+func (b *Benchmark) useClock(c clock.Clock) {
+	b.clock = c
+	for _, n := range b.nodes {
+		n.SetClock(c)
+	}
+	for _, user := range []interface{}{b.metricsCollector, b.workloadGen, scheduler.Unwrap(b.scheduler)} {
+		if clocked, ok := user.(interface{ SetClock(clock.Clock) }); ok {
+			clocked.SetClock(c)
+		}
+	}
+}
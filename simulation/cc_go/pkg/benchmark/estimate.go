@@ -0,0 +1,113 @@
+// pkg/benchmark/estimate.go - Analytical capacity estimate
+package benchmark
+
+import (
+	"cc_go/pkg/node"
+	"cc_go/pkg/workLoad"
+	"math"
+)
+
+// EstimateResources lists the resources a CapacityEstimate covers, in the
+// order they are reported
+var EstimateResources = []string{"cpu", "memory", "network", "io", "disk", "slots"}
+
+// CapacityEstimate is a quick analytical answer to how many containers of a
+// workload fit on a cluster at once, computed from mean requests without
+// simulating time. It ignores fragmentation, so it is an upper bound.
+type CapacityEstimate struct {
+	Demand     map[string]float64 // mean request of one container
+	Capacity   map[string]float64 // cluster total outside node reserves; missing when some node leaves the resource unconstrained
+	Limits     map[string]float64 // containers the resource alone could hold
+	Containers int                // containers the cluster can hold
+	Bottleneck string             // resource that sets Containers, empty if none constrains it
+}
+
+// EstimateCapacity divides the cluster's capacity by the expected request of
+// a container drawn from wl, per resource, and reports the most limiting one
+func EstimateCapacity(cluster []*node.Node, wl workLoad.WorkloadDefinition) CapacityEstimate {
+	estimate := CapacityEstimate{
+		Demand:   make(map[string]float64),
+		Capacity: make(map[string]float64),
+		Limits:   make(map[string]float64),
+	}
+	
+	wl = wl.Resolve(SmallestNode(cluster))
+	
+	// Expected request: template range midpoints weighted like the generator
+	totalWeight := 0
+	for _, template := range wl.Templates {
+		if template.Weight > 0 {
+			totalWeight += template.Weight
+		}
+	}
+	if totalWeight == 0 || len(cluster) == 0 {
+		return estimate
+	}
+	for _, template := range wl.Templates {
+		if template.Weight <= 0 {
+			continue
+		}
+		share := float64(template.Weight) / float64(totalWeight)
+		estimate.Demand["cpu"] += share * (template.CPUMin + template.CPUMax) / 2
+		estimate.Demand["memory"] += share * (template.MemoryMin + template.MemoryMax) / 2
+		estimate.Demand["network"] += share * (template.NetworkMin + template.NetworkMax) / 2
+		estimate.Demand["io"] += share * (template.IOMin + template.IOMax) / 2
+		estimate.Demand["disk"] += share * (template.DiskMin + template.DiskMax) / 2
+		for _, sidecar := range template.Sidecars {
+			estimate.Demand["cpu"] += share * sidecar.CPU
+			estimate.Demand["memory"] += share * sidecar.Memory
+			estimate.Demand["network"] += share * sidecar.Network
+			estimate.Demand["io"] += share * sidecar.IO
+			estimate.Demand["disk"] += share * sidecar.Disk
+		}
+	}
+	estimate.Demand["slots"] = 1
+	
+	// A zero capacity or container cap means unconstrained on that node,
+	// which makes the resource unconstrained for the cluster as a whole.
+	// Reserved capacity is not available to containers.
+	for _, n := range cluster {
+		cpu, memory, network, io := n.Reserved()
+		reserved := map[string]float64{"cpu": cpu, "memory": memory, "network": network, "io": io}
+		capacities := map[string]float64{
+			"cpu":     n.TotalCPU(),
+			"memory":  n.TotalMemory(),
+			"network": n.TotalNetwork(),
+			"io":      n.TotalIO(),
+			"disk":    n.TotalDisk(),
+			"slots":   float64(n.MaxContainers()),
+		}
+		for resource, capacity := range capacities {
+			if capacity <= 0 {
+				estimate.Capacity[resource] = math.Inf(1)
+			} else if resource == "slots" {
+				estimate.Capacity[resource] += capacity
+			} else {
+				estimate.Capacity[resource] += capacity - n.Headroom(capacity) - reserved[resource]
+			}
+		}
+	}
+	
+	limit := math.Inf(1)
+	for _, resource := range EstimateResources {
+		capacity := estimate.Capacity[resource]
+		if math.IsInf(capacity, 1) {
+			delete(estimate.Capacity, resource)
+			continue
+		}
+		if estimate.Demand[resource] <= 0 {
+			continue
+		}
+		
+		estimate.Limits[resource] = capacity / estimate.Demand[resource]
+		if estimate.Limits[resource] < limit {
+			limit = estimate.Limits[resource]
+			estimate.Bottleneck = resource
+		}
+	}
+	if estimate.Bottleneck != "" {
+		estimate.Containers = int(limit)
+	}
+	
+	return estimate
+}
@@ -0,0 +1,111 @@
+// pkg/benchmark/fairqueue.go - Weighted fair queuing of pending containers
+package benchmark
+
+import (
+	"cc_go/pkg/container"
+	"container/heap"
+	"sort"
+)
+
+// typeWeighted is implemented by workload generators that know how often
+// each container type is generated
+type typeWeighted interface {
+	TypeWeights() map[string]float64
+}
+
+// fairQueue hands out pending containers in weighted fair order by type:
+// each type advances a virtual pass by 1/weight per container dequeued and
+// the non-empty type with the lowest pass goes next, so during a backlog
+// every type is served in proportion to its weight. Within a type
+// containers keep priority-then-arrival order.
+type fairQueue struct {
+	weights map[string]float64
+	queues  map[string]*pendingQueue
+	pass    map[string]float64
+	virtual float64 // pass of the last dequeued type
+	size    int
+}
+
+func newFairQueue(weights map[string]float64) *fairQueue {
+	return &fairQueue{
+		weights: weights,
+		queues:  make(map[string]*pendingQueue),
+		pass:    make(map[string]float64),
+	}
+}
+
+func (q *fairQueue) Len() int {
+	return q.size
+}
+
+func (q *fairQueue) push(c *container.Container) {
+	queue, ok := q.queues[c.Type()]
+	if !ok {
+		queue = &pendingQueue{}
+		q.queues[c.Type()] = queue
+	}
+	// A type that was idle rejoins at the current virtual time rather than
+	// cashing in the turns it did not use
+	if queue.Len() == 0 && q.pass[c.Type()] < q.virtual {
+		q.pass[c.Type()] = q.virtual
+	}
+	heap.Push(queue, c)
+	q.size++
+}
+
+func (q *fairQueue) pop() *container.Container {
+	// Visit types in name order so ties break the same way every run
+	types := make([]string, 0, len(q.queues))
+	for t, queue := range q.queues {
+		if queue.Len() > 0 {
+			types = append(types, t)
+		}
+	}
+	if len(types) == 0 {
+		return nil
+	}
+	sort.Strings(types)
+	
+	next := types[0]
+	for _, t := range types[1:] {
+		if q.pass[t] < q.pass[next] {
+			next = t
+		}
+	}
+	
+	q.virtual = q.pass[next]
+	q.pass[next] += 1 / q.weight(next)
+	q.size--
+	return heap.Pop(q.queues[next]).(*container.Container)
+}
+
+// weight of a container type; types the workload does not list count as
+// the lightest listed type
+func (q *fairQueue) weight(containerType string) float64 {
+	if w, ok := q.weights[containerType]; ok && w > 0 {
+		return w
+	}
+	lightest := 0.0
+	for _, w := range q.weights {
+		if w > 0 && (lightest == 0 || w < lightest) {
+			lightest = w
+		}
+	}
+	if lightest == 0 {
+		return 1
+	}
+	return lightest
+}
+
+// SetFairQueuing makes pending containers dequeue in weighted fair order by
+// type, using the workload's template weights, instead of strictly by
+// priority. It has no effect if the generator does not report type weights.
+func (b *Benchmark) SetFairQueuing(enabled bool) {
+	b.fair = nil
+	if !enabled {
+		return
+	}
+	if weighted, ok := b.workloadGen.(typeWeighted); ok {
+		b.fair = newFairQueue(weighted.TypeWeights())
+	}
+}
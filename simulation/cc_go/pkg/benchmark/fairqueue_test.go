@@ -0,0 +1,62 @@
+// pkg/benchmark/fairqueue_test.go - Weighted fair queuing tests
+package benchmark
+
+import (
+	"math"
+	"testing"
+
+	"cc_go/pkg/container"
+	"cc_go/pkg/metrics"
+	"cc_go/pkg/scheduler"
+	"cc_go/pkg/workLoad"
+)
+
+func TestFairQueueSharesFollowTemplateWeights(t *testing.T) {
+	definition := workLoad.WorkloadDefinition{}
+	for _, template := range []struct {
+		containerType string
+		weight        int
+	}{{"web", 6}, {"db", 3}, {"batch", 1}} {
+		definition.Templates = append(definition.Templates, workLoad.ContainerTemplate{
+			Name: template.containerType, Image: "img", Type: template.containerType, Weight: template.weight,
+			CPUMin: 0.5, CPUMax: 1, MemoryMin: 128, MemoryMax: 256,
+			NetworkMin: 10, NetworkMax: 20, IOMin: 10, IOMax: 20,
+		})
+	}
+	g, err := workLoad.NewWorkload(definition)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := NewBenchmark(scheduler.NewBinPackScheduler(), g, metrics.NewCollector(), NewNeverRemove())
+	b.SetFairQueuing(true)
+	if b.fair == nil {
+		t.Fatal("fair queuing not enabled for a generator with type weights")
+	}
+	
+	// Every type keeps a backlog: each dequeued container is replaced by
+	// another of its type, with priorities mixed within each type
+	for _, containerType := range []string{"web", "db", "batch"} {
+		for i := 0; i < 50; i++ {
+			b.fair.push(container.NewContainer(containerType, "img", 1, 128, 10, 10, containerType, i%3))
+		}
+	}
+	const dequeues = 10000
+	served := make(map[string]int)
+	for i := 0; i < dequeues; i++ {
+		c := b.fair.pop()
+		served[c.Type()]++
+		b.fair.push(container.NewContainer(c.Type(), "img", 1, 128, 10, 10, c.Type(), i%3))
+	}
+	
+	weights := g.TypeWeights()
+	total := 0.0
+	for _, weight := range weights {
+		total += weight
+	}
+	for containerType, weight := range weights {
+		share := float64(served[containerType]) / dequeues
+		if want := weight / total; math.Abs(share-want) > 0.01 {
+			t.Errorf("%s got %.3f of the dequeues, want %.3f from its template weight", containerType, share, want)
+		}
+	}
+}
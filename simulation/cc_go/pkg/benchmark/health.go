@@ -0,0 +1,68 @@
+// pkg/benchmark/health.go - Node health degradation model
+package benchmark
+
+import (
+	"cc_go/pkg/node"
+	"time"
+)
+
+// healthInterval is how often node health is re-evaluated
+const healthInterval = 1 * time.Second
+
+// HealthModel wears nodes down while they are under stress and lets them
+// recover once the stress goes away. A node is stressed when its smoothed
+// utilization (see Node.SmoothedUtilization) runs above the utilization
+// threshold or its load swings more than the variance threshold, i.e. it
+// sees heavy container churn. Smoothing keeps a single busy sample from
+// wearing a node down.
+type HealthModel struct {
+	utilizationThreshold float64
+	varianceThreshold    float64
+	degradeRate          float64 // health lost per update while stressed
+	recoverRate          float64 // health regained per update otherwise
+}
+
+func NewHealthModel() *HealthModel {
+	return &HealthModel{
+		utilizationThreshold: 0.8,
+		varianceThreshold:    0.15,
+		degradeRate:          0.05,
+		recoverRate:          0.02,
+	}
+}
+
+func (h *HealthModel) SetThresholds(utilization, variance float64) {
+	h.utilizationThreshold = utilization
+	h.varianceThreshold = variance
+}
+
+func (h *HealthModel) SetRates(degrade, recover float64) {
+	h.degradeRate = degrade
+	h.recoverRate = recover
+}
+
+// Update applies one step of the model to n
+func (h *HealthModel) Update(n *node.Node) {
+	stressed := n.SmoothedUtilization() > h.utilizationThreshold ||
+		n.LoadVariance() > h.varianceThreshold
+	
+	if stressed {
+		n.UpdateHealthScore(n.HealthScore() - h.degradeRate)
+	} else {
+		n.UpdateHealthScore(n.HealthScore() + h.recoverRate)
+	}
+}
+
+func (b *Benchmark) HealthModel() *HealthModel {
+	return b.healthModel
+}
+
+func (b *Benchmark) updateHealth() bool {
+	b.mu.Lock()
+	for _, n := range b.nodes {
+		b.healthModel.Update(n)
+		b.metricsCollector.RecordNodeHealth(n.Name(), n.HealthScore())
+	}
+	b.mu.Unlock()
+	return true
+}
@@ -0,0 +1,18 @@
+// pkg/benchmark/images.go - Image pulls on placement
+package benchmark
+
+import (
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+)
+
+// pullImage charges c the time to pull its image onto n, which is nothing
+// when n already caches it, and caches the image there. It runs once a
+// placement is committed, after the scheduler's tentative placements have
+// been rolled back, so only real placements warm the cache.
+func (b *Benchmark) pullImage(c *container.Container, n *node.Node) {
+	hit := n.HasImage(c.Image())
+	c.SetPullDuration(n.PullDuration(c))
+	n.CacheImage(c.Image())
+	b.metricsCollector.RecordImagePull(hit)
+}
@@ -0,0 +1,103 @@
+// pkg/benchmark/loadtarget.go - Arrival rate control towards a utilization target
+package benchmark
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	// arrivalInterval is the arrival tick; without a utilization target
+	// one container arrives per tick
+	arrivalInterval = 100 * time.Millisecond
+
+	// loadControlInterval is how often the arrival rate is adjusted
+	loadControlInterval = 1 * time.Second
+
+	// Controller gains on the log of the arrival rate: proportional to the
+	// change of the utilization error, integral per second of error
+	loadProportionalGain = 2.0
+	loadIntegralGain     = 0.5
+
+	// maxRateStep bounds the relative change of the rate per adjustment,
+	// and the rate stays within [minArrivalRate, maxArrivalRate], so the
+	// lag between arrivals and utilization cannot build up oscillations
+	maxRateStep    = 0.2
+	minArrivalRate = 0.1   // containers per second
+	maxArrivalRate = 100.0 // containers per second
+
+	// saturatedFailureShare is the share of placements failing since the
+	// last adjustment above which the cluster counts as saturated: more
+	// arrivals would only fail, so the rate is not raised
+	saturatedFailureShare = 0.5
+)
+
+// loadController is a PI controller that raises the arrival rate while
+// cluster utilization is below the target and lowers it above. It works in
+// the velocity form on the log of the rate, so the rate changes by a factor
+// each step and there is no integral to wind up while it is clamped. A
+// target the cluster cannot reach, e.g. because fragmentation fails
+// placements first, leaves the rate where placements start failing.
+type loadController struct {
+	target    float64
+	rate      float64 // containers per second
+	lastError float64
+	credit    float64 // fractional arrivals carried over to the next tick
+	arrived   int     // arrivals since the last adjustment
+	failed    int     // failed placements since the last adjustment
+}
+
+// SetUtilizationTarget steers the arrival rate so average node utilization
+// holds near target, starting from one container per arrival tick. Zero
+// disables it, arriving at one container per tick throughout.
+func (b *Benchmark) SetUtilizationTarget(target float64) error {
+	if target == 0 {
+		b.loadTarget = nil
+		return nil
+	}
+	if target < 0 || target >= 1 {
+		return fmt.Errorf("utilization target must be in (0, 1), got %g", target)
+	}
+	b.loadTarget = &loadController{
+		target:    target,
+		rate:      1 / arrivalInterval.Seconds(),
+		lastError: target,
+	}
+	return nil
+}
+
+// arrivalsDue returns how many containers arrive this tick; the caller
+// holds b.mu
+func (b *Benchmark) arrivalsDue() int {
+	l := b.loadTarget
+	if l == nil {
+		return 1
+	}
+	l.credit += l.rate * arrivalInterval.Seconds()
+	due := int(l.credit)
+	l.credit -= float64(due)
+	l.arrived += due
+	return due
+}
+
+// controlLoad adjusts the arrival rate to the cluster utilization
+func (b *Benchmark) controlLoad() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	l := b.loadTarget
+	utilization := b.clusterUtilization()
+	deviation := l.target - utilization
+	step := loadProportionalGain*(deviation-l.lastError) + loadIntegralGain*deviation*loadControlInterval.Seconds()
+	step = math.Max(-maxRateStep, math.Min(maxRateStep, step))
+	if step > 0 && float64(l.failed) > saturatedFailureShare*float64(l.arrived) {
+		step = 0
+	}
+	l.arrived, l.failed = 0, 0
+	l.rate = math.Max(minArrivalRate, math.Min(maxArrivalRate, l.rate*math.Exp(step)))
+	l.lastError = deviation
+
+	b.metricsCollector.RecordLoadControl(l.target, utilization, l.rate)
+	return true
+}
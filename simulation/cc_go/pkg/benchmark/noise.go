@@ -0,0 +1,40 @@
+// pkg/benchmark/noise.go - Fluctuating container resource usage
+package benchmark
+
+import (
+	"math/rand"
+	"time"
+)
+
+// noiseInterval is how often container usage is re-drawn
+const noiseInterval = 500 * time.Millisecond
+
+// SetUsageNoise makes each placed container's actual usage wander around
+// its request by up to amplitude (0.2 means +/-20%), re-drawn every
+// noiseInterval. Scheduling still reserves the static request, so a busy
+// node can be momentarily over-committed. A seed of 0 seeds from the clock.
+func (b *Benchmark) SetUsageNoise(amplitude float64, seed int64) {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	b.noiseAmplitude = amplitude
+	b.noiseRng = rand.New(rand.NewSource(seed))
+}
+
+// SetLoadSmoothing sets the factor of every node's smoothed utilization,
+// which the health model judges stress by. See Node.SetLoadSmoothing.
+func (b *Benchmark) SetLoadSmoothing(alpha float64) {
+	b.loadSmoothing = alpha
+}
+
+func (b *Benchmark) fluctuateUsage() bool {
+	b.mu.Lock()
+	for _, n := range b.nodes {
+		for _, c := range n.Containers() {
+			c.SetUsageFactor(1 + b.noiseAmplitude*(2*b.noiseRng.Float64()-1))
+		}
+		n.ObserveLoad()
+	}
+	b.mu.Unlock()
+	return true
+}
@@ -0,0 +1,30 @@
+// pkg/benchmark/numa.go - NUMA pinning outcomes
+package benchmark
+
+import (
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+)
+
+// recordPinning notes whether a NUMA-pinned container landed within one
+// socket of n
+func (b *Benchmark) recordPinning(c *container.Container, n *node.Node) {
+	if c.NUMAPinned() {
+		b.metricsCollector.RecordPinnedPlacement(c, n.CrossSocket(c))
+	}
+}
+
+// notePinningFailure records a failed placement of a NUMA-pinned container
+// when some node had the aggregate capacity for it, i.e. only pinning
+// stood in the way
+func (b *Benchmark) notePinningFailure(c *container.Container) {
+	if !c.NUMAPinned() {
+		return
+	}
+	for _, n := range b.nodes {
+		if n.CanFit(c) {
+			b.metricsCollector.RecordPinningFailure(c)
+			return
+		}
+	}
+}
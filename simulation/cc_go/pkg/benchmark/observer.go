@@ -0,0 +1,35 @@
+// pkg/benchmark/observer.go - Scheduler state changes as metrics
+package benchmark
+
+import (
+	"cc_go/pkg/metrics"
+	"cc_go/pkg/scheduler"
+)
+
+// transitionRecorder forwards a scheduler's state changes to the collector
+type transitionRecorder struct {
+	collector metrics.Collector
+}
+
+func (r transitionRecorder) StateChanged(event scheduler.StateEvent) {
+	r.collector.RecordStateTransition(metrics.StateTransition{
+		Scheduler: event.Scheduler,
+		Kind:      event.Kind,
+		From:      event.From,
+		To:        event.To,
+		Detail:    event.Detail,
+	})
+}
+
+// observeScheduler records the scheduler's state changes for the run if it
+// reports them; the returned function stops the reports
+func (b *Benchmark) observeScheduler() func() {
+	observable, ok := scheduler.Unwrap(b.scheduler).(scheduler.Observable)
+	if !ok {
+		return func() {}
+	}
+	observable.SetObserver(transitionRecorder{b.metricsCollector})
+	return func() {
+		observable.SetObserver(nil)
+	}
+}
@@ -0,0 +1,28 @@
+// pkg/benchmark/oom.go - Out-of-memory kills on over-committed nodes
+package benchmark
+
+import (
+)
+
+// relieveMemoryPressure reclaims memory on every node whose effective
+// memory use exceeds its capacity, then OOM-kills containers where that
+// was not enough and resubmits them for scheduling. The caller must hold
+// b.mu.
+func (b *Benchmark) relieveMemoryPressure() {
+	now := b.clock.Now()
+	for _, n := range b.nodes {
+		reclaimed, victims := n.HandleMemoryPressure()
+		if reclaimed > 0 {
+			b.logger.Debugf("Reclaimed %.1f MB of memory on node %s", reclaimed, n.Name())
+			b.metricsCollector.RecordMemoryReclaim(n, reclaimed, len(victims) == 0)
+		}
+		for _, victim := range victims {
+			b.logger.Warnf("OOM-killed container %s (priority %d) on node %s", victim.ID(), victim.Priority(), n.Name())
+			b.metricsCollector.RecordRemovalEvent(victim.ID(), n, now)
+			b.metricsCollector.RecordOOMKill(victim, n)
+			b.metricsCollector.RecordDisruption(victim)
+			victim.SetQueuedAt(now)
+			b.enqueue(victim)
+		}
+	}
+}
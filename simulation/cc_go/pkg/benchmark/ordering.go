@@ -0,0 +1,103 @@
+// pkg/benchmark/ordering.go - Ordered container groups
+package benchmark
+
+import (
+	"cc_go/pkg/container"
+	"time"
+)
+
+// orderedGroup tracks the rollout of one ordered group: members are only
+// scheduled once their predecessor has been placed
+type orderedGroup struct {
+	next   int                          // index of the member allowed to schedule
+	held   map[int]*container.Container // members that arrived before their predecessor was placed
+	heldAt map[int]time.Time
+	failed bool // a member failed permanently; later members are cancelled
+}
+
+func (b *Benchmark) orderedGroup(name string) *orderedGroup {
+	group, ok := b.groups[name]
+	if !ok {
+		group = &orderedGroup{
+			held:   make(map[int]*container.Container),
+			heldAt: make(map[int]time.Time),
+		}
+		b.groups[name] = group
+	}
+	return group
+}
+
+// admit reports whether c may be scheduled now. A member whose predecessor
+// has not been placed yet is held until it is; a member of a group that
+// already failed is cancelled.
+func (b *Benchmark) admit(c *container.Container) bool {
+	if c.Group() == "" {
+		return true
+	}
+	
+	group := b.orderedGroup(c.Group())
+	if group.failed {
+		b.logger.Warnf("Cancelled container %s: an earlier member of group %s failed", c.ID(), c.Group())
+		b.metricsCollector.RecordOrderingCancellation(c)
+		return false
+	}
+	if c.GroupIndex() > group.next {
+		b.logger.Debugf("Holding container %s until member %d of group %s is placed", c.ID(), c.GroupIndex()-1, c.Group())
+		group.held[c.GroupIndex()] = c
+		group.heldAt[c.GroupIndex()] = b.clock.Now()
+		return false
+	}
+	
+	b.metricsCollector.RecordOrderingDelay(0)
+	return true
+}
+
+// orderedPlaced lets the successor of a placed group member schedule,
+// queueing it right away if it is already waiting
+func (b *Benchmark) orderedPlaced(c *container.Container) {
+	if c.Group() == "" {
+		return
+	}
+	
+	group := b.orderedGroup(c.Group())
+	if c.GroupIndex() != group.next {
+		return
+	}
+	group.next++
+	
+	if successor, ok := group.held[group.next]; ok {
+		b.metricsCollector.RecordOrderingDelay(b.clock.Now().Sub(group.heldAt[group.next]))
+		delete(group.held, group.next)
+		delete(group.heldAt, group.next)
+		b.enqueue(successor)
+	}
+}
+
+// orderedFailed cancels every later member of a group whose member c failed
+// permanently
+func (b *Benchmark) orderedFailed(c *container.Container) {
+	if c.Group() == "" {
+		return
+	}
+	
+	group := b.orderedGroup(c.Group())
+	group.failed = true
+	for index, successor := range group.held {
+		b.logger.Warnf("Cancelled container %s: member %d of group %s failed", successor.ID(), c.GroupIndex(), c.Group())
+		b.metricsCollector.RecordOrderingCancellation(successor)
+		delete(group.held, index)
+		delete(group.heldAt, index)
+	}
+}
+
+// abandonHeld records every group member still waiting for its predecessor
+// as a permanent failure
+func (b *Benchmark) abandonHeld() {
+	for name, group := range b.groups {
+		for _, c := range group.held {
+			b.logger.Warnf("Container %s of group %s still waiting for its predecessor at shutdown", c.ID(), name)
+			b.metricsCollector.RecordSchedulingEvent(c, nil, 0, false)
+		}
+	}
+	b.groups = make(map[string]*orderedGroup)
+}
@@ -0,0 +1,276 @@
+// pkg/benchmark/preemption.go - Priority preemption
+package benchmark
+
+import (
+	"cc_go/pkg/container"
+	"cc_go/pkg/metrics"
+	"cc_go/pkg/node"
+	"cc_go/pkg/scheduler"
+	"errors"
+	"sort"
+)
+
+// SetPreemption lets a container that fits nowhere evict lower-priority
+// containers to make room. Evicted containers go back on the pending queue
+// to be placed elsewhere. Preemption applies to containers scheduled one at
+// a time, not to batched waves.
+func (b *Benchmark) SetPreemption(enabled bool) {
+	b.preemption = enabled
+}
+
+// ErrNoPreemptionPlan is returned by PlanPreemption when no node can make
+// room for the container by evicting lower-priority ones
+var ErrNoPreemptionPlan = errors.New("no preemption makes room")
+
+// The victim search tries at most maxPreemptionCandidates of a node's
+// cheapest lower-priority containers and gives up on a node after
+// maxPreemptionSteps partial plans, keeping the best plan found so far
+const (
+	maxPreemptionCandidates = 24
+	maxPreemptionSteps      = 4096
+)
+
+// preemptionPlan is a node and the containers to evict from it
+type preemptionPlan struct {
+	target  *node.Node
+	victims []*container.Container
+}
+
+// planScore ranks plans: fewer Guaranteed victims first, then fewer
+// Burstable ones, so BestEffort containers are evicted before any other
+// class; then lower total disruption cost, lower total victim priority and
+// fewer victims
+type planScore struct {
+	guaranteed int
+	burstable  int
+	cost       float64
+	priority   int
+	count      int
+}
+
+func (s planScore) less(other planScore) bool {
+	if s.guaranteed != other.guaranteed {
+		return s.guaranteed < other.guaranteed
+	}
+	if s.burstable != other.burstable {
+		return s.burstable < other.burstable
+	}
+	if s.cost != other.cost {
+		return s.cost < other.cost
+	}
+	if s.priority != other.priority {
+		return s.priority < other.priority
+	}
+	return s.count < other.count
+}
+
+func (s planScore) add(victim *container.Container) planScore {
+	next := planScore{s.guaranteed, s.burstable, s.cost + victim.DisruptionCost(), s.priority + victim.Priority(), s.count + 1}
+	switch victim.QoSClass() {
+	case container.QoSGuaranteed:
+		next.guaranteed++
+	case container.QoSBurstable:
+		next.burstable++
+	}
+	return next
+}
+
+// PlanPreemption finds where c can be placed by evicting lower-priority
+// containers, considering every node: of all sets of victims that make
+// room without breaking a disruption budget, it returns the one evicting
+// the fewest Guaranteed and then Burstable containers, so BestEffort ones
+// go first; then the one with the lowest total disruption cost, the lowest
+// total priority and the fewest victims, so one cheap eviction wins over
+// several. Every victim in the plan is needed. Nothing is evicted.
+func PlanPreemption(c *container.Container, nodes []*node.Node) (*node.Node, []*container.Container, error) {
+	search := &victimSearch{c: c, budgets: newDisruptionBudgets(nodes)}
+	for _, n := range nodes {
+		search.node(n)
+	}
+	if search.best == nil {
+		return nil, nil, ErrNoPreemptionPlan
+	}
+	return search.best.target, search.best.victims, nil
+}
+
+// victimSearch is a branch and bound search for the cheapest preemption
+// plan across nodes
+type victimSearch struct {
+	c         *container.Container
+	budgets   disruptionBudgets
+	best      *preemptionPlan
+	bestScore planScore
+	
+	// State of the node being searched
+	n          *node.Node
+	candidates []*container.Container
+	remaining  []resourceSum // freed by evicting every candidate from i on
+	chosen     []*container.Container
+	steps      int
+}
+
+// resourceSum is an amount of every resource
+type resourceSum struct {
+	cpu, memory, network, io, disk float64
+}
+
+func (r resourceSum) plus(c *container.Container) resourceSum {
+	return resourceSum{r.cpu + c.CPURequest(), r.memory + c.MemoryRequest(),
+		r.network + c.NetworkRequest(), r.io + c.IORequest(), r.disk + c.DiskRequest()}
+}
+
+func (r resourceSum) add(other resourceSum) resourceSum {
+	return resourceSum{r.cpu + other.cpu, r.memory + other.memory,
+		r.network + other.network, r.io + other.io, r.disk + other.disk}
+}
+
+func (s *victimSearch) fits(freed resourceSum, count int) bool {
+	return fitsAfterEviction(s.n, s.c, count, freed.cpu, freed.memory, freed.network, freed.io, freed.disk)
+}
+
+// node searches n for a plan better than the best so far
+func (s *victimSearch) node(n *node.Node) {
+	if n.IsCordoned() || !scheduler.OnNamedNode.Admit(s.c, n) {
+		return
+	}
+	
+	candidates := make([]*container.Container, 0)
+	for _, existing := range n.Containers() {
+		if existing.Priority() < s.c.Priority() {
+			candidates = append(candidates, existing)
+		}
+	}
+	// Cheapest first, so the first plans found are good bounds
+	sort.Slice(candidates, func(i, j int) bool {
+		iRank, jRank := container.QoSRank(candidates[i].QoSClass()), container.QoSRank(candidates[j].QoSClass())
+		if iRank != jRank {
+			return iRank < jRank
+		}
+		if candidates[i].DisruptionCost() != candidates[j].DisruptionCost() {
+			return candidates[i].DisruptionCost() < candidates[j].DisruptionCost()
+		}
+		if candidates[i].Priority() != candidates[j].Priority() {
+			return candidates[i].Priority() < candidates[j].Priority()
+		}
+		return candidates[i].PlacedAt().After(candidates[j].PlacedAt())
+	})
+	if len(candidates) > maxPreemptionCandidates {
+		candidates = candidates[:maxPreemptionCandidates]
+	}
+	
+	s.n, s.candidates, s.chosen, s.steps = n, candidates, s.chosen[:0], 0
+	s.remaining = make([]resourceSum, len(candidates)+1)
+	for i := len(candidates) - 1; i >= 0; i-- {
+		s.remaining[i] = s.remaining[i+1].plus(candidates[i])
+	}
+	s.extend(0, resourceSum{}, planScore{})
+}
+
+// extend tries adding each candidate from i on to the chosen victims
+func (s *victimSearch) extend(i int, freed resourceSum, score planScore) {
+	s.steps++
+	if s.steps > maxPreemptionSteps {
+		return
+	}
+	if len(s.chosen) > 0 && s.fits(freed, len(s.chosen)) && s.n.FitsGPUWithout(s.c, s.chosen) {
+		s.best = &preemptionPlan{target: s.n, victims: append([]*container.Container(nil), s.chosen...)}
+		s.bestScore = score
+		return
+	}
+	// Give up on the branch if even evicting everything left cannot make room
+	if !s.fits(freed.add(s.remaining[i]), len(s.chosen)+len(s.candidates)-i) {
+		return
+	}
+	if s.c.GPUMemoryRequest() > 0 && !s.n.FitsGPUWithout(s.c, append(append([]*container.Container(nil), s.chosen...), s.candidates[i:]...)) {
+		return
+	}
+	
+	for j := i; j < len(s.candidates); j++ {
+		candidate := s.candidates[j]
+		next := score.add(candidate)
+		// Later candidates only cost more
+		if s.best != nil && !next.less(s.bestScore) {
+			break
+		}
+		if !s.budgets.allows(candidate) {
+			continue
+		}
+		s.budgets.evict(candidate)
+		s.chosen = append(s.chosen, candidate)
+		s.extend(j+1, freed.plus(candidate), next)
+		s.chosen = s.chosen[:len(s.chosen)-1]
+		s.budgets.readmit(candidate)
+	}
+}
+
+// planPreemption plans room for c, or returns nil if there is none
+func (b *Benchmark) planPreemption(c *container.Container) *preemptionPlan {
+	target, victims, err := PlanPreemption(c, b.nodes)
+	if err != nil {
+		return nil
+	}
+	return &preemptionPlan{target: target, victims: victims}
+}
+
+// preempt records the plan and carries it out, putting the victims back on
+// the pending queue
+func (b *Benchmark) preempt(c *container.Container, plan *preemptionPlan) {
+	b.metricsCollector.RecordPreemptionPlan(plan.quality())
+	
+	now := b.clock.Now()
+	for _, victim := range plan.victims {
+		if !plan.target.RemoveContainerRef(victim) {
+			continue
+		}
+		b.logger.Infof("Preempted container %s (priority %d) on node %s for container %s (priority %d)",
+			victim.ID(), victim.Priority(), plan.target.Name(), c.ID(), c.Priority())
+		b.metricsCollector.RecordRemovalEvent(victim.ID(), plan.target, now)
+		b.metricsCollector.RecordPreemption(victim, plan.target)
+		b.metricsCollector.RecordDisruption(victim)
+		victim.SetQueuedAt(now)
+		b.enqueue(victim)
+	}
+}
+
+// quality summarises the plan for the metrics; a nil plan was not found
+func (p *preemptionPlan) quality() metrics.PreemptionPlan {
+	if p == nil {
+		return metrics.PreemptionPlan{}
+	}
+	quality := metrics.PreemptionPlan{Found: true, Victims: len(p.victims)}
+	for _, victim := range p.victims {
+		quality.Priority += victim.Priority()
+		quality.Cost += victim.DisruptionCost()
+	}
+	return quality
+}
+
+// fitsWithout reports whether c would fit on n once victims are gone
+func fitsWithout(n *node.Node, c *container.Container, victims []*container.Container) bool {
+	var cpu, memory, network, io, disk float64
+	for _, victim := range victims {
+		cpu += victim.CPURequest()
+		memory += victim.MemoryRequest()
+		network += victim.NetworkRequest()
+		io += victim.IORequest()
+		disk += victim.DiskRequest()
+	}
+	return fitsAfterEviction(n, c, len(victims), cpu, memory, network, io, disk) && n.FitsGPUWithout(c, victims)
+}
+
+// fitsAfterEviction reports whether c would fit on n once count containers
+// holding the given resources are gone
+func fitsAfterEviction(n *node.Node, c *container.Container, count int, cpu, memory, network, io, disk float64) bool {
+	if n.MaxContainers() > 0 && n.ContainerCount()-count >= n.MaxContainers() {
+		return false
+	}
+	
+	fits := func(request, available, freed, total float64) bool {
+		return total == 0 || request <= available+freed-n.Headroom(total)
+	}
+	return fits(c.CPURequest(), n.AvailableCPU(), cpu, n.TotalCPU()) &&
+		fits(c.MemoryRequest(), n.AvailableMemory(), memory, n.TotalMemory()) &&
+		fits(c.NetworkRequest(), n.AvailableNetwork(), network, n.TotalNetwork()) &&
+		fits(c.IORequest(), n.AvailableIO(), io, n.TotalIO()) &&
+		fits(c.DiskRequest(), n.AvailableDisk(), disk, n.TotalDisk())
+}
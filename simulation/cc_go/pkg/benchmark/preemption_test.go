@@ -0,0 +1,132 @@
+// pkg/benchmark/preemption_test.go - Preemption planning against naive preemption
+package benchmark
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+	"cc_go/pkg/scheduler"
+)
+
+// naivePreemption is the plan a simple preemptor would make: on the most
+// utilized node where it works, evict the lowest-priority containers until
+// c fits, ignoring QoS classes. It is the baseline PlanPreemption is judged against.
+func naivePreemption(c *container.Container, nodes []*node.Node) *preemptionPlan {
+	byUtilization := append([]*node.Node(nil), nodes...)
+	sort.SliceStable(byUtilization, func(i, j int) bool {
+		return byUtilization[i].Utilization() > byUtilization[j].Utilization()
+	})
+	
+	budgets := newDisruptionBudgets(nodes)
+	for _, n := range byUtilization {
+		if n.IsCordoned() || !scheduler.OnNamedNode.Admit(c, n) {
+			continue
+		}
+		candidates := make([]*container.Container, 0)
+		for _, existing := range n.Containers() {
+			if existing.Priority() < c.Priority() {
+				candidates = append(candidates, existing)
+			}
+		}
+		sort.Slice(candidates, func(i, j int) bool {
+			return candidates[i].Priority() < candidates[j].Priority()
+		})
+		
+		remaining := budgets.clone()
+		victims := make([]*container.Container, 0)
+		for _, candidate := range candidates {
+			if fitsWithout(n, c, victims) {
+				break
+			}
+			if remaining.allows(candidate) {
+				remaining.evict(candidate)
+				victims = append(victims, candidate)
+			}
+		}
+		if len(victims) > 0 && fitsWithout(n, c, victims) {
+			return &preemptionPlan{target: n, victims: victims}
+		}
+	}
+	return nil
+}
+
+// score ranks the plan the way PlanPreemption does
+func (p *preemptionPlan) score() planScore {
+	var score planScore
+	for _, victim := range p.victims {
+		score = score.add(victim)
+	}
+	return score
+}
+
+// preemptionScenario fills a small cluster with containers of mixed
+// priority, QoS class, disruption cost and budget, and returns it with a
+// high-priority container that fits on no node as it is
+func preemptionScenario(rng *rand.Rand) ([]*node.Node, *container.Container) {
+	nodes := make([]*node.Node, 6)
+	for i := range nodes {
+		nodes[i] = node.NewNode(fmt.Sprintf("node-%d", i), 8, 8192, 1000, 1000)
+	}
+	costs := []float64{0.5, 1, 2, 5}
+	for _, n := range nodes {
+		for attempt := 0; attempt < 12; attempt++ {
+			name := fmt.Sprintf("svc-%d", rng.Intn(4))
+			cpu, memory := float64(1+rng.Intn(2)), float64(256*(1+rng.Intn(8)))
+			c := container.NewContainer(name, "img", cpu, memory, 10, 10, "web", rng.Intn(3))
+			c.SetDisruption(costs[rng.Intn(len(costs))], rng.Intn(2)*2)
+			if rng.Intn(3) == 0 {
+				c.SetLimits(cpu, memory)
+			}
+			n.AddContainer(c)
+		}
+	}
+	return nodes, container.NewContainer("urgent", "img", 3, 3072, 10, 10, "web", 3)
+}
+
+func TestPlannedPreemptionBeatsNaive(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var plans, naiveMissed int
+	var plannedCost, naiveCost float64
+	for scenario := 0; scenario < 500; scenario++ {
+		nodes, c := preemptionScenario(rng)
+		if _, err := scheduler.NewFirstFitScheduler().Schedule(c, nodes); err == nil {
+			continue
+		}
+		
+		target, victims, err := PlanPreemption(c, nodes)
+		naive := naivePreemption(c, nodes)
+		if err != nil {
+			if naive != nil {
+				t.Fatalf("scenario %d: no plan, but naive preemption evicts %d from %s", scenario, len(naive.victims), naive.target.Name())
+			}
+			continue
+		}
+		planned := &preemptionPlan{target: target, victims: victims}
+		if !fitsWithout(target, c, victims) {
+			t.Fatalf("scenario %d: %s does not fit on %s after evicting %d", scenario, c.Name(), target.Name(), len(victims))
+		}
+		plans++
+		if naive == nil {
+			naiveMissed++
+			continue
+		}
+		plannedCost += planned.quality().Cost
+		naiveCost += naive.quality().Cost
+		if naive.score().less(planned.score()) {
+			t.Errorf("scenario %d: naive plan %+v ranks above the planned %+v", scenario, naive.score(), planned.score())
+		}
+	}
+	
+	if plans == 0 {
+		t.Fatal("no scenario needed preemption")
+	}
+	if plannedCost >= naiveCost {
+		t.Errorf("planned preemption cost %g where naive preemption found a plan, naive %g", plannedCost, naiveCost)
+	}
+	t.Logf("%d plans, naive preemption found none for %d; where both did, disruption cost %g planned, %g naive",
+		plans, naiveMissed, plannedCost, naiveCost)
+}
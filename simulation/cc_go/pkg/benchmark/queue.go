@@ -0,0 +1,86 @@
+// pkg/benchmark/queue.go - Priority ordering of pending containers
+package benchmark
+
+import (
+	"cc_go/pkg/container"
+	"container/heap"
+)
+
+// pendingQueue is a container/heap of containers waiting to be scheduled,
+// ordered by priority (highest first) and then by arrival (oldest first).
+// BestEffort containers come after all others whatever their priority, so
+// they only get what is left.
+type pendingQueue []*container.Container
+
+func (q pendingQueue) Len() int {
+	return len(q)
+}
+
+func (q pendingQueue) Less(i, j int) bool {
+	iBestEffort, jBestEffort := q[i].QoSClass() == container.QoSBestEffort, q[j].QoSClass() == container.QoSBestEffort
+	if iBestEffort != jBestEffort {
+		return jBestEffort
+	}
+	if q[i].Priority() != q[j].Priority() {
+		return q[i].Priority() > q[j].Priority()
+	}
+	return q[i].CreationTime().Before(q[j].CreationTime())
+}
+
+func (q pendingQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *pendingQueue) Push(x interface{}) {
+	*q = append(*q, x.(*container.Container))
+}
+
+func (q *pendingQueue) Pop() interface{} {
+	old := *q
+	c := old[len(old)-1]
+	old[len(old)-1] = nil
+	*q = old[:len(old)-1]
+	return c
+}
+
+// enqueue adds a container to the pending queue
+func (b *Benchmark) enqueue(c *container.Container) {
+	if b.fair != nil {
+		b.fair.push(c)
+		return
+	}
+	heap.Push(&b.pending, c)
+}
+
+// drainPending schedules every pending container in priority order, or in
+// weighted fair order by type if fair queuing is enabled
+func (b *Benchmark) drainPending() {
+	for b.pendingLen() > 0 {
+		if b.fair != nil {
+			b.scheduleContainer(b.fair.pop())
+		} else {
+			b.scheduleContainer(heap.Pop(&b.pending).(*container.Container))
+		}
+	}
+}
+
+func (b *Benchmark) pendingLen() int {
+	if b.fair != nil {
+		return b.fair.Len()
+	}
+	return b.pending.Len()
+}
+
+// byPriority returns the containers in the order the pending queue would
+// hand them out, so a batch sees high-priority containers first
+func byPriority(containers []*container.Container) []*container.Container {
+	queue := make(pendingQueue, len(containers))
+	copy(queue, containers)
+	heap.Init(&queue)
+	
+	ordered := make([]*container.Container, 0, len(containers))
+	for queue.Len() > 0 {
+		ordered = append(ordered, heap.Pop(&queue).(*container.Container))
+	}
+	return ordered
+}
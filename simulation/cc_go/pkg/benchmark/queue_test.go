@@ -0,0 +1,66 @@
+// pkg/benchmark/queue_test.go - Priority ordering under capacity pressure
+package benchmark
+
+import (
+	"testing"
+	"time"
+
+	"cc_go/pkg/scheduler"
+	"cc_go/pkg/workLoad"
+)
+
+// mixedPriorityWorkload arrives as 1-CPU containers of priority 0, 1 and 2
+// in equal shares
+func mixedPriorityWorkload() workLoad.WorkloadDefinition {
+	definition := workLoad.WorkloadDefinition{}
+	for priority, name := range []string{"low", "medium", "high"} {
+		definition.Templates = append(definition.Templates, workLoad.ContainerTemplate{
+			Name: name, Image: "img", Type: name, Priority: priority, Weight: 1,
+			CPUMin: 1, CPUMax: 1, MemoryMin: 256, MemoryMax: 256,
+			NetworkMin: 10, NetworkMax: 10, IOMin: 10, IOMax: 10,
+			LifetimeMin: 1, LifetimeMax: 3,
+		})
+	}
+	return definition
+}
+
+func TestHigherPrioritySucceedsMoreUnderPressure(t *testing.T) {
+	// Ten arrivals a second against room for eight containers that live
+	// one to three seconds: most of the pending containers cannot be placed.
+	// Frequent retries keep a backlog that the queue drains by priority
+	cluster := ClusterDefinition{Nodes: []NodeTemplate{{Name: "small", Count: 2, CPU: 4, Memory: 4096, Network: 1000, IO: 1000}}}
+	for _, batchSize := range []int{1, 10} {
+		results, err := RunScenario(ScenarioConfig{
+			Scheduler: scheduler.NewFirstFitScheduler(),
+			Cluster:   cluster,
+			Workload:  mixedPriorityWorkload(),
+			Duration:  60 * time.Second,
+			Seed:      1,
+			Cleanup:   NewLifetimeBased(),
+			Setup: func(b *Benchmark) error {
+				b.SetAccelerated(true)
+				b.SetRetryPolicy(10, 100*time.Millisecond)
+				b.SetBatchSize(batchSize)
+				return nil
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		
+		rates := make([]float64, 3)
+		for priority := range rates {
+			stats := results.PriorityStats[priority]
+			if stats.Scheduled+stats.Failures == 0 {
+				t.Fatalf("batch size %d: no containers of priority %d", batchSize, priority)
+			}
+			rates[priority] = stats.SuccessRate()
+		}
+		if rates[0] > 0.9 {
+			t.Fatalf("batch size %d: priority 0 succeeded %.2f of the time; the cluster is not under pressure", batchSize, rates[0])
+		}
+		if !(rates[0] < rates[1] && rates[1] < rates[2]) {
+			t.Errorf("batch size %d: success rates by priority 0, 1, 2 are %.2f, %.2f, %.2f, want increasing", batchSize, rates[0], rates[1], rates[2])
+		}
+	}
+}
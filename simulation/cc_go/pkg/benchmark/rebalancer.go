@@ -0,0 +1,127 @@
+// pkg/benchmark/rebalancer.go - Periodic container rebalancing
+package benchmark
+
+import (
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+	"sort"
+)
+
+// Migration describes a single container move performed by the Rebalancer.
+type Migration struct {
+	Container *container.Container
+	From      *node.Node
+	To        *node.Node
+}
+
+// Rebalancer moves containers from hot nodes to cold ones whenever the
+// spread of utilization across the cluster exceeds a threshold.
+type Rebalancer struct {
+	threshold     float64 // cluster load variance that triggers a pass
+	maxMigrations int     // upper bound on moves per pass
+}
+
+func NewRebalancer() *Rebalancer {
+	return &Rebalancer{
+		threshold:     0.1,
+		maxMigrations: 5,
+	}
+}
+
+func (r *Rebalancer) SetThreshold(threshold float64) {
+	r.threshold = threshold
+}
+
+func (r *Rebalancer) SetMaxMigrations(count int) {
+	r.maxMigrations = count
+}
+
+// Rebalance performs one pass over the nodes and returns the migrations it
+// made. A container is only moved if the target can fit it, the move
+// narrows the utilization gap between the two nodes and its disruption
+// budget allows it; the cheapest containers to disrupt are moved first.
+func (r *Rebalancer) Rebalance(nodes []*node.Node) []Migration {
+	migrations := make([]Migration, 0)
+	if len(nodes) < 2 {
+		return migrations
+	}
+
+	budgets := newDisruptionBudgets(nodes)
+	for len(migrations) < r.maxMigrations {
+		if node.ClusterLoadVariance(nodes) <= r.threshold {
+			break
+		}
+
+		migration, ok := r.migrateOne(nodes, budgets)
+		if !ok {
+			break
+		}
+		migrations = append(migrations, migration)
+	}
+
+	return migrations
+}
+
+func (r *Rebalancer) migrateOne(nodes []*node.Node, budgets disruptionBudgets) (Migration, bool) {
+	sorted := make([]*node.Node, len(nodes))
+	copy(sorted, nodes)
+
+	// Sort nodes by current utilization (descending)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Utilization() > sorted[j].Utilization()
+	})
+
+	// Try the hottest nodes first, moving onto the coldest that can take the container
+	for i := 0; i < len(sorted); i++ {
+		source := sorted[i]
+		for j := len(sorted) - 1; j > i; j-- {
+			target := sorted[j]
+			if target.Utilization() >= source.Utilization() {
+				break
+			}
+
+			containers := make([]*container.Container, len(source.Containers()))
+			copy(containers, source.Containers())
+			sort.SliceStable(containers, func(a, b int) bool {
+				return containers[a].DisruptionCost() < containers[b].DisruptionCost()
+			})
+
+			for _, c := range containers {
+				if !budgets.allows(c) || c.NodeName() != "" || !target.CanFit(c) || !narrowsGap(c, source, target) {
+					continue
+				}
+
+				if !source.RemoveContainerRef(c) {
+					continue
+				}
+				if !target.AddContainer(c) {
+					// Put it back where it was; the source still has room for it
+					source.AddContainer(c)
+					continue
+				}
+
+				return Migration{Container: c, From: source, To: target}, true
+			}
+		}
+	}
+
+	return Migration{}, false
+}
+
+// narrowsGap reports whether moving c from source to target leaves the
+// source still at least as utilized as the target, so the move never
+// just swaps which node is hot.
+func narrowsGap(c *container.Container, source, target *node.Node) bool {
+	sourceAfter := utilizationWith(source, c, -1)
+	targetAfter := utilizationWith(target, c, 1)
+	return targetAfter <= sourceAfter
+}
+
+func utilizationWith(n *node.Node, c *container.Container, sign float64) float64 {
+	cpuUtil := node.Ratio(n.TotalCPU()-n.AvailableCPU()+sign*c.CPURequest(), n.TotalCPU())
+	memUtil := node.Ratio(n.TotalMemory()-n.AvailableMemory()+sign*c.MemoryRequest(), n.TotalMemory())
+	netUtil := node.Ratio(n.TotalNetwork()-n.AvailableNetwork()+sign*c.NetworkRequest(), n.TotalNetwork())
+	ioUtil := node.Ratio(n.TotalIO()-n.AvailableIO()+sign*c.IORequest(), n.TotalIO())
+
+	return (cpuUtil + memUtil + netUtil + ioUtil) / 4.0
+}
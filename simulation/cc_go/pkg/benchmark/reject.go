@@ -0,0 +1,60 @@
+// pkg/benchmark/reject.go - Rescheduling containers a node rejected
+package benchmark
+
+import (
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+	"cc_go/pkg/scheduler"
+)
+
+// maxRejectRetries bounds how many more nodes are tried after the chosen
+// node rejects a container
+const maxRejectRetries = 3
+
+// place adds c to n. If n rejects it, because the node changed between the
+// scheduling decision and the placement, the scheduler is asked again
+// without the rejecting nodes, up to maxRejectRetries times. It returns the
+// node c ended up on, or the last rejecting node and false.
+func (b *Benchmark) place(c *container.Container, n *node.Node) (*node.Node, bool) {
+	var rejected map[*node.Node]bool
+	for !n.AddContainer(c) {
+		b.logger.Debugf("Node %s rejected container %s", n.Name(), c.ID())
+		if rejected == nil {
+			rejected = make(map[*node.Node]bool)
+		}
+		rejected[n] = true
+		
+		next, err := b.scheduleExcluding(c, rejected)
+		if len(rejected) > maxRejectRetries || err != nil || next == nil {
+			b.metricsCollector.RecordRejection(false)
+			return n, false
+		}
+		n = next
+	}
+	
+	if rejected != nil {
+		b.logger.Debugf("Rescheduled container %s on node %s after %d rejections", c.ID(), n.Name(), len(rejected))
+		b.metricsCollector.RecordRejection(true)
+	}
+	return n, true
+}
+
+// scheduleExcluding is schedule without the excluded nodes
+func (b *Benchmark) scheduleExcluding(c *container.Container, excluded map[*node.Node]bool) (*node.Node, error) {
+	nodes := b.nodes
+	if b.pool != nil {
+		nodes = b.pool.Candidates(c)
+	}
+	
+	remaining := make([]*node.Node, 0, len(nodes))
+	for _, n := range nodes {
+		if !excluded[n] {
+			remaining = append(remaining, n)
+		}
+	}
+	if len(remaining) == 0 {
+		return nil, scheduler.ErrNoSuitableNode
+	}
+	
+	return b.scheduler.Schedule(c, remaining)
+}
@@ -0,0 +1,106 @@
+// pkg/benchmark/replicas.go - Minimum and maximum replicas per container type
+package benchmark
+
+import (
+	"cc_go/pkg/container"
+	"cc_go/pkg/workLoad"
+)
+
+// replicated is implemented by workload generators whose definition limits
+// the number of instances of a container type
+type replicated interface {
+	ReplicaLimits() map[string]workLoad.ReplicaLimits
+	NextOfType(containerType string) *container.Container
+}
+
+// replicaLimits returns the workload's replica limits, or nil without any
+func (b *Benchmark) replicaLimits() map[string]workLoad.ReplicaLimits {
+	if gen, ok := b.workloadGen.(replicated); ok {
+		return gen.ReplicaLimits()
+	}
+	return nil
+}
+
+// liveReplicas counts the containers of each limited type that are running
+// or still waiting for a node; the caller holds b.mu
+func (b *Benchmark) liveReplicas(limits map[string]workLoad.ReplicaLimits) map[string]int {
+	counts := make(map[string]int, len(limits))
+	for containerType := range limits {
+		counts[containerType] = 0
+	}
+	count := func(c *container.Container) {
+		if _, limited := counts[c.Type()]; limited {
+			counts[c.Type()]++
+		}
+	}
+	for _, n := range b.nodes {
+		for _, c := range n.Containers() {
+			count(c)
+		}
+	}
+	for _, retry := range b.retryQueue {
+		count(retry.container)
+	}
+	for _, c := range b.wave {
+		count(c)
+	}
+	return counts
+}
+
+// atReplicaMax reports whether an arrival must be turned away because its
+// type already has its maximum number of replicas; the caller holds b.mu
+func (b *Benchmark) atReplicaMax(c *container.Container) bool {
+	limits := b.replicaLimits()
+	limit, ok := limits[c.Type()]
+	if !ok || limit.Max == 0 {
+		return false
+	}
+	if b.liveReplicas(limits)[c.Type()] < limit.Max {
+		return false
+	}
+	b.metricsCollector.RecordReplicaCapped(c.Type())
+	return true
+}
+
+// replenishReplicas generates replacements for every type that has fallen
+// below its minimum, e.g. because containers completed, and schedules them;
+// the caller holds b.mu
+func (b *Benchmark) replenishReplicas() {
+	gen, ok := b.workloadGen.(replicated)
+	if !ok {
+		return
+	}
+	limits := gen.ReplicaLimits()
+	if len(limits) == 0 {
+		return
+	}
+	
+	live := b.liveReplicas(limits)
+	for containerType, limit := range limits {
+		replaced := 0
+		for live[containerType]+replaced < limit.Min {
+			replacement := gen.NextOfType(containerType)
+			if replacement == nil {
+				break
+			}
+			b.enroll(replacement)
+			b.metricsCollector.RecordReplacement(containerType)
+			b.enqueue(replacement)
+			replaced++
+		}
+		if replaced > 0 {
+			b.logger.Debugf("Generated %d %s containers to keep %d replicas", replaced, containerType, limit.Min)
+		}
+	}
+	b.drainPending()
+}
+
+// sampleReplicas records the live instances of each limited type; the
+// caller holds b.mu
+func (b *Benchmark) sampleReplicas() {
+	limits := b.replicaLimits()
+	if len(limits) == 0 {
+		return
+	}
+	b.metricsCollector.RecordReplicaSample(b.liveReplicas(limits))
+}
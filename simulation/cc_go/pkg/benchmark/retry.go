@@ -0,0 +1,72 @@
+// pkg/benchmark/retry.go - Bounded retry queue for failed placements
+package benchmark
+
+import (
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+	"time"
+)
+
+type pendingRetry struct {
+	container   *container.Container
+	nextAttempt time.Time
+}
+
+// SetRetryPolicy lets containers that fail to schedule be retried up to
+// maxRetries more times, waiting backoff (doubling after each attempt)
+// between tries. Zero retries records failures immediately.
+func (b *Benchmark) SetRetryPolicy(maxRetries int, backoff time.Duration) {
+	b.maxRetries = maxRetries
+	b.retryBackoff = backoff
+}
+
+// recordFailure either queues the container for another attempt or, once
+// its retries are exhausted or it was submitted through the API, records
+// the failure as final
+func (b *Benchmark) recordFailure(c *container.Container, n *node.Node, latency time.Duration) {
+	b.noteFailure(b.clock.Now())
+	b.notePinningFailure(c)
+	if !b.submitting && c.Attempts() <= b.maxRetries {
+		backoff := b.retryBackoff * time.Duration(1<<uint(c.Attempts()-1))
+		b.retryQueue = append(b.retryQueue, pendingRetry{
+			container:   c,
+			nextAttempt: b.clock.Now().Add(backoff),
+		})
+		b.logger.Debugf("Queued container %s for retry in %v (attempt %d of %d)",
+			c.ID(), backoff, c.Attempts(), b.maxRetries+1)
+		return
+	}
+
+	b.metricsCollector.RecordSchedulingEvent(c, n, latency, false)
+	b.orderedFailed(c)
+}
+
+// retryPending moves every queued container whose backoff has elapsed onto
+// the pending queue, where it competes with new arrivals by priority
+func (b *Benchmark) retryPending(now time.Time) {
+	if len(b.retryQueue) == 0 {
+		return
+	}
+
+	waiting := make([]pendingRetry, 0, len(b.retryQueue))
+	for _, pending := range b.retryQueue {
+		if now.Before(pending.nextAttempt) {
+			waiting = append(waiting, pending)
+		} else {
+			b.enqueue(pending.container)
+		}
+	}
+	b.retryQueue = waiting
+}
+
+// abandonRetries records every container still waiting for a retry as a
+// permanent failure
+func (b *Benchmark) abandonRetries() {
+	for _, pending := range b.retryQueue {
+		b.logger.Warnf("Container %s still pending at shutdown after %d attempts",
+			pending.container.ID(), pending.container.Attempts())
+		b.metricsCollector.RecordSchedulingEvent(pending.container, nil, 0, false)
+		b.orderedFailed(pending.container)
+	}
+	b.retryQueue = b.retryQueue[:0]
+}
@@ -0,0 +1,93 @@
+// pkg/benchmark/scenario.go - Programmatic benchmark runs
+package benchmark
+
+import (
+	"cc_go/pkg/metrics"
+	"cc_go/pkg/scheduler"
+	"cc_go/pkg/workLoad"
+	"fmt"
+	"time"
+)
+
+// ScenarioConfig describes one self-contained benchmark run
+type ScenarioConfig struct {
+	Scheduler   scheduler.Scheduler
+	Cluster     ClusterDefinition           // no node templates means the default cluster
+	Workload    workLoad.WorkloadDefinition // ignored when Generator is set
+	Generator   workLoad.WorkloadGenerator  // optional, e.g. a file generator that can Reload or a JSON Lines stream
+	Duration    time.Duration
+	GenerateFor time.Duration     // stop arrivals after this long; 0 for the whole run
+	Seed        int64             // seeds the workload and default cleanup; 0 seeds from the clock
+	Collector   metrics.Collector // optional; defaults to an in-memory MetricsCollector
+	Cleanup     CleanupPolicy     // optional; defaults to 10% random churn per second
+	Setup       func(b *Benchmark) error // optional; applies further settings before the run starts, or fails it
+}
+
+// RunScenario builds a fresh cluster and workload from cfg, runs the
+// benchmark to completion and returns its results. It reads no files or
+// flags and keeps all state in the run, so sweeps can call it repeatedly
+// in one process, also concurrently. Every run numbers its containers and
+// nodes from one and keeps its own clock, so runs with the same seed assign
+// the same IDs.
+func RunScenario(cfg ScenarioConfig) (*metrics.Results, error) {
+	if cfg.Scheduler == nil {
+		return nil, fmt.Errorf("scenario has no scheduler")
+	}
+	if cfg.Duration <= 0 {
+		return nil, fmt.Errorf("scenario duration must be positive, got %v", cfg.Duration)
+	}
+	
+	definition := cfg.Cluster
+	if len(definition.Nodes) == 0 {
+		definition = DefaultClusterDefinition()
+	}
+	nodes, err := NewCluster(definition)
+	if err != nil {
+		return nil, err
+	}
+	
+	generator := cfg.Generator
+	if generator == nil {
+		generator, err = workLoad.NewWorkload(cfg.Workload)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// Percentage requests are relative to the smallest node of this cluster
+	if referenced, ok := generator.(interface{ SetReferenceNode(workLoad.NodeSize) }); ok {
+		referenced.SetReferenceNode(SmallestNode(nodes))
+	}
+	// Streamed workloads replay a fixed sequence and take no seed
+	if seeded, ok := generator.(interface{ SetSeed(int64) }); ok && cfg.Seed != 0 {
+		seeded.SetSeed(cfg.Seed)
+	}
+	
+	cleanup := cfg.Cleanup
+	if cleanup == nil {
+		churn := NewRandomChurn(0.1)
+		if cfg.Seed != 0 {
+			churn.SetSeed(cfg.Seed)
+		}
+		cleanup = churn
+	}
+	
+	collector := cfg.Collector
+	if collector == nil {
+		collector = metrics.NewCollector()
+	}
+	
+	b := NewBenchmark(cfg.Scheduler, generator, collector, cleanup)
+	b.SetNodes(nodes)
+	if cfg.Setup != nil {
+		if err := cfg.Setup(b); err != nil {
+			return nil, err
+		}
+	}
+	
+	if limited, ok := generator.(interface{ SetMaxDuration(time.Duration) }); ok && cfg.GenerateFor > 0 {
+		limited.SetMaxDuration(cfg.GenerateFor)
+	}
+	b.Run(cfg.Duration)
+	
+	return collector.GetResults(), nil
+}
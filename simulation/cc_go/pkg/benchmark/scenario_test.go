@@ -0,0 +1,83 @@
+// pkg/benchmark/scenario_test.go - Programmatic benchmark runs
+package benchmark
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"cc_go/pkg/metrics"
+	"cc_go/pkg/scheduler"
+)
+
+// placements lists every scheduling event of a run as container, node and
+// outcome, in order
+func placements(results *metrics.Results) []string {
+	listed := make([]string, len(results.Events))
+	for i, event := range results.Events {
+		listed[i] = fmt.Sprintf("%s %s %v", event.ContainerID, event.NodeID, event.ScheduleSuccess)
+	}
+	return listed
+}
+
+func TestConcurrentScenariosMatchSerialRun(t *testing.T) {
+	scenario := func() ScenarioConfig {
+		return ScenarioConfig{
+			Scheduler: scheduler.NewAdaptiveScheduler(),
+			Workload:  mixedPriorityWorkload(),
+			Duration:  300 * time.Second,
+			Seed:      7,
+			Setup: func(b *Benchmark) error {
+				b.SetAccelerated(true)
+				return nil
+			},
+		}
+	}
+	
+	serial, err := RunScenario(scenario())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := placements(serial)
+	if len(want) == 0 {
+		t.Fatal("serial run recorded no events")
+	}
+	
+	const runs = 8
+	got := make([][]string, runs)
+	errs := make([]error, runs)
+	var wg sync.WaitGroup
+	for i := 0; i < runs; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results, err := RunScenario(scenario())
+			if err == nil {
+				got[i] = placements(results)
+			}
+			errs[i] = err
+		}(i)
+	}
+	wg.Wait()
+	
+	for i := range got {
+		if errs[i] != nil {
+			t.Fatalf("run %d: %v", i, errs[i])
+		}
+		if len(got[i]) != len(want) {
+			t.Errorf("run %d recorded %d events, the serial run %d", i, len(got[i]), len(want))
+			continue
+		}
+		for j := range want {
+			if got[i][j] != want[j] {
+				t.Errorf("run %d event %d is %q, the serial run's %q", i, j, got[i][j], want[j])
+				break
+			}
+		}
+	}
+	if first := want[0]; !strings.HasPrefix(first, "container-1 ") {
+		t.Errorf("first event %q, want container-1: IDs restart in every run", first)
+	}
+}
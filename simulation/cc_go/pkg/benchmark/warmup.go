@@ -0,0 +1,50 @@
+// pkg/benchmark/warmup.go - Warmup period excluded from metrics
+package benchmark
+
+import (
+	"cc_go/pkg/metrics"
+	"time"
+)
+
+// SetWarmup excludes the first d of the run from the results. Scheduling
+// runs normally, so containers placed meanwhile keep occupying their nodes,
+// but their events go to a throwaway collector; the run's collector only
+// learns how many placements and failures were left out.
+func (b *Benchmark) SetWarmup(d time.Duration) {
+	b.warmup = d
+}
+
+// startWarmup sets the run's collector aside until the warmup has elapsed
+func (b *Benchmark) startWarmup() {
+	if b.warmup <= 0 {
+		return
+	}
+	b.warmupEnd = b.clock.Now().Add(b.warmup)
+	b.measured = b.metricsCollector
+	throwaway := metrics.NewCollector()
+	throwaway.SetClock(b.clock)
+	b.metricsCollector = throwaway
+	b.logger.Infof("Warming up for %v before recording metrics", b.warmup)
+}
+
+// endWarmup restores the run's collector once the warmup has elapsed; the
+// caller holds b.mu
+func (b *Benchmark) endWarmup(now time.Time) {
+	if b.measured == nil || now.Before(b.warmupEnd) {
+		return
+	}
+	excluded := b.metricsCollector.GetResults()
+	b.metricsCollector = b.measured
+	b.measured = nil
+	b.metricsCollector.RecordWarmup(b.warmup, excluded.ContainersScheduled, excluded.SchedulingFailures)
+	b.logger.Infof("Warmup complete: excluded %d placements and %d failures",
+		excluded.ContainersScheduled, excluded.SchedulingFailures)
+}
+
+// collector is the run's collector, even while warmup metrics are discarded
+func (b *Benchmark) collector() metrics.Collector {
+	if b.measured != nil {
+		return b.measured
+	}
+	return b.metricsCollector
+}
@@ -0,0 +1,88 @@
+// pkg/clock/clock.go - Simulation clock
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock tells simulation time. Real follows the wall clock; Simulated only
+// moves when it is advanced, so a run can cover an hour in milliseconds.
+// Scheduling latency is compute time and is always measured with the wall
+// clock, never with a Clock. There is no process-wide clock: every run
+// hands its own to the nodes, collector, workload and scheduler it uses.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// Real is the wall clock
+type Real struct{}
+
+func (Real) Now() time.Time {
+	return time.Now()
+}
+
+func (Real) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// Simulated is a virtual clock that stands still until AdvanceTo moves it
+type Simulated struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []waiter
+}
+
+type waiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func NewSimulated(start time.Time) *Simulated {
+	return &Simulated{now: start}
+}
+
+func (s *Simulated) Now() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	return s.now
+}
+
+// After returns a channel that receives the time once the clock has been
+// advanced by at least d
+func (s *Simulated) After(d time.Duration) <-chan time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- s.now
+		return ch
+	}
+	s.waiters = append(s.waiters, waiter{at: s.now.Add(d), ch: ch})
+	return ch
+}
+
+// AdvanceTo moves the clock forward to t, firing every After that falls due
+// on the way; it never moves the clock back
+func (s *Simulated) AdvanceTo(t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	if t.After(s.now) {
+		s.now = t
+	}
+	
+	sort.Slice(s.waiters, func(i, j int) bool {
+		return s.waiters[i].at.Before(s.waiters[j].at)
+	})
+	due := 0
+	for due < len(s.waiters) && !s.waiters[due].at.After(s.now) {
+		s.waiters[due].ch <- s.waiters[due].at
+		due++
+	}
+	s.waiters = s.waiters[due:]
+}
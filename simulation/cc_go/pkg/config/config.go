@@ -0,0 +1,337 @@
+// pkg/config/config.go - Experiment configuration loading
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"cc_go/pkg/node"
+	"cc_go/pkg/scheduler"
+	"cc_go/pkg/workLoad"
+)
+
+// Config describes a single benchmark run. Every field can also be set by
+// the matching command line flag, which takes precedence over the file.
+type Config struct {
+	Scheduler         string   `json:"scheduler"`
+	Workload          string   `json:"workload"`
+	WorkloadSources   []WorkloadSource `json:"workload_sources"` // mixed instead of Workload when set
+	Cluster           string   `json:"cluster"`
+	Output            string   `json:"output"`
+	OutputDir         string   `json:"output_dir"` // write every result file into this directory instead of next to Output
+	Duration          int      `json:"duration"` // seconds
+	GenerateFor       Duration `json:"generate_for"` // stop arrivals after this long; 0 for the whole run
+	Warmup            Duration `json:"warmup"` // start of the run scheduled normally but left out of the results
+	Seed              int64    `json:"seed"`     // 0 seeds from the clock
+	Verbose           bool     `json:"verbose"` // log at debug level
+	LogFile           string   `json:"log_file"` // "-" for stdout
+	AdaptiveState     string   `json:"adaptive_state"`
+	Serve             string   `json:"serve"`
+	Stream            bool     `json:"stream"`
+	MaxEvents         int      `json:"max_events"` // keep only the last N scheduling events; 0 keeps all
+	BatchSize         int      `json:"batch_size"`
+	IntensityFraction float64  `json:"intensity_fraction"`
+	MaxRetries        int      `json:"max_retries"`
+	RetryBackoff      Duration `json:"retry_backoff"`
+	Dominant          bool     `json:"dominant"`
+	ResourceWeights   ResourceWeights `json:"resource_weights"` // CPU, memory, network and IO weights of binpack and spread utilization
+	RebalanceInterval Duration `json:"rebalance_interval"`
+	AnnealIterations  int      `json:"anneal_iterations"`
+	AnnealCooling     float64  `json:"anneal_cooling"`
+	SaturationKnee    float64  `json:"saturation_knee"`     // utilization where the saturation penalty starts
+	SaturationExponent float64 `json:"saturation_exponent"` // steepness of the saturation penalty
+	PackThreshold     float64  `json:"pack_threshold"` // utilization up to which the hybrid scheduler packs before spreading
+	SchedulerConfig   map[string]scheduler.Options `json:"scheduler_config"` // options by scheduler name, see the scheduler package
+	UsageNoise        float64  `json:"usage_noise"` // amplitude of usage fluctuation around requests; 0 disables it
+	LoadSmoothing     float64  `json:"load_smoothing"` // EMA factor of node utilization seen by the health model
+	ImageLocality     bool     `json:"image_locality"` // prefer nodes that already cache the image
+	Cleanup           string   `json:"cleanup"`    // "random", "lifetime" or "never"
+	ChurnRate         float64  `json:"churn_rate"` // fraction removed per node per second under random cleanup
+	Preemption        bool     `json:"preemption"` // let containers evict lower-priority ones when nothing fits
+	FairQueue         bool     `json:"fair_queue"` // dequeue pending containers in weighted fair order by type
+	Accelerate        bool     `json:"accelerate"` // run on a simulated clock instead of in real time
+	Index             bool     `json:"index"` // offer schedulers only capacity-indexed candidates
+	Autoscale         bool     `json:"autoscale"` // add nodes when placements keep failing, remove empty ones when idle
+	AutoscaleTemplate string   `json:"autoscale_template"` // cluster node template to add; empty for the first
+	AutoscaleFailures int      `json:"autoscale_failures"` // failed placements within the cooldown that trigger a scale up
+	AutoscaleUtilization float64 `json:"autoscale_utilization"` // cluster utilization below which empty nodes are removed
+	AutoscaleCooldown Duration `json:"autoscale_cooldown"`
+	AutoscaleMaxNodes int      `json:"autoscale_max_nodes"` // 0 for no limit
+	AutoscaleMinNodes int      `json:"autoscale_min_nodes"`
+	TargetUtilization float64  `json:"target_utilization"` // steer the arrival rate to hold average node utilization here; 0 for a fixed rate
+	Compare           bool     `json:"compare"` // run every scheduler instead of just Scheduler
+	Estimate          bool     `json:"estimate"` // print a capacity estimate instead of running
+	Record            string   `json:"record"` // golden file to write the run's placements to
+	Verify            string   `json:"verify"` // golden file the run's placements must match
+}
+
+func Default() *Config {
+	return &Config{
+		Scheduler:    "adaptive",
+		Workload:     "workloads/mixed_workload.json",
+		Output:       "results.csv",
+		LogFile:      "scheduler.log",
+		Duration:     300,
+		BatchSize:    1,
+		RetryBackoff: Duration(1 * time.Second),
+		AnnealIterations: 2000,
+		AnnealCooling:    0.995,
+		SaturationKnee:   0.85,
+		SaturationExponent: 2,
+		PackThreshold:    scheduler.DefaultPackThreshold,
+		Cleanup:          "random",
+		ChurnRate:        0.1,
+		LoadSmoothing:    node.DefaultLoadSmoothing,
+		AutoscaleFailures:    5,
+		AutoscaleUtilization: 0.3,
+		AutoscaleCooldown:    Duration(5 * time.Second),
+		AutoscaleMaxNodes:    20,
+		AutoscaleMinNodes:    1,
+		ResourceWeights:      ResourceWeights{0.25, 0.25, 0.25, 0.25},
+	}
+}
+
+// Load reads a JSON config file. Fields missing from the file keep their
+// Default values.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := Default()
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %v", path, err)
+	}
+
+	return cfg, nil
+}
+
+// Validate checks the config before a run starts so that mistakes are
+// reported up front rather than minutes into a benchmark
+func (c *Config) Validate() error {
+	known := false
+	for _, name := range scheduler.List() {
+		if c.Scheduler == name {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return fmt.Errorf("unknown scheduler %q (available: %s)", c.Scheduler, strings.Join(scheduler.List(), ", "))
+	}
+
+	for name, opts := range c.SchedulerConfig {
+		if _, err := scheduler.New(name, opts); err != nil {
+			return fmt.Errorf("scheduler_config: %v", err)
+		}
+	}
+
+	if len(c.WorkloadSources) > 0 {
+		if c.Estimate {
+			return fmt.Errorf("capacity estimates need a single template workload, not workload sources")
+		}
+		for _, source := range c.WorkloadSources {
+			if source.File == "" || workLoad.IsJSONLines(source.File) {
+				return fmt.Errorf("workload source %q must be a template workload file", source.File)
+			}
+			if _, err := os.Stat(source.File); err != nil {
+				return fmt.Errorf("workload source: %v", err)
+			}
+		}
+	} else if c.Workload != "-" {
+		if _, err := os.Stat(c.Workload); err != nil {
+			return fmt.Errorf("workload file: %v", err)
+		}
+	}
+	if workLoad.IsJSONLines(c.Workload) {
+		if c.Estimate {
+			return fmt.Errorf("capacity estimates need a template workload, not JSON Lines")
+		}
+		if c.Compare && c.Workload == "-" {
+			return fmt.Errorf("compare replays the workload once per scheduler and cannot read it from stdin")
+		}
+	}
+	if c.TargetUtilization < 0 || c.TargetUtilization >= 1 {
+		return fmt.Errorf("target utilization must be in [0, 1), got %g", c.TargetUtilization)
+	}
+	if c.OutputDir != "" && c.Stream {
+		return fmt.Errorf("output dir cannot be used with stream, which writes events to the output file")
+	}
+	if c.Record != "" || c.Verify != "" {
+		if c.Record != "" && c.Verify != "" {
+			return fmt.Errorf("record and verify cannot be used together")
+		}
+		if c.Compare || c.Estimate || c.Stream || c.MaxEvents > 0 {
+			return fmt.Errorf("record and verify need a single run keeping every event, not compare, estimate, stream or max events")
+		}
+	}
+	if c.Verify != "" {
+		if _, err := os.Stat(c.Verify); err != nil {
+			return fmt.Errorf("golden file: %v", err)
+		}
+	}
+	if c.LogFile == "" {
+		return fmt.Errorf("log file must be a path or - for stdout")
+	}
+	if c.Cluster != "" {
+		if _, err := os.Stat(c.Cluster); err != nil {
+			return fmt.Errorf("cluster file: %v", err)
+		}
+	}
+
+	if c.Duration <= 0 {
+		return fmt.Errorf("duration must be positive, got %d", c.Duration)
+	}
+	if c.BatchSize < 1 {
+		return fmt.Errorf("batch size must be at least 1, got %d", c.BatchSize)
+	}
+	if c.MaxEvents < 0 {
+		return fmt.Errorf("max events must not be negative, got %d", c.MaxEvents)
+	}
+	if c.MaxRetries < 0 {
+		return fmt.Errorf("max retries must not be negative, got %d", c.MaxRetries)
+	}
+	if c.AnnealIterations < 0 {
+		return fmt.Errorf("anneal iterations must not be negative, got %d", c.AnnealIterations)
+	}
+	if c.AnnealCooling <= 0 || c.AnnealCooling > 1 {
+		return fmt.Errorf("anneal cooling must be in (0, 1], got %g", c.AnnealCooling)
+	}
+	if c.SaturationKnee <= 0 || c.SaturationKnee >= 1 {
+		return fmt.Errorf("saturation knee must be in (0, 1), got %g", c.SaturationKnee)
+	}
+	if c.SaturationExponent <= 0 {
+		return fmt.Errorf("saturation exponent must be positive, got %g", c.SaturationExponent)
+	}
+	if c.PackThreshold <= 0 || c.PackThreshold > 1 {
+		return fmt.Errorf("pack threshold must be in (0, 1], got %g", c.PackThreshold)
+	}
+	if c.Cleanup != "random" && c.Cleanup != "lifetime" && c.Cleanup != "never" {
+		return fmt.Errorf("unknown cleanup policy %q (expected random, lifetime or never)", c.Cleanup)
+	}
+	if c.ChurnRate < 0 || c.ChurnRate > 1 {
+		return fmt.Errorf("churn rate must be in [0, 1], got %g", c.ChurnRate)
+	}
+	if c.UsageNoise < 0 || c.UsageNoise > 1 {
+		return fmt.Errorf("usage noise must be in [0, 1], got %g", c.UsageNoise)
+	}
+	if c.LoadSmoothing <= 0 || c.LoadSmoothing > 1 {
+		return fmt.Errorf("load smoothing must be in (0, 1], got %g", c.LoadSmoothing)
+	}
+	if c.IntensityFraction < 0 {
+		return fmt.Errorf("intensity fraction must not be negative, got %g", c.IntensityFraction)
+	}
+	if c.AutoscaleFailures < 1 {
+		return fmt.Errorf("autoscale failures must be at least 1, got %d", c.AutoscaleFailures)
+	}
+	if c.AutoscaleUtilization < 0 || c.AutoscaleUtilization > 1 {
+		return fmt.Errorf("autoscale utilization must be in [0, 1], got %g", c.AutoscaleUtilization)
+	}
+	if c.AutoscaleMaxNodes < 0 || c.AutoscaleMinNodes < 0 {
+		return fmt.Errorf("autoscale node limits must not be negative")
+	}
+	if c.AutoscaleMaxNodes > 0 && c.AutoscaleMinNodes > c.AutoscaleMaxNodes {
+		return fmt.Errorf("autoscale min nodes %d exceeds max nodes %d", c.AutoscaleMinNodes, c.AutoscaleMaxNodes)
+	}
+	for _, w := range c.ResourceWeights {
+		if w < 0 {
+			return fmt.Errorf("resource weights must not be negative, got %v", c.ResourceWeights)
+		}
+	}
+	if c.ResourceWeights == (ResourceWeights{}) {
+		return fmt.Errorf("resource weights must not all be zero")
+	}
+	if c.Warmup < 0 || time.Duration(c.Warmup) >= time.Duration(c.Duration)*time.Second {
+		return fmt.Errorf("warmup must be shorter than the run duration, got %v", c.Warmup)
+	}
+	if c.RetryBackoff < 0 || c.RebalanceInterval < 0 || c.GenerateFor < 0 || c.AutoscaleCooldown < 0 {
+		return fmt.Errorf("intervals must not be negative")
+	}
+
+	return nil
+}
+
+// Duration is a time.Duration written as a string ("1s", "500ms") in config
+// files. It also implements flag.Value so flags can share the field.
+type Duration time.Duration
+
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+func (d *Duration) Set(value string) error {
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return d.Set(value)
+}
+
+// WorkloadSource is one template workload file of a mix. Its weight sets
+// its share of arrivals while it is active; with a period it is active only
+// for the first active part of every period, e.g. {"period": "60s",
+// "active": "10s"} for a burst every minute. Start delays it.
+type WorkloadSource struct {
+	File   string   `json:"file"`
+	Name   string   `json:"name"` // defaults to the file name without its extension
+	Weight int      `json:"weight"`
+	Start  Duration `json:"start"`
+	Period Duration `json:"period"`
+	Active Duration `json:"active"`
+}
+
+// SourceName is the name containers from the source are tagged with
+func (s WorkloadSource) SourceName() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return strings.TrimSuffix(filepath.Base(s.File), filepath.Ext(s.File))
+}
+
+// ResourceWeights are CPU, memory, network and IO weights, written as a JSON
+// array in config files and as "cpu,memory,network,io" on the command line
+type ResourceWeights [4]float64
+
+func (w ResourceWeights) String() string {
+	parts := make([]string, len(w))
+	for i, weight := range w {
+		parts[i] = strconv.FormatFloat(weight, 'g', -1, 64)
+	}
+	return strings.Join(parts, ",")
+}
+
+func (w *ResourceWeights) Set(value string) error {
+	parts := strings.Split(value, ",")
+	if len(parts) != len(w) {
+		return fmt.Errorf("expected %d comma-separated weights, got %q", len(w), value)
+	}
+	var parsed ResourceWeights
+	for i, part := range parts {
+		weight, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return err
+		}
+		parsed[i] = weight
+	}
+	*w = parsed
+	return nil
+}
@@ -2,9 +2,39 @@ package container
 
 import (
 	"fmt"
+	"math"
+	"sync/atomic"
 	"time"
 )
 
+// lastID is the number in the most recently assigned container ID. It only
+// keeps containers created outside a run apart: a run numbers the
+// containers entering it itself, see SetID.
+var lastID uint64
+
+// Intensity thresholds above which a request counts as intensive for that
+// resource. They drive the adaptive scheduler's interference penalties and
+// default to 2 cores, 2048 MB, 500 Mbps and 5000 IOPS.
+var (
+	cpuIntensityThreshold     = 2.0
+	memoryIntensityThreshold  = 2048.0
+	networkIntensityThreshold = 500.0
+	ioIntensityThreshold      = 5000.0
+)
+
+// SetIntensityThresholds overrides the intensity thresholds. It should be
+// called at startup, before any scheduling happens.
+func SetIntensityThresholds(cpu, memory, network, io float64) {
+	cpuIntensityThreshold = cpu
+	memoryIntensityThreshold = memory
+	networkIntensityThreshold = network
+	ioIntensityThreshold = io
+}
+
+func IntensityThresholds() (cpu, memory, network, io float64) {
+	return cpuIntensityThreshold, memoryIntensityThreshold, networkIntensityThreshold, ioIntensityThreshold
+}
+
 type Container struct {
 	id              string
 	name            string
@@ -13,15 +43,38 @@ type Container struct {
 	memoryRequest   float64 // Memory in MB requested
 	networkRequest  float64 // Network bandwidth in Mbps
 	ioRequest       float64 // IO operations per second
+	diskRequest     float64 // Disk space in GB
+	gpuMemoryRequest float64 // GPU memory in GB, taken from a single GPU
+	cpuLimit        float64 // most CPU the container may use; 0 for no limit
+	memoryLimit     float64 // most memory the container may use; 0 for no limit
+	memoryCeiling   float64 // memory use left after reclaim on its node; 0 if none was reclaimed
 	containerType   string  // Type of workload (e.g., "web", "database", "batch")
 	creationTime    time.Time
 	startupDuration time.Duration
+	pullDuration    time.Duration // image pull on the current node
+	imageSize       float64       // MB
+	lifetime        time.Duration // how long it runs once placed; 0 for indefinitely
 	priority        int
+	attempts        int // scheduling attempts made so far
+	placedAt        time.Time // when the container last landed on a node
+	queuedAt        time.Time // when it last started waiting for a node; zero for its creation
+	usageFactor     float64   // actual usage as a multiple of the request
+	profile         UsageProfile // how usage evolves after placement; nil for constant
+	spreadKey       string    // containers sharing a key are spread across zones
+	source          string    // workload source that generated it; empty for a single workload
+	group           string    // ordered group the container belongs to; empty if none
+	groupIndex      int       // position within the ordered group
+	disruptionCost  float64   // cost of evicting the container once
+	minAvailable    int       // running containers of the same name an eviction must leave
+	numaPinned      bool      // must fit within one NUMA socket of its node
+	nodeName        string    // name or ID of the only node it may run on; empty for any
+	failureReason   string    // why the last scheduling attempt failed
+	sidecars        []*Container // co-located containers whose requests add to this one's
 }
 
 func NewContainer(name, image string, cpuReq, memReq, netReq, ioReq float64, containerType string, priority int) *Container {
 	return &Container{
-		id:              fmt.Sprintf("container-%d", time.Now().UnixNano()),
+		id:              fmt.Sprintf("container-%d", atomic.AddUint64(&lastID, 1)),
 		name:            name,
 		image:           image,
 		cpuRequest:      cpuReq,
@@ -32,6 +85,8 @@ func NewContainer(name, image string, cpuReq, memReq, netReq, ioReq float64, con
 		creationTime:    time.Now(),
 		startupDuration: 0,
 		priority:        priority,
+		usageFactor:     1.0,
+		disruptionCost:  1.0,
 	}
 }
 
@@ -39,6 +94,13 @@ func (c *Container) ID() string {
 	return c.id
 }
 
+// SetID replaces the container's ID; a run gives every container entering
+// it the next number of its own sequence, so seeded runs assign the same
+// IDs. It must be called before the container is placed anywhere.
+func (c *Container) SetID(id string) {
+	c.id = id
+}
+
 func (c *Container) Name() string {
 	return c.name
 }
@@ -47,20 +109,42 @@ func (c *Container) Image() string {
 	return c.image
 }
 
+// Requests include those of any sidecars
 func (c *Container) CPURequest() float64 {
-	return c.cpuRequest
+	return c.podRequest(c.cpuRequest, (*Container).CPURequest)
 }
 
 func (c *Container) MemoryRequest() float64 {
-	return c.memoryRequest
+	return c.podRequest(c.memoryRequest, (*Container).MemoryRequest)
 }
 
 func (c *Container) NetworkRequest() float64 {
-	return c.networkRequest
+	return c.podRequest(c.networkRequest, (*Container).NetworkRequest)
 }
 
 func (c *Container) IORequest() float64 {
-	return c.ioRequest
+	return c.podRequest(c.ioRequest, (*Container).IORequest)
+}
+
+func (c *Container) DiskRequest() float64 {
+	return c.podRequest(c.diskRequest, (*Container).DiskRequest)
+}
+
+// SetDiskRequest sets the disk space, in GB, the container needs on its node
+func (c *Container) SetDiskRequest(gb float64) {
+	c.diskRequest = gb
+}
+
+// GPUMemoryRequest is the container's own GPU memory in GB; sidecars do
+// not share its GPU
+func (c *Container) GPUMemoryRequest() float64 {
+	return c.gpuMemoryRequest
+}
+
+// SetGPUMemoryRequest sets the GPU memory, in GB, the container needs on a
+// single GPU of its node; 0 means it needs no GPU
+func (c *Container) SetGPUMemoryRequest(gb float64) {
+	c.gpuMemoryRequest = gb
 }
 
 func (c *Container) Type() string {
@@ -71,30 +155,238 @@ func (c *Container) Priority() int {
 	return c.priority
 }
 
+// SetSpreadKey groups the container with others, e.g. replicas of one
+// service, that should be spread across failure domains
+func (c *Container) SetSpreadKey(key string) {
+	c.spreadKey = key
+}
+
+func (c *Container) SpreadKey() string {
+	return c.spreadKey
+}
+
+// SetSource records which workload of a mix generated the container
+func (c *Container) SetSource(source string) {
+	c.source = source
+}
+
+func (c *Container) Source() string {
+	return c.source
+}
+
+// SetDisruption sets what evicting the container costs and its disruption
+// budget: no eviction may leave fewer than minAvailable containers with the
+// same name running (0 for no budget)
+func (c *Container) SetDisruption(cost float64, minAvailable int) {
+	c.disruptionCost = cost
+	c.minAvailable = minAvailable
+}
+
+func (c *Container) DisruptionCost() float64 {
+	return c.disruptionCost
+}
+
+func (c *Container) MinAvailable() int {
+	return c.minAvailable
+}
+
+// SetFailureReason records why the latest scheduling attempt failed
+func (c *Container) SetFailureReason(reason string) {
+	c.failureReason = reason
+}
+
+func (c *Container) FailureReason() string {
+	return c.failureReason
+}
+
+// SetNUMAPinned asks for the container's CPU and memory to come from a
+// single NUMA socket of its node
+func (c *Container) SetNUMAPinned(pinned bool) {
+	c.numaPinned = pinned
+}
+
+func (c *Container) NUMAPinned() bool {
+	return c.numaPinned
+}
+
+// SetNodeName pins the container to the node with that name or ID: it is
+// placed there or nowhere, whatever the scheduler would prefer
+func (c *Container) SetNodeName(name string) {
+	c.nodeName = name
+}
+
+func (c *Container) NodeName() string {
+	return c.nodeName
+}
+
+// SetOrdering makes the container member index of an ordered group: it is
+// only scheduled once the member before it has been placed
+func (c *Container) SetOrdering(group string, index int) {
+	c.group = group
+	c.groupIndex = index
+}
+
+func (c *Container) Group() string {
+	return c.group
+}
+
+func (c *Container) GroupIndex() int {
+	return c.groupIndex
+}
+
+// SetUsageFactor sets the container's current usage as a multiple of its
+// requests; negative factors are clamped to zero
+func (c *Container) SetUsageFactor(factor float64) {
+	if factor < 0 {
+		factor = 0
+	}
+	c.usageFactor = factor
+}
+
+func (c *Container) UsageFactor() float64 {
+	return c.usageFactor
+}
+
+func (c *Container) SetUsageProfile(profile UsageProfile) {
+	c.profile = profile
+}
+
+func (c *Container) UsageProfile() UsageProfile {
+	return c.profile
+}
+
+// EffectiveUsage is what the container actually consumes at now: its
+// requests shaped by its usage profile at its age on the node, scaled by
+// the usage factor. CPU and memory never exceed the container's limits.
+func (c *Container) EffectiveUsage(now time.Time) (cpu, memory, network, io float64) {
+	cpuLevel, memoryLevel, networkLevel, ioLevel := 1.0, 1.0, 1.0, 1.0
+	if c.profile != nil && !c.placedAt.IsZero() {
+		cpuLevel, memoryLevel, networkLevel, ioLevel = c.profile.UsageAt(now.Sub(c.placedAt))
+	}
+	
+	cpu = c.CPURequest() * cpuLevel * c.usageFactor
+	memory = c.MemoryRequest() * memoryLevel * c.usageFactor
+	// Sidecars are not limited, so they add their requests to the cap
+	sidecarCPU, sidecarMemory := c.SidecarOverhead()
+	if c.cpuLimit > 0 {
+		cpu = math.Min(cpu, c.cpuLimit+sidecarCPU)
+	}
+	if c.memoryLimit > 0 {
+		memory = math.Min(memory, c.memoryLimit+sidecarMemory)
+	}
+	if c.memoryCeiling > 0 {
+		memory = math.Min(memory, c.memoryCeiling)
+	}
+	return cpu, memory,
+		c.NetworkRequest() * networkLevel * c.usageFactor,
+		c.IORequest() * ioLevel * c.usageFactor
+}
+
+// RecordAttempt notes that the container is about to be offered to a scheduler
+func (c *Container) RecordAttempt() {
+	c.attempts++
+}
+
+func (c *Container) Attempts() int {
+	return c.attempts
+}
+
 func (c *Container) SetStartupDuration(d time.Duration) {
 	c.startupDuration = d
 }
 
+// StartupDuration is the time from placement until ready: the cold start
+// plus pulling the image, if the node did not have it
 func (c *Container) StartupDuration() time.Duration {
-	return c.startupDuration
+	return c.startupDuration + c.pullDuration
+}
+
+// SetPullDuration sets how long pulling the image took on the node the
+// container was last placed on
+func (c *Container) SetPullDuration(d time.Duration) {
+	c.pullDuration = d
+}
+
+// SetLifetime sets how long the container runs after placement before it
+// completes, under lifetime-based cleanup
+func (c *Container) SetLifetime(d time.Duration) {
+	c.lifetime = d
+}
+
+func (c *Container) Lifetime() time.Duration {
+	return c.lifetime
+}
+
+// SetQueuedAt restarts the container's wait for a node at t, e.g. when it
+// is evicted and has to be placed again
+func (c *Container) SetQueuedAt(t time.Time) {
+	c.queuedAt = t
+}
+
+// QueuedAt is when the container started waiting for its current or most
+// recent placement: its creation unless it was evicted since
+func (c *Container) QueuedAt() time.Time {
+	if c.queuedAt.IsZero() {
+		return c.creationTime
+	}
+	return c.queuedAt
+}
+
+// PlacedAt is when the container last landed on a node; zero if never
+func (c *Container) PlacedAt() time.Time {
+	return c.placedAt
+}
+
+// SetImageSize sets the size of the container's image in MB
+func (c *Container) SetImageSize(mb float64) {
+	c.imageSize = mb
+}
+
+func (c *Container) ImageSize() float64 {
+	return c.imageSize
+}
+
+// MarkPlaced records that the container landed on a node at t and begins
+// starting up; a moved container starts up again on its new node
+func (c *Container) MarkPlaced(t time.Time) {
+	c.placedAt = t
+	c.memoryCeiling = 0
+}
+
+// IsReady reports whether the container has finished starting up, i.e.
+// its startup duration has elapsed since it was placed
+func (c *Container) IsReady(now time.Time) bool {
+	return !c.placedAt.IsZero() && !now.Before(c.placedAt.Add(c.StartupDuration()))
+}
+
+// CreationTime is when the container arrived, used to order equal-priority
+// containers first come, first served
+func (c *Container) CreationTime() time.Time {
+	return c.creationTime
+}
+
+// SetCreationTime stamps the container's arrival on the run's clock; until
+// then it carries the wall-clock time it was created
+func (c *Container) SetCreationTime(t time.Time) {
+	c.creationTime = t
 }
 
-func (c *Container) Age() time.Duration {
-	return time.Since(c.creationTime)
+func (c *Container) Age(now time.Time) time.Duration {
+	return now.Sub(c.creationTime)
 }
 
 func (c *Container) CPUIntensive() bool {
-	return c.cpuRequest > 2.0
+	return c.CPURequest() > cpuIntensityThreshold
 }
 
 func (c *Container) MemoryIntensive() bool {
-	return c.memoryRequest > 2048
+	return c.MemoryRequest() > memoryIntensityThreshold
 }
 
 func (c *Container) NetworkIntensive() bool {
-	return c.networkRequest > 500
+	return c.NetworkRequest() > networkIntensityThreshold
 }
 
 func (c *Container) IOIntensive() bool {
-	return c.ioRequest > 5000
+	return c.IORequest() > ioIntensityThreshold
 }
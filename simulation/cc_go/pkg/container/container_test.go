@@ -0,0 +1,41 @@
+package container
+
+import (
+	"testing"
+	"time"
+)
+
+func TestContainerIDsAreUnique(t *testing.T) {
+	seen := make(map[string]bool, 10000)
+	for i := 0; i < 10000; i++ {
+		c := NewContainer("web", "nginx", 0.5, 256, 10, 100, "web", 1)
+		if seen[c.ID()] {
+			t.Fatalf("container %d reuses ID %s", i, c.ID())
+		}
+		seen[c.ID()] = true
+	}
+}
+
+func TestReclaimMemoryStopsAtRequest(t *testing.T) {
+	now := time.Now()
+	c := NewContainer("cache", "redis", 1, 1000, 10, 100, "cache", 1)
+	c.SetLimits(2, 1500)
+	c.SetUsageFactor(1.4)
+	
+	if got := c.ReclaimableMemory(now); got != 400 {
+		t.Fatalf("reclaimable memory %g, want 400", got)
+	}
+	if got := c.ReclaimMemory(1000, now); got != 400 {
+		t.Errorf("reclaimed %g, want the 400 above the request", got)
+	}
+	if _, memory, _, _ := c.EffectiveUsage(now); memory != 1000 {
+		t.Errorf("memory use after reclaim %g, want the request 1000", memory)
+	}
+	
+	guaranteed := NewContainer("db", "postgres", 1, 1000, 10, 100, "db", 1)
+	guaranteed.SetLimits(1, 1000)
+	guaranteed.SetUsageFactor(1.4)
+	if got := guaranteed.ReclaimMemory(1000, now); got != 0 {
+		t.Errorf("reclaimed %g from a Guaranteed container, want 0", got)
+	}
+}
@@ -0,0 +1,31 @@
+// pkg/container/pod.go - Sidecars scheduled together with their container
+package container
+
+// AddSidecar attaches a sidecar that must run on the same node. From then
+// on the container is scheduled as a pod: its requests are the sum of its
+// own and its sidecars', so it either fits somewhere as a whole or fails.
+func (c *Container) AddSidecar(sidecar *Container) {
+	c.sidecars = append(c.sidecars, sidecar)
+}
+
+func (c *Container) Sidecars() []*Container {
+	return c.sidecars
+}
+
+// SidecarOverhead is how much CPU and memory the sidecars add to the
+// container's own requests
+func (c *Container) SidecarOverhead() (cpu, memory float64) {
+	for _, sidecar := range c.sidecars {
+		cpu += sidecar.CPURequest()
+		memory += sidecar.MemoryRequest()
+	}
+	return cpu, memory
+}
+
+// podRequest adds the sidecars' share of a resource to the container's own
+func (c *Container) podRequest(own float64, request func(*Container) float64) float64 {
+	for _, sidecar := range c.sidecars {
+		own += request(sidecar)
+	}
+	return own
+}
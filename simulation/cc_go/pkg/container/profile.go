@@ -0,0 +1,90 @@
+// pkg/container/profile.go - Resource usage profiles over a container's lifetime
+package container
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// UsageProfile shapes how much of its requests a container actually uses
+// as it ages on a node. UsageAt returns the usage of each resource as a
+// multiple of the request, age measured from placement.
+type UsageProfile interface {
+	UsageAt(age time.Duration) (cpu, memory, network, io float64)
+}
+
+// UsageProfileNames lists the built-in profiles accepted by NewUsageProfile
+var UsageProfileNames = []string{"constant", "ramp", "sawtooth", "spike"}
+
+// NewUsageProfile returns the built-in profile called name with its
+// default shape; an empty name means constant
+func NewUsageProfile(name string) (UsageProfile, error) {
+	switch name {
+	case "", "constant":
+		return ConstantProfile{}, nil
+	case "ramp":
+		return RampProfile{Start: 0.2, Duration: 30 * time.Second}, nil
+	case "sawtooth":
+		return SawtoothProfile{Min: 0.3, Period: 20 * time.Second}, nil
+	case "spike":
+		return SpikeProfile{Base: 0.5, Peak: 1.5, Period: 30 * time.Second, Width: 5 * time.Second}, nil
+	default:
+		return nil, fmt.Errorf("unknown usage profile %q (expected one of %v)", name, UsageProfileNames)
+	}
+}
+
+// ConstantProfile uses exactly the requests, always
+type ConstantProfile struct{}
+
+func (p ConstantProfile) UsageAt(age time.Duration) (cpu, memory, network, io float64) {
+	return 1, 1, 1, 1
+}
+
+// RampProfile starts at Start times the requests and grows linearly to the
+// full requests over Duration, like a batch job warming up
+type RampProfile struct {
+	Start    float64
+	Duration time.Duration
+}
+
+func (p RampProfile) UsageAt(age time.Duration) (cpu, memory, network, io float64) {
+	level := 1.0
+	if p.Duration > 0 && age < p.Duration {
+		level = p.Start + (1-p.Start)*age.Seconds()/p.Duration.Seconds()
+	}
+	return level, level, level, level
+}
+
+// SawtoothProfile climbs from Min to the full requests over each Period,
+// then drops back, like a cache that fills up and is flushed
+type SawtoothProfile struct {
+	Min    float64
+	Period time.Duration
+}
+
+func (p SawtoothProfile) UsageAt(age time.Duration) (cpu, memory, network, io float64) {
+	level := 1.0
+	if p.Period > 0 {
+		phase := math.Mod(age.Seconds(), p.Period.Seconds()) / p.Period.Seconds()
+		level = p.Min + (1-p.Min)*phase
+	}
+	return level, level, level, level
+}
+
+// SpikeProfile idles at Base and jumps to Peak for Width at the start of
+// every Period, like a cron job; Peak above 1 exceeds the requests
+type SpikeProfile struct {
+	Base   float64
+	Peak   float64
+	Period time.Duration
+	Width  time.Duration
+}
+
+func (p SpikeProfile) UsageAt(age time.Duration) (cpu, memory, network, io float64) {
+	level := p.Base
+	if p.Period > 0 && time.Duration(math.Mod(float64(age), float64(p.Period))) < p.Width {
+		level = p.Peak
+	}
+	return level, level, level, level
+}
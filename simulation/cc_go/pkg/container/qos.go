@@ -0,0 +1,94 @@
+// pkg/container/qos.go - Resource limits and QoS classes
+package container
+
+import (
+	"math"
+	"time"
+)
+
+// QoS classes, from the first to be evicted to the last
+const (
+	QoSBestEffort = "BestEffort"
+	QoSBurstable  = "Burstable"
+	QoSGuaranteed = "Guaranteed"
+)
+
+// QoSClasses lists the classes in eviction order
+var QoSClasses = []string{QoSBestEffort, QoSBurstable, QoSGuaranteed}
+
+// SetLimits caps the CPU and memory the container may actually use. A
+// limit of zero leaves that resource unlimited; a limit below the request
+// is raised to it.
+func (c *Container) SetLimits(cpu, memory float64) {
+	if cpu > 0 && cpu < c.cpuRequest {
+		cpu = c.cpuRequest
+	}
+	if memory > 0 && memory < c.memoryRequest {
+		memory = c.memoryRequest
+	}
+	c.cpuLimit = cpu
+	c.memoryLimit = memory
+}
+
+// Limits returns the CPU and memory limits; zero means unlimited
+func (c *Container) Limits() (cpu, memory float64) {
+	return c.cpuLimit, c.memoryLimit
+}
+
+// ReclaimableMemory is the memory the container uses at now beyond its
+// request, which the kernel can take back under pressure without killing
+// it. It never exceeds the gap between limit and request, so Guaranteed
+// containers have none.
+func (c *Container) ReclaimableMemory(now time.Time) float64 {
+	_, memory, _, _ := c.EffectiveUsage(now)
+	return math.Max(memory-c.MemoryRequest(), 0)
+}
+
+// ReclaimMemory takes back up to mb of the container's reclaimable memory
+// at now and returns how much it took. Its usage stays down at most at
+// the reduced level until it is placed again.
+func (c *Container) ReclaimMemory(mb float64, now time.Time) float64 {
+	reclaimed := math.Min(mb, c.ReclaimableMemory(now))
+	if reclaimed <= 0 {
+		return 0
+	}
+	_, memory, _, _ := c.EffectiveUsage(now)
+	c.memoryCeiling = memory - reclaimed
+	return reclaimed
+}
+
+// QoSClass derives the container's class the way Kubernetes does:
+// Guaranteed if its CPU and memory limits equal non-zero requests,
+// BestEffort if it has neither requests nor limits, and Burstable
+// otherwise. A pod is Guaranteed or BestEffort only if its sidecars are
+// too.
+func (c *Container) QoSClass() string {
+	class := c.ownQoSClass()
+	for _, sidecar := range c.sidecars {
+		if sidecar.ownQoSClass() != class {
+			return QoSBurstable
+		}
+	}
+	return class
+}
+
+func (c *Container) ownQoSClass() string {
+	if c.cpuRequest > 0 && c.memoryRequest > 0 &&
+		c.cpuLimit == c.cpuRequest && c.memoryLimit == c.memoryRequest {
+		return QoSGuaranteed
+	}
+	if c.cpuRequest == 0 && c.memoryRequest == 0 && c.cpuLimit == 0 && c.memoryLimit == 0 {
+		return QoSBestEffort
+	}
+	return QoSBurstable
+}
+
+// QoSRank orders classes for eviction: lower ranks are evicted first
+func QoSRank(class string) int {
+	for rank, candidate := range QoSClasses {
+		if candidate == class {
+			return rank
+		}
+	}
+	return len(QoSClasses)
+}
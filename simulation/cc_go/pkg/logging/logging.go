@@ -0,0 +1,136 @@
+// pkg/logging/logging.go - Leveled logging
+package logging
+
+import (
+	"io"
+	"log"
+	"os"
+	"sync"
+)
+
+// Level orders log messages by importance; messages below a logger's level
+// are dropped
+type Level int
+
+const (
+	Debug Level = iota // per-container chatter, e.g. every placement
+	Info               // progress of the run
+	Warn               // something failed but the run goes on
+	Error              // something the run cannot recover from by itself
+)
+
+var levelNames = [...]string{"DEBUG", "INFO", "WARN", "ERROR"}
+
+func (l Level) String() string {
+	if l < Debug || l > Error {
+		return "UNKNOWN"
+	}
+	return levelNames[l]
+}
+
+// Logger is what the simulator logs through; inject one to route messages
+// elsewhere, e.g. into a test or a structured log pipeline
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// StdLogger writes messages at or above its level to a standard library
+// logger, each prefixed with its level
+type StdLogger struct {
+	mu    sync.Mutex
+	out   *log.Logger
+	level Level
+}
+
+func New(w io.Writer, level Level) *StdLogger {
+	return &StdLogger{
+		out:   log.New(w, "", log.LstdFlags),
+		level: level,
+	}
+}
+
+func (l *StdLogger) SetLevel(level Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	
+	l.level = level
+}
+
+// Enabled reports whether messages at level are written
+func (l *StdLogger) Enabled(level Level) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	
+	return level >= l.level
+}
+
+func (l *StdLogger) logf(level Level, format string, args []interface{}) {
+	if !l.Enabled(level) {
+		return
+	}
+	l.out.Printf(level.String()+" "+format, args...)
+}
+
+func (l *StdLogger) Debugf(format string, args ...interface{}) {
+	l.logf(Debug, format, args)
+}
+
+func (l *StdLogger) Infof(format string, args ...interface{}) {
+	l.logf(Info, format, args)
+}
+
+func (l *StdLogger) Warnf(format string, args ...interface{}) {
+	l.logf(Warn, format, args)
+}
+
+func (l *StdLogger) Errorf(format string, args ...interface{}) {
+	l.logf(Error, format, args)
+}
+
+// Discard drops every message
+type Discard struct{}
+
+func (Discard) Debugf(format string, args ...interface{}) {}
+func (Discard) Infof(format string, args ...interface{})  {}
+func (Discard) Warnf(format string, args ...interface{})  {}
+func (Discard) Errorf(format string, args ...interface{}) {}
+
+var (
+	defaultMu     sync.Mutex
+	defaultLogger Logger = New(os.Stderr, Info)
+)
+
+// SetDefault replaces the logger used by the package-level functions and by
+// components that were not given a logger of their own
+func SetDefault(l Logger) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	
+	defaultLogger = l
+}
+
+func Default() Logger {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	
+	return defaultLogger
+}
+
+func Debugf(format string, args ...interface{}) {
+	Default().Debugf(format, args...)
+}
+
+func Infof(format string, args ...interface{}) {
+	Default().Infof(format, args...)
+}
+
+func Warnf(format string, args ...interface{}) {
+	Default().Warnf(format, args...)
+}
+
+func Errorf(format string, args ...interface{}) {
+	Default().Errorf(format, args...)
+}
@@ -0,0 +1,164 @@
+// pkg/metrics/artifacts.go - Writing every result file into one directory
+package metrics
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// SaveAll writes every result file with data into dir, which is created if
+// missing: events.csv (SaveToFile), types.csv, timeline.csv,
+// throughput.csv, nodes.csv, node_utilization.csv and summary.json, plus
+// states.csv, replicas.csv and load.csv when the run produced any. A failing writer
+// does not stop the others; their errors are returned together.
+func (r *Results) SaveAll(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	artifacts := []struct {
+		name string
+		save func(string) error
+		skip bool
+	}{
+		{"events.csv", r.SaveToFile, false},
+		{"types.csv", r.SaveTypeStatsToFile, false},
+		{"timeline.csv", r.SaveTimelineCSV, false},
+		{"throughput.csv", r.SaveThroughputCSV, false},
+		{"nodes.csv", r.SaveNodeCountCSV, false},
+		{"node_utilization.csv", r.SaveNodeUtilizationCSV, false},
+		{"summary.json", r.SaveSummaryJSON, false},
+		{"states.csv", r.SaveStateTransitionsCSV, len(r.StateTransitions) == 0},
+		{"replicas.csv", r.SaveReplicaCSV, len(r.ReplicaSeries) == 0},
+		{"load.csv", r.SaveLoadTargetCSV, len(r.LoadTarget.Samples) == 0},
+	}
+	var errs []error
+	for _, artifact := range artifacts {
+		if artifact.skip {
+			continue
+		}
+		if err := artifact.save(filepath.Join(dir, artifact.name)); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", artifact.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Summary is the headline figures of a run, as written by SaveSummaryJSON
+type Summary struct {
+	ContainersScheduled int                  `json:"containers_scheduled"`
+	SchedulingFailures  int                  `json:"scheduling_failures"`
+	AverageLatency      float64              `json:"average_latency_ms"`
+	AverageQueueDelay   float64              `json:"average_queue_delay_ms"`
+	P95QueueDelay       float64              `json:"p95_queue_delay_ms"`
+	AverageStartupTime  float64              `json:"average_startup_ms"`
+	AverageTimeToReady  float64              `json:"average_time_to_ready_ms"`
+	ResourceUtilization float64              `json:"resource_utilization"`
+	PackingEfficiency   float64              `json:"packing_efficiency"`
+	FairnessIndex       float64              `json:"fairness_index"`
+	WorstServedType     string               `json:"worst_served_type"`
+	Energy              float64              `json:"energy_wh"`
+	Cost                float64              `json:"cost"`
+	Preemptions         int                  `json:"preemptions"`
+	Disruptions         int                  `json:"disruptions"`
+	OOMKills            int                  `json:"oom_kills"`
+	MemoryReclaimed     float64              `json:"memory_reclaimed_mb"`
+	Migrations          int                  `json:"migrations"`
+	PeakNodes           int                  `json:"peak_nodes"`
+	TargetUtilization   float64              `json:"target_utilization,omitempty"`
+	SteadyUtilization   float64              `json:"steady_utilization,omitempty"`
+	SteadyArrivalRate   float64              `json:"steady_arrival_rate,omitempty"`
+	TypeStats           map[string]TypeStats `json:"types"`
+}
+
+func (r *Results) Summary() Summary {
+	steadyUtilization, steadyRate := r.LoadTarget.Steady()
+	return Summary{
+		ContainersScheduled: r.ContainersScheduled,
+		SchedulingFailures:  r.SchedulingFailures,
+		AverageLatency:      r.AverageLatency,
+		AverageQueueDelay:   r.AverageQueueDelay,
+		P95QueueDelay:       r.QueueDelayPercentile(95),
+		AverageStartupTime:  r.AverageStartupTime,
+		AverageTimeToReady:  r.AverageTimeToReady,
+		ResourceUtilization: r.ResourceUtilization,
+		PackingEfficiency:   r.PackingEfficiency,
+		FairnessIndex:       r.FairnessIndex,
+		WorstServedType:     r.WorstServedType,
+		Energy:              r.TotalEnergy / 3600.0,
+		Cost:                r.TotalCost,
+		Preemptions:         r.Preemptions,
+		Disruptions:         r.Disruptions,
+		OOMKills:            r.OOMKills,
+		MemoryReclaimed:     r.MemoryReclaim.Total,
+		Migrations:          len(r.Migrations),
+		PeakNodes:           r.PeakNodes,
+		TargetUtilization:   r.LoadTarget.Target,
+		SteadyUtilization:   steadyUtilization,
+		SteadyArrivalRate:   steadyRate,
+		TypeStats:           r.TypeStats,
+	}
+}
+
+func (r *Results) SaveSummaryJSON(filename string) error {
+	data, err := json.MarshalIndent(r.Summary(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+// SaveNodeUtilizationCSV writes the spread of utilization across nodes,
+// at the end of the run and averaged over it, one row per resource
+func (r *Results) SaveNodeUtilizationCSV(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	if err := writer.Write([]string{"Figure", "Resource", "Min", "Median", "P90", "Max"}); err != nil {
+		return err
+	}
+
+	figures := []struct {
+		name  string
+		stats NodeUtilizationStats
+	}{
+		{"end", r.NodeUtilization},
+		{"average", r.NodeUtilizationAverage},
+	}
+	for _, figure := range figures {
+		rows := []struct {
+			resource     string
+			distribution Distribution
+		}{
+			{"cpu", figure.stats.CPU},
+			{"memory", figure.stats.Memory},
+			{"network", figure.stats.Network},
+			{"io", figure.stats.IO},
+			{"disk", figure.stats.Disk},
+			{"overall", figure.stats.Overall},
+		}
+		for _, row := range rows {
+			d := row.distribution
+			record := []string{figure.name, row.resource}
+			for _, value := range []float64{d.Min, d.Median, d.P90, d.Max} {
+				record = append(record, strconv.FormatFloat(value, 'f', 4, 64))
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	return writer.Error()
+}
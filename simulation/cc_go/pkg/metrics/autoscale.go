@@ -0,0 +1,64 @@
+// pkg/metrics/autoscale.go - Cluster size tracking
+package metrics
+
+import (
+	"cc_go/pkg/node"
+	"encoding/csv"
+	"os"
+	"strconv"
+	"time"
+)
+
+// NodeCountSample is the number of nodes in the cluster at a point in the run
+type NodeCountSample struct {
+	Offset time.Duration // since the start of the run
+	Count  int
+}
+
+// RecordNodeCountSample adds a point to the node count series
+func (c *MetricsCollector) RecordNodeCountSample(count int) {
+	c.nodeCountSeries = append(c.nodeCountSeries, NodeCountSample{
+		Offset: c.clock.Now().Sub(c.startTime),
+		Count:  count,
+	})
+	if count > c.peakNodes {
+		c.peakNodes = count
+	}
+}
+
+// RecordScaling counts a node the autoscaler added (up) or removed
+func (c *MetricsCollector) RecordScaling(n *node.Node, up bool) {
+	if up {
+		c.scaleUps++
+	} else {
+		c.scaleDowns++
+	}
+}
+
+// SaveNodeCountCSV writes the node count series, one row per sample
+func (r *Results) SaveNodeCountCSV(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+	
+	if err := writer.Write([]string{"Offset(s)", "Nodes"}); err != nil {
+		return err
+	}
+	
+	for _, sample := range r.NodeCountSeries {
+		record := []string{
+			strconv.FormatFloat(sample.Offset.Seconds(), 'f', 3, 64),
+			strconv.Itoa(sample.Count),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	
+	return writer.Error()
+}
@@ -0,0 +1,66 @@
+// pkg/metrics/capped.go - Bounded event retention
+package metrics
+
+import (
+	"math/rand"
+	"time"
+)
+
+// latencyReservoirSize is how many successful latencies a capped collector
+// keeps for percentile estimates
+const latencyReservoirSize = 4096
+
+// NewCappedCollector returns a collector that keeps only the most recent
+// maxEvents scheduling events, so long runs use bounded memory. The summary
+// counters and averages still cover every event; latency percentiles come
+// from a uniform reservoir sample of all successful placements. Results.Events,
+// and so the CSV written from it, only holds the retained window.
+func NewCappedCollector(maxEvents int) *MetricsCollector {
+	c := NewCollector()
+	c.maxEvents = maxEvents
+	c.events = make([]SchedulingEvent, 0, maxEvents)
+	c.latencySample = make([]float64, 0, latencyReservoirSize)
+	c.queueDelaySample = make([]float64, 0, latencyReservoirSize)
+	c.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	return c
+}
+
+// retainEvent keeps event, overwriting the oldest one once maxEvents are held
+func (c *MetricsCollector) retainEvent(event SchedulingEvent) {
+	if c.maxEvents <= 0 || len(c.events) < c.maxEvents {
+		c.events = append(c.events, event)
+		return
+	}
+	
+	c.events[c.oldestEvent] = event
+	c.oldestEvent = (c.oldestEvent + 1) % c.maxEvents
+	c.droppedEvents++
+}
+
+// retainedEvents returns the kept events, oldest first
+func (c *MetricsCollector) retainedEvents() []SchedulingEvent {
+	if c.oldestEvent == 0 {
+		return c.events
+	}
+	
+	ordered := make([]SchedulingEvent, 0, len(c.events))
+	ordered = append(ordered, c.events[c.oldestEvent:]...)
+	return append(ordered, c.events[:c.oldestEvent]...)
+}
+
+// sampleLatency adds a successful placement's latency and queue delay to
+// the reservoir (Algorithm R); c.containersScheduled already counts it
+func (c *MetricsCollector) sampleLatency(latency, queueDelay time.Duration) {
+	ms := float64(latency.Microseconds()) / 1000.0
+	delayMs := float64(queueDelay.Microseconds()) / 1000.0
+	if len(c.latencySample) < latencyReservoirSize {
+		c.latencySample = append(c.latencySample, ms)
+		c.queueDelaySample = append(c.queueDelaySample, delayMs)
+		return
+	}
+	
+	if i := c.rng.Intn(c.containersScheduled); i < latencyReservoirSize {
+		c.latencySample[i] = ms
+		c.queueDelaySample[i] = delayMs
+	}
+}
@@ -0,0 +1,40 @@
+// pkg/metrics/compare.go - Combined results of several schedulers
+package metrics
+
+import (
+	"encoding/csv"
+	"os"
+)
+
+// SchedulerResults pairs a scheduler's name with the results of its run
+type SchedulerResults struct {
+	Scheduler string
+	Results   *Results
+}
+
+// SaveComparisonToFile writes the events of every run to one CSV, using the
+// same columns as SaveToFile preceded by a Scheduler column
+func SaveComparisonToFile(filename string, runs []SchedulerResults) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+	
+	if err := writer.Write(append([]string{"Scheduler"}, eventHeader...)); err != nil {
+		return err
+	}
+	
+	for _, run := range runs {
+		for _, event := range run.Results.Events {
+			if err := writer.Write(append([]string{run.Scheduler}, eventRecord(event)...)); err != nil {
+				return err
+			}
+		}
+	}
+	
+	return nil
+}
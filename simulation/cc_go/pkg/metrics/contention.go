@@ -0,0 +1,33 @@
+// pkg/metrics/contention.go - Throughput lost to NIC contention
+package metrics
+
+import (
+	"time"
+)
+
+// NetworkContention summarizes how much throughput over-subscribed NICs cost
+type NetworkContention struct {
+	MeanDegradation float64       // time-averaged share of cluster network demand lost
+	PeakDegradation float64       // worst single node's lost share in any sample
+	LostThroughput  float64       // time-averaged Mbps lost across the cluster
+	ContendedTime   time.Duration // node time spent with an over-subscribed NIC
+}
+
+// RecordContentionSample takes one sample of the cluster's NIC contention:
+// the demand offered to and lost by all NICs, the worst node's contention
+// and how many nodes were contended
+func (c *MetricsCollector) RecordContentionSample(demand, lost, peak float64, contended int, interval time.Duration) {
+	degradation := 0.0
+	if demand > 0 {
+		degradation = lost / demand
+	}
+	
+	weight := float64(c.contentionDatapoints)
+	c.contention.MeanDegradation = (c.contention.MeanDegradation*weight + degradation) / (weight + 1)
+	c.contention.LostThroughput = (c.contention.LostThroughput*weight + lost) / (weight + 1)
+	if peak > c.contention.PeakDegradation {
+		c.contention.PeakDegradation = peak
+	}
+	c.contention.ContendedTime += time.Duration(contended) * interval
+	c.contentionDatapoints++
+}
@@ -0,0 +1,13 @@
+// pkg/metrics/disruption.go - Eviction disruption accounting
+package metrics
+
+import (
+	"cc_go/pkg/container"
+)
+
+// RecordDisruption charges the disruption cost of evicting victim from a
+// running node, whether by preemption, migration, draining or an OOM kill
+func (c *MetricsCollector) RecordDisruption(victim *container.Container) {
+	c.disruptions++
+	c.disruptionCost += victim.DisruptionCost()
+}
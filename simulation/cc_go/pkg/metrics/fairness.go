@@ -0,0 +1,103 @@
+// pkg/metrics/fairness.go - Per-container-type fairness reporting
+package metrics
+
+import (
+	"encoding/csv"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// TypeStats counts scheduling outcomes for one container type
+type TypeStats struct {
+	Scheduled int
+	Failures  int
+}
+
+func (s TypeStats) SuccessRate() float64 {
+	total := s.Scheduled + s.Failures
+	if total == 0 {
+		return 0.0
+	}
+	return float64(s.Scheduled) / float64(total)
+}
+
+// fairnessIndex computes Jain's fairness index over per-type success rates
+// and returns it along with the type with the lowest success rate. The
+// index is 1.0 when every type is served equally and approaches 1/n when a
+// single type gets all the successes.
+func fairnessIndex(typeStats map[string]TypeStats) (float64, string) {
+	if len(typeStats) == 0 {
+		return 0.0, ""
+	}
+
+	types := make([]string, 0, len(typeStats))
+	for containerType := range typeStats {
+		types = append(types, containerType)
+	}
+	sort.Strings(types)
+
+	sum := 0.0
+	sumSquares := 0.0
+	worstType := types[0]
+	for _, containerType := range types {
+		rate := typeStats[containerType].SuccessRate()
+		sum += rate
+		sumSquares += rate * rate
+		if rate < typeStats[worstType].SuccessRate() {
+			worstType = containerType
+		}
+	}
+
+	if sumSquares == 0 {
+		// Every type failed completely, which is at least equal treatment
+		return 1.0, worstType
+	}
+
+	return (sum * sum) / (float64(len(types)) * sumSquares), worstType
+}
+
+// SaveTypeStatsToFile writes the per-type breakdown as a sidecar CSV
+func (r *Results) SaveTypeStatsToFile(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	header := []string{
+		"ContainerType",
+		"Scheduled",
+		"Failures",
+		"SuccessRate",
+	}
+
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	types := make([]string, 0, len(r.TypeStats))
+	for containerType := range r.TypeStats {
+		types = append(types, containerType)
+	}
+	sort.Strings(types)
+
+	for _, containerType := range types {
+		stats := r.TypeStats[containerType]
+		record := []string{
+			containerType,
+			strconv.Itoa(stats.Scheduled),
+			strconv.Itoa(stats.Failures),
+			strconv.FormatFloat(stats.SuccessRate(), 'f', 3, 64),
+		}
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
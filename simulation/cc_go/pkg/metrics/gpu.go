@@ -0,0 +1,29 @@
+// pkg/metrics/gpu.go - GPU memory and GPU count utilization
+package metrics
+
+// GPUUtilization keeps how much GPU memory is allocated apart from how many
+// GPUs are in use at all. Containers sharing GPUs fill the second faster
+// than the first.
+type GPUUtilization struct {
+	MeanMemory    float64 // time-averaged share of the cluster's GPU memory allocated
+	PeakMemory    float64
+	MeanAllocated float64 // time-averaged share of GPUs with any memory allocated
+	PeakAllocated float64
+	Samples       int // 0 when the cluster has no GPUs
+}
+
+// RecordGPUSample takes one sample of the share of GPU memory allocated
+// and the share of GPUs in use; it should only be called for clusters
+// with GPUs
+func (c *MetricsCollector) RecordGPUSample(memory, allocated float64) {
+	weight := float64(c.gpu.Samples)
+	c.gpu.MeanMemory = (c.gpu.MeanMemory*weight + memory) / (weight + 1)
+	c.gpu.MeanAllocated = (c.gpu.MeanAllocated*weight + allocated) / (weight + 1)
+	if memory > c.gpu.PeakMemory {
+		c.gpu.PeakMemory = memory
+	}
+	if allocated > c.gpu.PeakAllocated {
+		c.gpu.PeakAllocated = allocated
+	}
+	c.gpu.Samples++
+}
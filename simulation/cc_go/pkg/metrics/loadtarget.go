@@ -0,0 +1,84 @@
+// pkg/metrics/loadtarget.go - Arrival rate steered towards a utilization target
+package metrics
+
+import (
+	"encoding/csv"
+	"os"
+	"strconv"
+	"time"
+)
+
+// LoadTarget is how the arrival rate was steered towards a cluster
+// utilization target, one sample per controller step
+type LoadTarget struct {
+	Target  float64
+	Samples []LoadTargetSample
+}
+
+// LoadTargetSample is the utilization the controller saw and the arrival
+// rate it set in response
+type LoadTargetSample struct {
+	Offset      time.Duration // since the start of the run
+	Utilization float64
+	Rate        float64 // containers per second
+}
+
+// steadyFraction is the share of the run, at its end, taken as steady state
+const steadyFraction = 0.25
+
+// Steady returns the mean utilization and arrival rate over the last
+// quarter of the samples, by which time the controller should have settled
+func (t LoadTarget) Steady() (utilization, rate float64) {
+	if len(t.Samples) == 0 {
+		return 0, 0
+	}
+	count := int(float64(len(t.Samples))*steadyFraction + 0.5)
+	if count < 1 {
+		count = 1
+	}
+	for _, sample := range t.Samples[len(t.Samples)-count:] {
+		utilization += sample.Utilization
+		rate += sample.Rate
+	}
+	return utilization / float64(count), rate / float64(count)
+}
+
+// RecordLoadControl adds a step of the controller steering towards target
+func (c *MetricsCollector) RecordLoadControl(target, utilization, rate float64) {
+	c.loadTarget.Target = target
+	c.loadTarget.Samples = append(c.loadTarget.Samples, LoadTargetSample{
+		Offset:      c.clock.Now().Sub(c.startTime),
+		Utilization: utilization,
+		Rate:        rate,
+	})
+}
+
+// SaveLoadTargetCSV writes the controller steps, one row per step
+func (r *Results) SaveLoadTargetCSV(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	if err := writer.Write([]string{"Offset(s)", "Target", "Utilization", "ArrivalRate"}); err != nil {
+		return err
+	}
+
+	for _, sample := range r.LoadTarget.Samples {
+		record := []string{
+			strconv.FormatFloat(sample.Offset.Seconds(), 'f', 3, 64),
+			strconv.FormatFloat(r.LoadTarget.Target, 'f', 4, 64),
+			strconv.FormatFloat(sample.Utilization, 'f', 4, 64),
+			strconv.FormatFloat(sample.Rate, 'f', 3, 64),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	return writer.Error()
+}
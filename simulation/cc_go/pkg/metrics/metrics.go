@@ -2,10 +2,15 @@
 package metrics
 
 import (
+	"cc_go/pkg/clock"
 	"cc_go/pkg/container"
 	"cc_go/pkg/node"
 	"encoding/csv"
+	"io"
+	"math"
+	"math/rand"
 	"os"
+	"sort"
 	"strconv"
 	"time"
 )
@@ -15,36 +20,229 @@ type SchedulingEvent struct {
 	ContainerID         string
 	ContainerType       string
 	NodeID              string
-	SchedulingLatency   time.Duration
+	ComputeLatency      time.Duration // time spent deciding, inside the scheduler
+	QueueDelay          time.Duration // time waited from arrival (or eviction) until placed; 0 for failures
 	ScheduleSuccess     bool
 	ResourceUtilization float64
+	// Per-resource utilization of the target node after placement
+	CPUUtilization      float64
+	MemoryUtilization   float64
+	NetworkUtilization  float64
+	IOUtilization       float64
+	DiskUtilization     float64
+	FailureReason       string // diagnostic of a failed placement
+}
+
+type MigrationEvent struct {
+	Timestamp           time.Time
+	ContainerID         string
+	FromNodeID          string
+	ToNodeID            string
+	ClusterLoadVariance float64 // cluster-wide load variance after the move
 }
 
 type Results struct {
 	ContainersScheduled   int
+	ScheduledAfterRetry   int // successes that needed more than one attempt
 	SchedulingFailures    int
 	AverageLatency        float64
+	AverageStartupTime    float64 // ms from placement until ready
+	ImageCacheHits        int     // placements whose node already had the image
+	ImageCacheMisses      int
+	AverageTimeToReady    float64 // ms from arrival until ready, including startup
+	AverageQueueDelay     float64 // ms successful placements waited between arrival (or eviction) and placement
 	ResourceUtilization   float64
+	NodeUtilization       NodeUtilizationStats // spread across nodes at the end of the run
+	NodeUtilizationAverage NodeUtilizationStats // the same, averaged over the run
 	Events                []SchedulingEvent
+	DroppedEvents         int       // events discarded by a capped collector
+	LatencySample         []float64 // ms; reservoir of successful latencies when events were capped, nil otherwise
+	QueueDelaySample      []float64 // ms; queue delays of the same placements as LatencySample
+	Migrations            []MigrationEvent
+	Removals              []RemovalEvent
+	TotalEnergy           float64 // watt-seconds consumed by the cluster
+	TotalCost             float64 // dollars accrued by occupied nodes
+	PackingEfficiency     float64 // time-averaged utilization of occupied nodes
+	Stranded              StrandedResources // time-averaged stranded share of cluster capacity
+	NetworkContention     NetworkContention
+	GPU                   GPUUtilization
+	TypeStats             map[string]TypeStats
+	PriorityStats         map[int]PriorityStats
+	QoSStats              map[string]QoSStats // by QoS class
+	SourceStats           map[string]TypeStats // by workload source, for mixed workloads
+	Preemptions           int
+	SidecarOverhead       SidecarOverhead
+	PinnedPlacements      int // NUMA-pinned containers placed
+	CrossSocketPlacements int // pinned containers split across sockets
+	PinningFailures       int // pinned placements that failed although a node had the aggregate capacity
+	Disruptions           int     // evictions of running containers, for any reason
+	DisruptionCost        float64 // summed disruption cost of those evictions
+	OOMKills              int // containers evicted because their node ran out of memory
+	MemoryReclaim         MemoryReclaim
+	LoadTarget            LoadTarget // empty unless the arrival rate was steered towards a utilization target
+	OrderedContainers     int     // ordered group members that became schedulable
+	AverageOrderingDelay  float64 // ms an ordered group member waited for its predecessor
+	OrderingCancelled     int     // group members dropped because an earlier member failed
+	Rejections            int // placements a node refused after the scheduler chose it
+	RescheduledAfterReject int // rejected placements that then succeeded on another node
+	FairnessIndex         float64 // Jain's index over per-type success rates
+	WorstServedType       string
+	NodeHealth            map[string]float64 // latest health score by node name
+	TimeToFirstFailure    time.Duration // since run start; zero if nothing failed
+	SaturationTime        time.Duration // when failures became consistent; zero if never
+	UnschedulableSeries   []UnschedulableSample
+	ThroughputSeries      []ThroughputSample
+	NodeCountSeries       []NodeCountSample
+	PeakNodes             int
+	ScaleUps              int // nodes added by the autoscaler
+	ScaleDowns            int // empty nodes removed by the autoscaler
+	StateTransitions      []StateTransition // scheduler state changes in the order they happened
+	Warmup                time.Duration // start of the run excluded from every other field
+	WarmupPlacements      int // containers placed during the warmup; they still occupied nodes
+	WarmupFailures        int
+	ReplicaSeries         []ReplicaSample
+	ReplicaStats          map[string]ReplicaStats // by container type with replica limits
+	PreemptionPlans       PreemptionTotals
+}
+
+// StrandedResources holds, per resource, the share of cluster capacity that
+// is free but stranded on nodes that can no longer fit a typical container
+type StrandedResources struct {
+	CPU     float64
+	Memory  float64
+	Network float64
+	IO      float64
+	Disk    float64
 }
 
 type Collector interface {
 	RecordSchedulingEvent(container *container.Container, node *node.Node, latency time.Duration, success bool)
+	RecordMigration(container *container.Container, from, to *node.Node, clusterLoadVariance float64)
+	RecordRemovalEvent(containerID string, node *node.Node, t time.Time)
+	RecordPowerSample(watts float64, interval time.Duration)
+	RecordCostSample(hourlyCost float64, interval time.Duration)
+	RecordPackingSample(occupiedUtilization float64)
+	RecordStrandedSample(stranded StrandedResources)
+	RecordContentionSample(demand, lost, peak float64, contended int, interval time.Duration)
+	RecordGPUSample(memory, allocated float64)
+	RecordUnschedulableSample(waiting int)
+	RecordThroughputSample(arrivals, placements, backlog int, interval time.Duration)
+	RecordNodeUtilizationSample(stats NodeUtilizationStats)
+	RecordNodeCountSample(count int)
+	RecordScaling(n *node.Node, up bool)
+	RecordNodeHealth(nodeName string, score float64)
+	RecordImagePull(hit bool)
+	RecordPreemption(victim *container.Container, node *node.Node)
+	RecordPreemptionPlan(plan PreemptionPlan)
+	RecordRejection(rescheduled bool)
+	RecordOOMKill(victim *container.Container, node *node.Node)
+	RecordMemoryReclaim(node *node.Node, mb float64, relieved bool)
+	RecordLoadControl(target, utilization, rate float64)
+	RecordDisruption(victim *container.Container)
+	RecordPinnedPlacement(pinned *container.Container, crossSocket bool)
+	RecordPinningFailure(pinned *container.Container)
+	RecordOrderingDelay(delay time.Duration)
+	RecordOrderingCancellation(container *container.Container)
+	RecordStateTransition(transition StateTransition)
+	RecordWarmup(d time.Duration, placements, failures int)
+	RecordReplicaSample(counts map[string]int)
+	RecordReplacement(containerType string)
+	RecordReplicaCapped(containerType string)
 	GetResults() *Results
 }
 
 type MetricsCollector struct {
 	events               []SchedulingEvent
+	migrations           []MigrationEvent
+	removals             []RemovalEvent
 	containersScheduled  int
+	scheduledAfterRetry  int
 	schedulingFailures   int
 	totalLatency         time.Duration
+	totalStartup         time.Duration
+	imageCacheHits       int
+	imageCacheMisses     int
+	totalTimeToReady     time.Duration
+	totalQueueDelay      time.Duration
 	resourceUtilization  float64
 	utilizationDatapoints int
+	nodeUtilization      NodeUtilizationStats
+	nodeUtilizationAverage NodeUtilizationStats
+	nodeUtilizationSamples int
+	totalEnergy          float64
+	totalCost            float64
+	packingEfficiency    float64
+	packingDatapoints    int
+	stranded             StrandedResources
+	strandedDatapoints   int
+	contention           NetworkContention
+	contentionDatapoints int
+	gpu                  GPUUtilization
+	typeStats            map[string]TypeStats
+	priorityStats        map[int]PriorityStats
+	qosStats             map[string]QoSStats
+	sourceStats          map[string]TypeStats
+	preemptions          int
+	disruptions          int
+	scheduledCPU         float64 // requested by placed containers, sidecars included
+	scheduledMemory      float64
+	sidecarCPU           float64
+	sidecarMemory        float64
+	pinnedPlacements     int
+	crossSocketPlacements int
+	pinningFailures      int
+	disruptionCost       float64
+	rejections           int
+	oomKills             int
+	reclaim              MemoryReclaim
+	loadTarget           LoadTarget
+	orderedAdmitted      int
+	totalOrderingDelay   time.Duration
+	orderingCancelled    int
+	rescheduledAfterReject int
+	nodeHealth           map[string]float64
+	startTime            time.Time
+	clock                clock.Clock // times events and series; the wall clock unless set
+	firstFailure         time.Duration
+	saturationTime       time.Duration
+	recentOutcomes       []bool
+	failuresSinceSample  int
+	unschedulableSeries  []UnschedulableSample
+	throughputSeries     []ThroughputSample
+	nodeCountSeries      []NodeCountSample
+	peakNodes            int
+	scaleUps             int
+	scaleDowns           int
+	stateTransitions     []StateTransition
+	warmup               time.Duration
+	warmupPlacements     int
+	warmupFailures       int
+	replicaSeries        []ReplicaSample
+	replicaStats         map[string]ReplicaStats
+	preemptionTotals     PreemptionTotals
+	stream               *csv.Writer // when set, events are written here instead of kept
+	maxEvents            int         // when positive, only the last maxEvents events are kept
+	oldestEvent          int         // ring buffer position of the oldest kept event
+	droppedEvents        int
+	latencySample        []float64
+	queueDelaySample     []float64 // queue delays of the placements in latencySample
+	rng                  *rand.Rand
+	streamErr            error
 }
 
 func NewCollector() *MetricsCollector {
 	return &MetricsCollector{
 		events:              make([]SchedulingEvent, 0),
+		migrations:          make([]MigrationEvent, 0),
+		removals:            make([]RemovalEvent, 0),
+		typeStats:           make(map[string]TypeStats),
+		priorityStats:       make(map[int]PriorityStats),
+		qosStats:            make(map[string]QoSStats),
+		sourceStats:         make(map[string]TypeStats),
+		nodeHealth:          make(map[string]float64),
+		startTime:           time.Now(),
+		clock:               clock.Real{},
+		unschedulableSeries: make([]UnschedulableSample, 0),
 		containersScheduled: 0,
 		schedulingFailures:  0,
 		totalLatency:        0,
@@ -53,6 +251,37 @@ func NewCollector() *MetricsCollector {
 	}
 }
 
+// SetClock has the collector time events and series by c, e.g. a run's
+// simulated clock, instead of the wall clock; offsets count from now on c
+func (c *MetricsCollector) SetClock(clk clock.Clock) {
+	c.clock = clk
+	c.startTime = clk.Now()
+}
+
+// NewStreamingCollector returns a collector that writes each scheduling event
+// to w as a CSV row the moment it is recorded. Events are not kept in memory,
+// so Results.Events stays empty; the summary counters are unaffected.
+func NewStreamingCollector(w io.Writer) *MetricsCollector {
+	c := NewCollector()
+	c.stream = csv.NewWriter(w)
+	c.writeRow(eventHeader)
+	return c
+}
+
+// StreamErr returns the first error hit while streaming events, if any
+func (c *MetricsCollector) StreamErr() error {
+	return c.streamErr
+}
+
+func (c *MetricsCollector) writeRow(record []string) {
+	if c.streamErr != nil {
+		return
+	}
+	c.stream.Write(record)
+	c.stream.Flush()
+	c.streamErr = c.stream.Error()
+}
+
 func (c *MetricsCollector) RecordSchedulingEvent(container *container.Container, node *node.Node, latency time.Duration, success bool) {
 	var nodeID string
 	var utilization float64
@@ -67,38 +296,310 @@ func (c *MetricsCollector) RecordSchedulingEvent(container *container.Container,
 	}
 	
 	event := SchedulingEvent{
-		Timestamp:           time.Now(),
+		Timestamp:           c.clock.Now(),
 		ContainerID:         container.ID(),
 		ContainerType:       container.Type(),
 		NodeID:              nodeID,
-		SchedulingLatency:   latency,
+		ComputeLatency:      latency,
 		ScheduleSuccess:     success,
 		ResourceUtilization: utilization,
 	}
+	if !success {
+		event.FailureReason = container.FailureReason()
+	} else if node != nil {
+		event.QueueDelay = c.clock.Now().Sub(container.QueuedAt())
+	}
+	if node != nil {
+		event.CPUUtilization = node.CPUUtilization()
+		event.MemoryUtilization = node.MemoryUtilization()
+		event.NetworkUtilization = node.NetworkUtilization()
+		event.IOUtilization = node.IOUtilization()
+		event.DiskUtilization = node.DiskUtilization()
+	}
 	
-	c.events = append(c.events, event)
+	if c.stream != nil {
+		c.writeRow(eventRecord(event))
+	} else {
+		c.retainEvent(event)
+	}
 	
+	stats := c.typeStats[container.Type()]
 	if success {
 		c.containersScheduled++
 		c.totalLatency += latency
+		c.totalQueueDelay += event.QueueDelay
+		// Time to ready is the wait until placement plus the cold start
+		c.totalStartup += container.StartupDuration()
+		c.totalTimeToReady += event.QueueDelay + container.StartupDuration()
+		if c.maxEvents > 0 {
+			c.sampleLatency(latency, event.QueueDelay)
+		}
+		stats.Scheduled++
+		c.trackSidecars(container)
+		if container.Attempts() > 1 {
+			c.scheduledAfterRetry++
+		}
 	} else {
 		c.schedulingFailures++
+		stats.Failures++
+	}
+	c.typeStats[container.Type()] = stats
+	if source := container.Source(); source != "" {
+		sourceStats := c.sourceStats[source]
+		if success {
+			sourceStats.Scheduled++
+		} else {
+			sourceStats.Failures++
+		}
+		c.sourceStats[source] = sourceStats
 	}
+	c.trackPriority(container, node, success && node != nil)
+	c.trackQoS(container, success && node != nil)
+	c.trackSaturation(success)
+}
+
+func (c *MetricsCollector) RecordMigration(container *container.Container, from, to *node.Node, clusterLoadVariance float64) {
+	c.migrations = append(c.migrations, MigrationEvent{
+		Timestamp:           c.clock.Now(),
+		ContainerID:         container.ID(),
+		FromNodeID:          from.ID(),
+		ToNodeID:            to.ID(),
+		ClusterLoadVariance: clusterLoadVariance,
+	})
+}
+
+// RecordRemovalEvent notes that a container left node at time t, closing
+// its entry in the timeline
+func (c *MetricsCollector) RecordRemovalEvent(containerID string, node *node.Node, t time.Time) {
+	c.removals = append(c.removals, RemovalEvent{
+		Timestamp:   t,
+		ContainerID: containerID,
+		NodeID:      node.ID(),
+	})
+}
+
+// RecordRejection counts a placement refused by the chosen node, noting
+// whether the container was then placed on another node
+func (c *MetricsCollector) RecordRejection(rescheduled bool) {
+	c.rejections++
+	if rescheduled {
+		c.rescheduledAfterReject++
+	}
+}
+
+// RecordImagePull counts whether a placement found its image already
+// cached on the node
+func (c *MetricsCollector) RecordImagePull(hit bool) {
+	if hit {
+		c.imageCacheHits++
+	} else {
+		c.imageCacheMisses++
+	}
+}
+
+// RecordNodeHealth keeps the most recent health score of each node
+func (c *MetricsCollector) RecordNodeHealth(nodeName string, score float64) {
+	c.nodeHealth[nodeName] = score
+}
+
+// RecordPowerSample integrates the cluster's power draw over the sampling interval
+func (c *MetricsCollector) RecordPowerSample(watts float64, interval time.Duration) {
+	c.totalEnergy += watts * interval.Seconds()
+}
+
+// RecordCostSample accrues the hourly price of occupied nodes over the sampling interval
+func (c *MetricsCollector) RecordCostSample(hourlyCost float64, interval time.Duration) {
+	c.totalCost += hourlyCost * interval.Hours()
+}
+
+// RecordPackingSample tracks the mean utilization of nodes that host at
+// least one container; denser packing keeps this high
+func (c *MetricsCollector) RecordPackingSample(occupiedUtilization float64) {
+	c.packingEfficiency = (c.packingEfficiency * float64(c.packingDatapoints) + occupiedUtilization) / float64(c.packingDatapoints + 1)
+	c.packingDatapoints++
+}
+
+// RecordStrandedSample tracks the fraction of cluster capacity that is free
+// but unusable by a median-sized container
+func (c *MetricsCollector) RecordStrandedSample(stranded StrandedResources) {
+	weight := float64(c.strandedDatapoints)
+	average := func(mean, sample float64) float64 {
+		return (mean * weight + sample) / (weight + 1)
+	}
+	
+	c.stranded = StrandedResources{
+		CPU:     average(c.stranded.CPU, stranded.CPU),
+		Memory:  average(c.stranded.Memory, stranded.Memory),
+		Network: average(c.stranded.Network, stranded.Network),
+		IO:      average(c.stranded.IO, stranded.IO),
+		Disk:    average(c.stranded.Disk, stranded.Disk),
+	}
+	c.strandedDatapoints++
 }
 
 func (c *MetricsCollector) GetResults() *Results {
-	var avgLatency float64
+	var avgLatency, avgStartup, avgTimeToReady, avgQueueDelay float64
 	if c.containersScheduled > 0 {
 		avgLatency = float64(c.totalLatency.Microseconds()) / float64(c.containersScheduled) / 1000.0 // Convert to ms
+		avgStartup = float64(c.totalStartup.Microseconds()) / float64(c.containersScheduled) / 1000.0
+		avgTimeToReady = float64(c.totalTimeToReady.Microseconds()) / float64(c.containersScheduled) / 1000.0
+		avgQueueDelay = float64(c.totalQueueDelay.Microseconds()) / float64(c.containersScheduled) / 1000.0
+	}
+	
+	typeStats := make(map[string]TypeStats, len(c.typeStats))
+	for containerType, stats := range c.typeStats {
+		typeStats[containerType] = stats
+	}
+	fairness, worstType := fairnessIndex(typeStats)
+	
+	priorityStats := make(map[int]PriorityStats, len(c.priorityStats))
+	for priority, stats := range c.priorityStats {
+		priorityStats[priority] = stats
+	}
+	
+	var latencySample, queueDelaySample []float64
+	if c.latencySample != nil {
+		latencySample = append([]float64(nil), c.latencySample...)
+		queueDelaySample = append([]float64(nil), c.queueDelaySample...)
+	}
+	
+	sourceStats := make(map[string]TypeStats, len(c.sourceStats))
+	for source, stats := range c.sourceStats {
+		sourceStats[source] = stats
+	}
+	
+	qosStats := make(map[string]QoSStats, len(c.qosStats))
+	for class, stats := range c.qosStats {
+		qosStats[class] = stats
+	}
+	
+	nodeHealth := make(map[string]float64, len(c.nodeHealth))
+	for name, score := range c.nodeHealth {
+		nodeHealth[name] = score
 	}
 	
 	return &Results{
 		ContainersScheduled:   c.containersScheduled,
+		ScheduledAfterRetry:   c.scheduledAfterRetry,
 		SchedulingFailures:    c.schedulingFailures,
 		AverageLatency:        avgLatency,
+		AverageStartupTime:    avgStartup,
+		ImageCacheHits:        c.imageCacheHits,
+		ImageCacheMisses:      c.imageCacheMisses,
+		AverageTimeToReady:    avgTimeToReady,
+		AverageQueueDelay:     avgQueueDelay,
 		ResourceUtilization:   c.resourceUtilization,
-		Events:                c.events,
+		NodeUtilization:       c.nodeUtilization,
+		NodeUtilizationAverage: c.nodeUtilizationAverage,
+		Events:                c.retainedEvents(),
+		DroppedEvents:         c.droppedEvents,
+		LatencySample:         latencySample,
+		QueueDelaySample:      queueDelaySample,
+		Migrations:            c.migrations,
+		Removals:              c.removals,
+		TotalEnergy:           c.totalEnergy,
+		TotalCost:             c.totalCost,
+		PackingEfficiency:     c.packingEfficiency,
+		Stranded:              c.stranded,
+		NetworkContention:     c.contention,
+		GPU:                   c.gpu,
+		TypeStats:             typeStats,
+		PriorityStats:         priorityStats,
+		QoSStats:              qosStats,
+		SourceStats:           sourceStats,
+		Preemptions:           c.preemptions,
+		SidecarOverhead:       c.sidecarOverhead(),
+		PinnedPlacements:      c.pinnedPlacements,
+		CrossSocketPlacements: c.crossSocketPlacements,
+		PinningFailures:       c.pinningFailures,
+		Disruptions:           c.disruptions,
+		DisruptionCost:        c.disruptionCost,
+		Rejections:            c.rejections,
+		OOMKills:              c.oomKills,
+		MemoryReclaim:         c.reclaim,
+		LoadTarget:            c.loadTarget,
+		OrderedContainers:     c.orderedAdmitted,
+		AverageOrderingDelay:  c.averageOrderingDelay(),
+		OrderingCancelled:     c.orderingCancelled,
+		RescheduledAfterReject: c.rescheduledAfterReject,
+		FairnessIndex:         fairness,
+		WorstServedType:       worstType,
+		NodeHealth:            nodeHealth,
+		TimeToFirstFailure:    c.firstFailure,
+		SaturationTime:        c.saturationTime,
+		UnschedulableSeries:   c.unschedulableSeries,
+		ThroughputSeries:      c.throughputSeries,
+		NodeCountSeries:       c.nodeCountSeries,
+		PeakNodes:             c.peakNodes,
+		ScaleUps:              c.scaleUps,
+		ScaleDowns:            c.scaleDowns,
+		StateTransitions:      c.stateTransitions,
+		Warmup:                c.warmup,
+		WarmupPlacements:      c.warmupPlacements,
+		WarmupFailures:        c.warmupFailures,
+		ReplicaSeries:         c.replicaSeries,
+		ReplicaStats:          c.replicaStats,
+		PreemptionPlans:       c.preemptionTotals,
+	}
+}
+
+// LatencyPercentile returns the p-th percentile (0-100) of the scheduling
+// latency of successful placements, in milliseconds. When events were
+// capped it is estimated from LatencySample.
+func (r *Results) LatencyPercentile(p float64) float64 {
+	var latencies []float64
+	if r.LatencySample != nil {
+		latencies = append(latencies, r.LatencySample...)
+	} else {
+		latencies = make([]float64, 0, len(r.Events))
+		for _, event := range r.Events {
+			if event.ScheduleSuccess {
+				latencies = append(latencies, float64(event.ComputeLatency.Microseconds())/1000.0)
+			}
+		}
+	}
+	if len(latencies) == 0 {
+		return 0
+	}
+	sort.Float64s(latencies)
+	
+	return nearestRank(latencies, p)
+}
+
+// QueueDelayPercentile returns the p-th percentile (0-100) of the queue
+// delay of successful placements, in milliseconds. When events were capped
+// it is estimated from QueueDelaySample.
+func (r *Results) QueueDelayPercentile(p float64) float64 {
+	var delays []float64
+	if r.QueueDelaySample != nil {
+		delays = append(delays, r.QueueDelaySample...)
+	} else {
+		delays = make([]float64, 0, len(r.Events))
+		for _, event := range r.Events {
+			if event.ScheduleSuccess {
+				delays = append(delays, float64(event.QueueDelay.Microseconds())/1000.0)
+			}
+		}
+	}
+	if len(delays) == 0 {
+		return 0
+	}
+	sort.Float64s(delays)
+	
+	return nearestRank(delays, p)
+}
+
+// nearestRank returns the p-th percentile (0-100) of sorted, which must not
+// be empty
+func nearestRank(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100.0 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
 	}
+	return sorted[rank-1]
 }
 
 func (r *Results) SaveToFile(filename string) error {
@@ -112,36 +613,65 @@ func (r *Results) SaveToFile(filename string) error {
 	defer writer.Flush()
 	
 	// Write header
-	header := []string{
-		"Timestamp",
-		"ContainerID",
-		"ContainerType",
-		"NodeID",
-		"SchedulingLatency(ms)",
-		"Success",
-		"ResourceUtilization",
-	}
-	
-	if err := writer.Write(header); err != nil {
+	if err := writer.Write(eventHeader); err != nil {
 		return err
 	}
 	
 	// Write events
 	for _, event := range r.Events {
-		record := []string{
-			event.Timestamp.Format(time.RFC3339),
-			event.ContainerID,
-			event.ContainerType,
-			event.NodeID,
-			strconv.FormatFloat(float64(event.SchedulingLatency.Microseconds())/1000.0, 'f', 3, 64),
-			strconv.FormatBool(event.ScheduleSuccess),
-			strconv.FormatFloat(event.ResourceUtilization, 'f', 3, 64),
-		}
-		
-		if err := writer.Write(record); err != nil {
+		if err := writer.Write(eventRecord(event)); err != nil {
 			return err
 		}
 	}
 	
 	return nil
 }
+
+var eventHeader = []string{
+	"Timestamp",
+	"ContainerID",
+	"ContainerType",
+	"NodeID",
+	"SchedulingLatency(ms)",
+	"Success",
+	"ResourceUtilization",
+	"CPUUtilization",
+	"MemoryUtilization",
+	"NetworkUtilization",
+	"IOUtilization",
+	"DiskUtilization",
+	"FailureReason",
+	"QueueDelay(ms)",
+}
+
+func eventRecord(event SchedulingEvent) []string {
+	record := []string{
+		event.Timestamp.Format(time.RFC3339),
+		event.ContainerID,
+		event.ContainerType,
+		event.NodeID,
+		strconv.FormatFloat(float64(event.ComputeLatency.Microseconds())/1000.0, 'f', 3, 64),
+		strconv.FormatBool(event.ScheduleSuccess),
+		strconv.FormatFloat(event.ResourceUtilization, 'f', 3, 64),
+	}
+	
+	// Failed placements have no target node, so the per-resource cells stay empty
+	perResource := []float64{
+		event.CPUUtilization,
+		event.MemoryUtilization,
+		event.NetworkUtilization,
+		event.IOUtilization,
+		event.DiskUtilization,
+	}
+	for _, utilization := range perResource {
+		if event.NodeID == "" {
+			record = append(record, "")
+		} else {
+			record = append(record, strconv.FormatFloat(utilization, 'f', 3, 64))
+		}
+	}
+	record = append(record, event.FailureReason)
+	record = append(record, strconv.FormatFloat(float64(event.QueueDelay.Microseconds())/1000.0, 'f', 3, 64))
+	
+	return record
+}
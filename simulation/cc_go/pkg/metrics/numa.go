@@ -0,0 +1,21 @@
+// pkg/metrics/numa.go - NUMA pinning outcomes
+package metrics
+
+import (
+	"cc_go/pkg/container"
+)
+
+// RecordPinnedPlacement counts a placed NUMA-pinned container; crossSocket
+// means no single socket could hold it and it runs split across sockets
+func (c *MetricsCollector) RecordPinnedPlacement(pinned *container.Container, crossSocket bool) {
+	c.pinnedPlacements++
+	if crossSocket {
+		c.crossSocketPlacements++
+	}
+}
+
+// RecordPinningFailure counts a failed attempt to place a NUMA-pinned
+// container that some node had the aggregate capacity for
+func (c *MetricsCollector) RecordPinningFailure(pinned *container.Container) {
+	c.pinningFailures++
+}
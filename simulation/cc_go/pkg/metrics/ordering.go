@@ -0,0 +1,27 @@
+// pkg/metrics/ordering.go - Ordered group rollout statistics
+package metrics
+
+import (
+	"cc_go/pkg/container"
+	"time"
+)
+
+// RecordOrderingDelay notes that a member of an ordered group became
+// schedulable after waiting delay for its predecessor to be placed
+func (c *MetricsCollector) RecordOrderingDelay(delay time.Duration) {
+	c.orderedAdmitted++
+	c.totalOrderingDelay += delay
+}
+
+// RecordOrderingCancellation notes that container was dropped because an
+// earlier member of its ordered group failed
+func (c *MetricsCollector) RecordOrderingCancellation(container *container.Container) {
+	c.orderingCancelled++
+}
+
+func (c *MetricsCollector) averageOrderingDelay() float64 {
+	if c.orderedAdmitted == 0 {
+		return 0
+	}
+	return float64(c.totalOrderingDelay.Microseconds()) / float64(c.orderedAdmitted) / 1000.0
+}
@@ -0,0 +1,28 @@
+// pkg/metrics/preemption.go - Preemption plan quality
+package metrics
+
+// PreemptionPlan summarises a set of evictions planned to make room for a
+// container; Found is false if no plan was found
+type PreemptionPlan struct {
+	Found    bool
+	Victims  int
+	Priority int     // summed priority of the victims
+	Cost     float64 // summed disruption cost of the victims
+}
+
+// PreemptionTotals sums the plans the benchmark carried out
+type PreemptionTotals struct {
+	Plans    int
+	Victims  int
+	Priority int     // summed priority of every victim
+	Cost     float64 // summed disruption cost of every victim
+}
+
+// RecordPreemptionPlan adds a carried out plan to the totals
+func (c *MetricsCollector) RecordPreemptionPlan(plan PreemptionPlan) {
+	totals := &c.preemptionTotals
+	totals.Plans++
+	totals.Victims += plan.Victims
+	totals.Priority += plan.Priority
+	totals.Cost += plan.Cost
+}
@@ -0,0 +1,64 @@
+// pkg/metrics/priority.go - Per-priority-class placement quality
+package metrics
+
+import (
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+)
+
+// PriorityStats describes how well one priority class was served
+type PriorityStats struct {
+	Scheduled   int
+	Failures    int
+	Preempted   int     // times a container of this class was evicted for a higher-priority one
+	OOMKilled   int     // times a container of this class was evicted by memory pressure
+	totalHealth float64 // summed health of the nodes chosen for successful placements
+}
+
+// AverageNodeHealth is the mean health score of the nodes this class was
+// placed on; higher means it landed on more reliable nodes
+func (s PriorityStats) AverageNodeHealth() float64 {
+	if s.Scheduled == 0 {
+		return 0
+	}
+	return s.totalHealth / float64(s.Scheduled)
+}
+
+func (s PriorityStats) SuccessRate() float64 {
+	total := s.Scheduled + s.Failures
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Scheduled) / float64(total)
+}
+
+func (c *MetricsCollector) trackPriority(container *container.Container, node *node.Node, success bool) {
+	stats := c.priorityStats[container.Priority()]
+	if success {
+		stats.Scheduled++
+		stats.totalHealth += node.HealthScore()
+	} else {
+		stats.Failures++
+	}
+	c.priorityStats[container.Priority()] = stats
+}
+
+// RecordPreemption notes that victim was evicted from node to make room for
+// a higher-priority container
+func (c *MetricsCollector) RecordPreemption(victim *container.Container, node *node.Node) {
+	stats := c.priorityStats[victim.Priority()]
+	stats.Preempted++
+	c.priorityStats[victim.Priority()] = stats
+	c.trackQoSEviction(victim, false)
+	c.preemptions++
+}
+
+// RecordOOMKill notes that victim was evicted from node because the node's
+// effective memory use exceeded its capacity
+func (c *MetricsCollector) RecordOOMKill(victim *container.Container, node *node.Node) {
+	stats := c.priorityStats[victim.Priority()]
+	stats.OOMKilled++
+	c.priorityStats[victim.Priority()] = stats
+	c.trackQoSEviction(victim, true)
+	c.oomKills++
+}
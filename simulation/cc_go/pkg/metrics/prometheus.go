@@ -0,0 +1,260 @@
+// pkg/metrics/prometheus.go - Prometheus text-format exporter
+package metrics
+
+import (
+	"cc_go/pkg/clock"
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// latencyBuckets are the upper bounds, in seconds, of the scheduling
+// latency histogram
+var latencyBuckets = []float64{0.00001, 0.00005, 0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1}
+
+// PrometheusExporter wraps a Collector, forwarding every recording to it
+// while keeping live counters that it serves in Prometheus text format.
+type PrometheusExporter struct {
+	collector Collector
+
+	mu                  sync.Mutex
+	containersScheduled int
+	schedulingFailures  int
+	bucketCounts        []int
+	latencySum          float64
+	latencyCount        int
+	inspectNodes        func(fn func(nodes []*node.Node))
+}
+
+func NewPrometheusExporter(collector Collector) *PrometheusExporter {
+	return &PrometheusExporter{
+		collector:    collector,
+		bucketCounts: make([]int, len(latencyBuckets)),
+	}
+}
+
+// SetNodeInspector provides the exporter with locked access to the cluster
+// so per-node utilization can be reported at scrape time.
+func (e *PrometheusExporter) SetNodeInspector(inspect func(fn func(nodes []*node.Node))) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.inspectNodes = inspect
+}
+
+// SetClock hands the run's clock on to the wrapped collector, if it keeps
+// time
+func (e *PrometheusExporter) SetClock(c clock.Clock) {
+	if clocked, ok := e.collector.(interface{ SetClock(clock.Clock) }); ok {
+		clocked.SetClock(c)
+	}
+}
+
+func (e *PrometheusExporter) RecordSchedulingEvent(container *container.Container, node *node.Node, latency time.Duration, success bool) {
+	e.collector.RecordSchedulingEvent(container, node, latency, success)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if success {
+		e.containersScheduled++
+	} else {
+		e.schedulingFailures++
+	}
+
+	seconds := latency.Seconds()
+	for i, bound := range latencyBuckets {
+		if seconds <= bound {
+			e.bucketCounts[i]++
+		}
+	}
+	e.latencySum += seconds
+	e.latencyCount++
+}
+
+func (e *PrometheusExporter) RecordMigration(container *container.Container, from, to *node.Node, clusterLoadVariance float64) {
+	e.collector.RecordMigration(container, from, to, clusterLoadVariance)
+}
+
+func (e *PrometheusExporter) RecordRemovalEvent(containerID string, node *node.Node, t time.Time) {
+	e.collector.RecordRemovalEvent(containerID, node, t)
+}
+
+func (e *PrometheusExporter) RecordPowerSample(watts float64, interval time.Duration) {
+	e.collector.RecordPowerSample(watts, interval)
+}
+
+func (e *PrometheusExporter) RecordUnschedulableSample(waiting int) {
+	e.collector.RecordUnschedulableSample(waiting)
+}
+
+func (e *PrometheusExporter) RecordNodeUtilizationSample(stats NodeUtilizationStats) {
+	e.collector.RecordNodeUtilizationSample(stats)
+}
+
+func (e *PrometheusExporter) RecordNodeCountSample(count int) {
+	e.collector.RecordNodeCountSample(count)
+}
+
+func (e *PrometheusExporter) RecordScaling(n *node.Node, up bool) {
+	e.collector.RecordScaling(n, up)
+}
+
+func (e *PrometheusExporter) RecordStrandedSample(stranded StrandedResources) {
+	e.collector.RecordStrandedSample(stranded)
+}
+
+func (e *PrometheusExporter) RecordThroughputSample(arrivals, placements, backlog int, interval time.Duration) {
+	e.collector.RecordThroughputSample(arrivals, placements, backlog, interval)
+}
+
+func (e *PrometheusExporter) RecordOrderingDelay(delay time.Duration) {
+	e.collector.RecordOrderingDelay(delay)
+}
+
+func (e *PrometheusExporter) RecordOrderingCancellation(container *container.Container) {
+	e.collector.RecordOrderingCancellation(container)
+}
+
+func (e *PrometheusExporter) RecordStateTransition(transition StateTransition) {
+	e.collector.RecordStateTransition(transition)
+}
+
+func (e *PrometheusExporter) RecordWarmup(d time.Duration, placements, failures int) {
+	e.collector.RecordWarmup(d, placements, failures)
+}
+
+func (e *PrometheusExporter) RecordPreemptionPlan(plan PreemptionPlan) {
+	e.collector.RecordPreemptionPlan(plan)
+}
+
+func (e *PrometheusExporter) RecordReplicaSample(counts map[string]int) {
+	e.collector.RecordReplicaSample(counts)
+}
+
+func (e *PrometheusExporter) RecordReplacement(containerType string) {
+	e.collector.RecordReplacement(containerType)
+}
+
+func (e *PrometheusExporter) RecordReplicaCapped(containerType string) {
+	e.collector.RecordReplicaCapped(containerType)
+}
+
+func (e *PrometheusExporter) RecordOOMKill(victim *container.Container, node *node.Node) {
+	e.collector.RecordOOMKill(victim, node)
+}
+
+func (e *PrometheusExporter) RecordMemoryReclaim(node *node.Node, mb float64, relieved bool) {
+	e.collector.RecordMemoryReclaim(node, mb, relieved)
+}
+
+func (e *PrometheusExporter) RecordLoadControl(target, utilization, rate float64) {
+	e.collector.RecordLoadControl(target, utilization, rate)
+}
+
+func (e *PrometheusExporter) RecordRejection(rescheduled bool) {
+	e.collector.RecordRejection(rescheduled)
+}
+
+func (e *PrometheusExporter) RecordPinnedPlacement(pinned *container.Container, crossSocket bool) {
+	e.collector.RecordPinnedPlacement(pinned, crossSocket)
+}
+
+func (e *PrometheusExporter) RecordPinningFailure(pinned *container.Container) {
+	e.collector.RecordPinningFailure(pinned)
+}
+
+func (e *PrometheusExporter) RecordDisruption(victim *container.Container) {
+	e.collector.RecordDisruption(victim)
+}
+
+func (e *PrometheusExporter) RecordPreemption(victim *container.Container, node *node.Node) {
+	e.collector.RecordPreemption(victim, node)
+}
+
+func (e *PrometheusExporter) RecordImagePull(hit bool) {
+	e.collector.RecordImagePull(hit)
+}
+
+func (e *PrometheusExporter) RecordNodeHealth(nodeName string, score float64) {
+	e.collector.RecordNodeHealth(nodeName, score)
+}
+
+func (e *PrometheusExporter) RecordCostSample(hourlyCost float64, interval time.Duration) {
+	e.collector.RecordCostSample(hourlyCost, interval)
+}
+
+func (e *PrometheusExporter) RecordPackingSample(occupiedUtilization float64) {
+	e.collector.RecordPackingSample(occupiedUtilization)
+}
+
+func (e *PrometheusExporter) RecordContentionSample(demand, lost, peak float64, contended int, interval time.Duration) {
+	e.collector.RecordContentionSample(demand, lost, peak, contended, interval)
+}
+
+func (e *PrometheusExporter) RecordGPUSample(memory, allocated float64) {
+	e.collector.RecordGPUSample(memory, allocated)
+}
+
+func (e *PrometheusExporter) GetResults() *Results {
+	return e.collector.GetResults()
+}
+
+func (e *PrometheusExporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprint(w, e.render())
+}
+
+func (e *PrometheusExporter) render() string {
+	// Read node state first: the inspector takes the benchmark lock, which
+	// is held while recording into e, so e.mu must not be held here
+	e.mu.Lock()
+	inspectNodes := e.inspectNodes
+	e.mu.Unlock()
+
+	nodeLines := make([]string, 0)
+	if inspectNodes != nil {
+		inspectNodes(func(nodes []*node.Node) {
+			for _, n := range nodes {
+				nodeLines = append(nodeLines, fmt.Sprintf("scheduler_node_utilization{node=%q} %g\n", n.Name(), n.Utilization()))
+			}
+		})
+		sort.Strings(nodeLines)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var sb strings.Builder
+
+	sb.WriteString("# HELP scheduler_containers_scheduled_total Containers successfully placed on a node.\n")
+	sb.WriteString("# TYPE scheduler_containers_scheduled_total counter\n")
+	fmt.Fprintf(&sb, "scheduler_containers_scheduled_total %d\n", e.containersScheduled)
+
+	sb.WriteString("# HELP scheduler_scheduling_failures_total Containers that could not be placed.\n")
+	sb.WriteString("# TYPE scheduler_scheduling_failures_total counter\n")
+	fmt.Fprintf(&sb, "scheduler_scheduling_failures_total %d\n", e.schedulingFailures)
+
+	sb.WriteString("# HELP scheduler_scheduling_latency_seconds Time spent deciding where to place a container.\n")
+	sb.WriteString("# TYPE scheduler_scheduling_latency_seconds histogram\n")
+	for i, bound := range latencyBuckets {
+		fmt.Fprintf(&sb, "scheduler_scheduling_latency_seconds_bucket{le=\"%g\"} %d\n", bound, e.bucketCounts[i])
+	}
+	fmt.Fprintf(&sb, "scheduler_scheduling_latency_seconds_bucket{le=\"+Inf\"} %d\n", e.latencyCount)
+	fmt.Fprintf(&sb, "scheduler_scheduling_latency_seconds_sum %g\n", e.latencySum)
+	fmt.Fprintf(&sb, "scheduler_scheduling_latency_seconds_count %d\n", e.latencyCount)
+
+	if inspectNodes != nil {
+		sb.WriteString("# HELP scheduler_node_utilization Average resource utilization of a node.\n")
+		sb.WriteString("# TYPE scheduler_node_utilization gauge\n")
+		for _, line := range nodeLines {
+			sb.WriteString(line)
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,39 @@
+// pkg/metrics/qos.go - Placement and eviction by QoS class
+package metrics
+
+import (
+	"cc_go/pkg/container"
+)
+
+// QoSStats counts what happened to the containers of one QoS class
+type QoSStats struct {
+	Scheduled int
+	Failures  int
+	Preempted int
+	OOMKilled int
+}
+
+// Evicted is how often containers of the class were preempted or OOM-killed
+func (s QoSStats) Evicted() int {
+	return s.Preempted + s.OOMKilled
+}
+
+func (c *MetricsCollector) trackQoS(container *container.Container, success bool) {
+	stats := c.qosStats[container.QoSClass()]
+	if success {
+		stats.Scheduled++
+	} else {
+		stats.Failures++
+	}
+	c.qosStats[container.QoSClass()] = stats
+}
+
+func (c *MetricsCollector) trackQoSEviction(victim *container.Container, oom bool) {
+	stats := c.qosStats[victim.QoSClass()]
+	if oom {
+		stats.OOMKilled++
+	} else {
+		stats.Preempted++
+	}
+	c.qosStats[victim.QoSClass()] = stats
+}
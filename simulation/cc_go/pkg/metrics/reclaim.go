@@ -0,0 +1,28 @@
+// pkg/metrics/reclaim.go - Memory reclaimed before OOM kills
+package metrics
+
+import (
+	"cc_go/pkg/node"
+)
+
+// MemoryReclaim summarizes the memory taken back from containers using
+// more than they requested on nodes under memory pressure
+type MemoryReclaim struct {
+	Events   int     // reclaims, at most one per node under pressure per check
+	Total    float64 // MB reclaimed
+	Largest  float64 // MB of the largest single reclaim
+	Relieved int     // reclaims that ended the pressure without an OOM kill
+}
+
+// RecordMemoryReclaim notes that mb of memory was reclaimed on node and
+// whether that was enough to spare it from OOM kills
+func (c *MetricsCollector) RecordMemoryReclaim(node *node.Node, mb float64, relieved bool) {
+	c.reclaim.Events++
+	c.reclaim.Total += mb
+	if mb > c.reclaim.Largest {
+		c.reclaim.Largest = mb
+	}
+	if relieved {
+		c.reclaim.Relieved++
+	}
+}
@@ -0,0 +1,104 @@
+// pkg/metrics/replicas.go - Instance counts for types with replica limits
+package metrics
+
+import (
+	"encoding/csv"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ReplicaSample is the number of live containers of each limited type at a
+// point in the run; waiting containers count as live
+type ReplicaSample struct {
+	Offset time.Duration // since the start of the run
+	Counts map[string]int
+}
+
+// ReplicaStats summarises how a type with replica limits fared
+type ReplicaStats struct {
+	Samples      int
+	Lowest       int // fewest live instances seen in a sample
+	Highest      int
+	Replacements int // containers generated to keep the type at its minimum
+	Capped       int // arrivals turned away at the maximum
+}
+
+// RecordReplicaSample adds a point to the replica series
+func (c *MetricsCollector) RecordReplicaSample(counts map[string]int) {
+	if c.replicaStats == nil {
+		c.replicaStats = make(map[string]ReplicaStats)
+	}
+	for containerType, count := range counts {
+		stats := c.replicaStats[containerType]
+		if stats.Samples == 0 || count < stats.Lowest {
+			stats.Lowest = count
+		}
+		if count > stats.Highest {
+			stats.Highest = count
+		}
+		stats.Samples++
+		c.replicaStats[containerType] = stats
+	}
+	c.replicaSeries = append(c.replicaSeries, ReplicaSample{
+		Offset: c.clock.Now().Sub(c.startTime),
+		Counts: counts,
+	})
+}
+
+// RecordReplacement counts a container generated to keep its type at the
+// minimum number of replicas
+func (c *MetricsCollector) RecordReplacement(containerType string) {
+	c.updateReplicaStats(containerType, func(stats *ReplicaStats) { stats.Replacements++ })
+}
+
+// RecordReplicaCapped counts an arrival turned away because its type was
+// already at the maximum number of replicas
+func (c *MetricsCollector) RecordReplicaCapped(containerType string) {
+	c.updateReplicaStats(containerType, func(stats *ReplicaStats) { stats.Capped++ })
+}
+
+func (c *MetricsCollector) updateReplicaStats(containerType string, update func(*ReplicaStats)) {
+	if c.replicaStats == nil {
+		c.replicaStats = make(map[string]ReplicaStats)
+	}
+	stats := c.replicaStats[containerType]
+	update(&stats)
+	c.replicaStats[containerType] = stats
+}
+
+// SaveReplicaCSV writes the replica series, one row per sample and one
+// column per limited type
+func (r *Results) SaveReplicaCSV(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+	
+	types := make([]string, 0, len(r.ReplicaStats))
+	for containerType := range r.ReplicaStats {
+		types = append(types, containerType)
+	}
+	sort.Strings(types)
+	
+	if err := writer.Write(append([]string{"Offset(s)"}, types...)); err != nil {
+		return err
+	}
+	
+	for _, sample := range r.ReplicaSeries {
+		record := []string{strconv.FormatFloat(sample.Offset.Seconds(), 'f', 3, 64)}
+		for _, containerType := range types {
+			record = append(record, strconv.Itoa(sample.Counts[containerType]))
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	
+	return writer.Error()
+}
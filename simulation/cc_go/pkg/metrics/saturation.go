@@ -0,0 +1,59 @@
+// pkg/metrics/saturation.go - Cluster saturation tracking
+package metrics
+
+import (
+	"time"
+)
+
+// saturationWindow is how many of the most recent scheduling outcomes are
+// looked at when deciding whether failures have become consistent
+const saturationWindow = 10
+
+// UnschedulableSample counts the containers that could not be placed at a
+// point in the run: those given up on since the previous sample plus those
+// still waiting for a retry
+type UnschedulableSample struct {
+	Offset time.Duration // since the start of the run
+	Count  int
+}
+
+// trackSaturation notes the first failure and the saturation point, which
+// is reached once at least half of the last saturationWindow scheduling
+// outcomes were failures
+func (c *MetricsCollector) trackSaturation(success bool) {
+	if !success {
+		c.failuresSinceSample++
+		if c.firstFailure == 0 {
+			c.firstFailure = c.clock.Now().Sub(c.startTime)
+		}
+	}
+	
+	c.recentOutcomes = append(c.recentOutcomes, success)
+	if len(c.recentOutcomes) > saturationWindow {
+		c.recentOutcomes = c.recentOutcomes[1:]
+	}
+	
+	if c.saturationTime != 0 || len(c.recentOutcomes) < saturationWindow {
+		return
+	}
+	
+	failures := 0
+	for _, ok := range c.recentOutcomes {
+		if !ok {
+			failures++
+		}
+	}
+	if failures*2 >= saturationWindow {
+		c.saturationTime = c.clock.Now().Sub(c.startTime)
+	}
+}
+
+// RecordUnschedulableSample adds a point to the unschedulable series;
+// waiting is the number of containers currently queued for a retry
+func (c *MetricsCollector) RecordUnschedulableSample(waiting int) {
+	c.unschedulableSeries = append(c.unschedulableSeries, UnschedulableSample{
+		Offset: c.clock.Now().Sub(c.startTime),
+		Count:  waiting + c.failuresSinceSample,
+	})
+	c.failuresSinceSample = 0
+}
@@ -0,0 +1,31 @@
+// pkg/metrics/sidecar.go - Sidecar resource overhead
+package metrics
+
+import (
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+)
+
+// SidecarOverhead is the share of the CPU and memory requested by
+// scheduled containers that went to their sidecars
+type SidecarOverhead struct {
+	CPU    float64
+	Memory float64
+}
+
+// trackSidecars adds a placed container's requests, sidecars included, to
+// the scheduled totals
+func (c *MetricsCollector) trackSidecars(placed *container.Container) {
+	cpu, memory := placed.SidecarOverhead()
+	c.scheduledCPU += placed.CPURequest()
+	c.scheduledMemory += placed.MemoryRequest()
+	c.sidecarCPU += cpu
+	c.sidecarMemory += memory
+}
+
+func (c *MetricsCollector) sidecarOverhead() SidecarOverhead {
+	return SidecarOverhead{
+		CPU:    node.Ratio(c.sidecarCPU, c.scheduledCPU),
+		Memory: node.Ratio(c.sidecarMemory, c.scheduledMemory),
+	}
+}
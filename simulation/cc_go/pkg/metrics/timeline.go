@@ -0,0 +1,66 @@
+// pkg/metrics/timeline.go - Container lifecycle timeline
+package metrics
+
+import (
+	"encoding/csv"
+	"os"
+	"strconv"
+	"time"
+)
+
+// RemovalEvent marks a container leaving the cluster
+type RemovalEvent struct {
+	Timestamp   time.Time
+	ContainerID string
+	NodeID      string
+}
+
+// SaveTimelineCSV writes one row per placed container with the node it was
+// placed on and when it started and ended, for Gantt-style charts of
+// cluster occupancy. Containers still running at the end of the run have
+// empty End and Duration cells.
+func (r *Results) SaveTimelineCSV(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+	
+	header := []string{"ContainerID", "ContainerType", "NodeID", "Start", "End", "Duration(s)"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	
+	removals := make(map[string]RemovalEvent, len(r.Removals))
+	for _, removal := range r.Removals {
+		removals[removal.ContainerID] = removal
+	}
+	
+	for _, event := range r.Events {
+		if !event.ScheduleSuccess {
+			continue
+		}
+		
+		record := []string{
+			event.ContainerID,
+			event.ContainerType,
+			event.NodeID,
+			event.Timestamp.Format(time.RFC3339Nano),
+			"",
+			"",
+		}
+		if removal, ok := removals[event.ContainerID]; ok {
+			record[4] = removal.Timestamp.Format(time.RFC3339Nano)
+			record[5] = strconv.FormatFloat(removal.Timestamp.Sub(event.Timestamp).Seconds(), 'f', 3, 64)
+		}
+		
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	
+	return nil
+}
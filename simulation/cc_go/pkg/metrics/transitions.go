@@ -0,0 +1,57 @@
+// pkg/metrics/transitions.go - Scheduler state transitions
+package metrics
+
+import (
+	"encoding/csv"
+	"os"
+	"strconv"
+	"time"
+)
+
+// StateTransition is a change of a scheduler's internal state, such as the
+// adaptive scheduler entering a new phase
+type StateTransition struct {
+	Offset    time.Duration // since the start of the run
+	Scheduler string
+	Kind      string // what changed, e.g. "phase" or "weights"
+	From      string // empty for the initial state
+	To        string
+	Detail    string
+}
+
+// RecordStateTransition stamps the transition with the run offset and keeps it
+func (c *MetricsCollector) RecordStateTransition(transition StateTransition) {
+	transition.Offset = c.clock.Now().Sub(c.startTime)
+	c.stateTransitions = append(c.stateTransitions, transition)
+}
+
+func (r *Results) SaveStateTransitionsCSV(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+	
+	if err := writer.Write([]string{"Offset(s)", "Scheduler", "Kind", "From", "To", "Detail"}); err != nil {
+		return err
+	}
+	
+	for _, t := range r.StateTransitions {
+		record := []string{
+			strconv.FormatFloat(t.Offset.Seconds(), 'f', 3, 64),
+			t.Scheduler,
+			t.Kind,
+			t.From,
+			t.To,
+			t.Detail,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	
+	return writer.Error()
+}
@@ -0,0 +1,90 @@
+// pkg/metrics/utilization.go - Distribution of utilization across nodes
+package metrics
+
+import (
+	"cc_go/pkg/node"
+	"sort"
+)
+
+// Distribution summarizes one utilization figure across the nodes
+type Distribution struct {
+	Min    float64
+	Median float64
+	P90    float64
+	Max    float64
+}
+
+// NodeUtilizationStats is the spread of utilization across the cluster,
+// per resource and for the average of the four resources. Unlike the mean
+// utilization it tells an evenly loaded cluster from one with some nodes
+// full and the rest empty.
+type NodeUtilizationStats struct {
+	CPU     Distribution
+	Memory  Distribution
+	Network Distribution
+	IO      Distribution
+	Disk    Distribution // over nodes with a disk capacity only
+	Overall Distribution
+}
+
+// ComputeNodeUtilizationStats measures how utilization is distributed over
+// nodes right now
+func ComputeNodeUtilizationStats(nodes []*node.Node) NodeUtilizationStats {
+	var cpu, memory, network, io, disk, overall []float64
+	for _, n := range nodes {
+		cpu = append(cpu, n.CPUUtilization())
+		memory = append(memory, n.MemoryUtilization())
+		network = append(network, n.NetworkUtilization())
+		io = append(io, n.IOUtilization())
+		if n.TotalDisk() > 0 {
+			disk = append(disk, n.DiskUtilization())
+		}
+		overall = append(overall, n.Utilization())
+	}
+	
+	return NodeUtilizationStats{
+		CPU:     distributionOf(cpu),
+		Memory:  distributionOf(memory),
+		Network: distributionOf(network),
+		IO:      distributionOf(io),
+		Disk:    distributionOf(disk),
+		Overall: distributionOf(overall),
+	}
+}
+
+func distributionOf(values []float64) Distribution {
+	if len(values) == 0 {
+		return Distribution{}
+	}
+	sort.Float64s(values)
+	
+	return Distribution{
+		Min:    values[0],
+		Median: nearestRank(values, 50),
+		P90:    nearestRank(values, 90),
+		Max:    values[len(values)-1],
+	}
+}
+
+// RecordNodeUtilizationSample keeps the latest utilization distribution,
+// which ends up as the end-of-run figure, and its running time average
+func (c *MetricsCollector) RecordNodeUtilizationSample(stats NodeUtilizationStats) {
+	c.nodeUtilization = stats
+	c.nodeUtilizationSamples++
+	
+	weight := 1 / float64(c.nodeUtilizationSamples)
+	average := &c.nodeUtilizationAverage
+	for _, pair := range []struct{ sum *Distribution; sample Distribution }{
+		{&average.CPU, stats.CPU},
+		{&average.Memory, stats.Memory},
+		{&average.Network, stats.Network},
+		{&average.IO, stats.IO},
+		{&average.Disk, stats.Disk},
+		{&average.Overall, stats.Overall},
+	} {
+		pair.sum.Min += (pair.sample.Min - pair.sum.Min) * weight
+		pair.sum.Median += (pair.sample.Median - pair.sum.Median) * weight
+		pair.sum.P90 += (pair.sample.P90 - pair.sum.P90) * weight
+		pair.sum.Max += (pair.sample.Max - pair.sum.Max) * weight
+	}
+}
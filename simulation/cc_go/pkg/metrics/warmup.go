@@ -0,0 +1,14 @@
+// pkg/metrics/warmup.go - Warmup accounting
+package metrics
+
+import (
+	"time"
+)
+
+// RecordWarmup notes that the first d of the run was excluded from the
+// results, along with the placements and failures made during it
+func (c *MetricsCollector) RecordWarmup(d time.Duration, placements, failures int) {
+	c.warmup = d
+	c.warmupPlacements = placements
+	c.warmupFailures = failures
+}
@@ -0,0 +1,65 @@
+// pkg/node/clone.go - Copies of nodes for what-if scheduling
+package node
+
+import (
+	"cc_go/pkg/container"
+)
+
+// Clone returns an independent copy of the node for trying out placements:
+// adding or removing containers on the copy, or cordoning it, leaves the
+// original untouched. The clone keeps the node's ID and name and belongs
+// to no Pool.
+//
+// The container objects themselves are shared by reference, not copied.
+func (n *Node) Clone() *Node {
+	clone := *n
+	clone.onChange = nil
+	clone.whatIf = true
+	
+	clone.containers = make([]*container.Container, len(n.containers))
+	copy(clone.containers, n.containers)
+	clone.containerIndex = make(map[string]int, len(n.containerIndex))
+	for id, i := range n.containerIndex {
+		clone.containerIndex[id] = i
+	}
+	clone.loadHistory = append([]float64(nil), n.loadHistory...)
+	if n.images != nil {
+		clone.images = make(map[string]bool, len(n.images))
+		for image, cached := range n.images {
+			clone.images[image] = cached
+		}
+	}
+	
+	if n.numa != nil {
+		sockets := *n.numa
+		sockets.cpu = append([]milli(nil), n.numa.cpu...)
+		sockets.memory = append([]milli(nil), n.numa.memory...)
+		sockets.pinned = make(map[string]int, len(n.numa.pinned))
+		for id, socket := range n.numa.pinned {
+			sockets.pinned[id] = socket
+		}
+		clone.numa = &sockets
+	}
+	if n.gpus != nil {
+		gpus := *n.gpus
+		gpus.used = append([]milli(nil), n.gpus.used...)
+		gpus.assigned = make(map[string]int, len(n.gpus.assigned))
+		for id, gpu := range n.gpus.assigned {
+			gpus.assigned[id] = gpu
+		}
+		clone.gpus = &gpus
+	}
+	
+	return &clone
+}
+
+// CloneCluster clones every node, in order, so a scheduler can simulate a
+// sequence of placements across the cluster, score the result and discard
+// it without touching the live nodes
+func CloneCluster(nodes []*Node) []*Node {
+	clones := make([]*Node, len(nodes))
+	for i, n := range nodes {
+		clones[i] = n.Clone()
+	}
+	return clones
+}
@@ -0,0 +1,39 @@
+// pkg/node/clone_test.go - What-if copies of nodes
+package node
+
+import (
+	"testing"
+
+	"cc_go/pkg/container"
+)
+
+func TestCloneLeavesNodeAndContainerUntouched(t *testing.T) {
+	n := NewNode("n", 8, 8192, 1000, 1000)
+	resident := container.NewContainer("resident", "img", 2, 1024, 10, 10, "web", 0)
+	if !n.AddContainer(resident) {
+		t.Fatal("resident container does not fit an empty node")
+	}
+	
+	clone := n.Clone()
+	candidate := container.NewContainer("candidate", "img", 4, 2048, 10, 10, "web", 0)
+	if !clone.AddContainer(candidate) {
+		t.Fatal("candidate does not fit the clone")
+	}
+	if !candidate.PlacedAt().IsZero() {
+		t.Errorf("placing on a clone marked the container placed at %v", candidate.PlacedAt())
+	}
+	clone.RemoveContainer(resident.ID())
+	
+	if got := len(n.Containers()); got != 1 {
+		t.Errorf("original holds %d containers after changes to its clone, want 1", got)
+	}
+	if n.CPUUtilization() != 0.25 {
+		t.Errorf("original CPU utilization %g after changes to its clone, want 0.25", n.CPUUtilization())
+	}
+	if clone.CPUUtilization() != 0.5 {
+		t.Errorf("clone CPU utilization %g, want 0.5", clone.CPUUtilization())
+	}
+	if err := n.CheckAccounting(); err != nil {
+		t.Error(err)
+	}
+}
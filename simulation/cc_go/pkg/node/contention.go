@@ -0,0 +1,55 @@
+// pkg/node/contention.go - Contention for the node's physical NIC
+package node
+
+import (
+	"cc_go/pkg/container"
+)
+
+// SetNICBandwidth sets the Mbps the node's NIC can actually carry. Network
+// capacity is handed out to containers as an additive allocation; a NIC
+// slower than that capacity over-subscribes it, and containers whose
+// combined traffic exceeds the NIC get less throughput than they asked
+// for. Zero means the NIC matches the network capacity.
+func (n *Node) SetNICBandwidth(mbps float64) {
+	if mbps < 0 {
+		mbps = 0
+	}
+	n.nicBandwidth = mbps
+}
+
+// NICBandwidth is the Mbps the NIC carries; zero if the network is
+// unconstrained
+func (n *Node) NICBandwidth() float64 {
+	if n.nicBandwidth > 0 {
+		return n.nicBandwidth
+	}
+	return n.totalNetwork
+}
+
+// NetworkDemand is the traffic the node's containers and reservation try
+// to push through the NIC right now
+func (n *Node) NetworkDemand() float64 {
+	_, _, network, _ := n.EffectiveUsage()
+	return network + n.reservedNetwork.float()
+}
+
+// NetworkContention is the fraction of the demanded throughput lost to an
+// over-subscribed NIC: 0 while demand fits, 0.5 when twice as much traffic
+// is offered as the NIC carries
+func (n *Node) NetworkContention() float64 {
+	return contention(n.NetworkDemand(), n.NICBandwidth())
+}
+
+// NetworkContentionWith is the contention the node would see with c placed
+// on it, c's traffic taken at its request
+func (n *Node) NetworkContentionWith(c *container.Container) float64 {
+	_, _, network, _ := c.EffectiveUsage(n.clock.Now())
+	return contention(n.NetworkDemand()+network, n.NICBandwidth())
+}
+
+func contention(demand, nic float64) float64 {
+	if nic <= 0 || demand <= nic {
+		return 0
+	}
+	return 1 - nic/demand
+}
@@ -0,0 +1,177 @@
+// pkg/node/gpu.go - GPU memory partitioning
+package node
+
+import (
+	"cc_go/pkg/container"
+)
+
+// gpus tracks the memory of each of a node's GPUs. A container's GPU
+// memory request is carved out of a single GPU, MIG style, so small
+// containers share a GPU until its memory is used up.
+type gpus struct {
+	memory   float64        // GB on each GPU
+	used     []milli        // GB allocated on each GPU
+	assigned map[string]int // container ID to its GPU
+}
+
+// SetGPUs gives the node count GPUs of memory GB each; a count of 0 leaves
+// it without GPUs. It must be called while the node is empty.
+func (n *Node) SetGPUs(count int, memory float64) {
+	if count <= 0 || memory <= 0 {
+		n.gpus = nil
+		return
+	}
+	n.gpus = &gpus{
+		memory:   memory,
+		used:     make([]milli, count),
+		assigned: make(map[string]int),
+	}
+}
+
+func (n *Node) GPUs() int {
+	if n.gpus == nil {
+		return 0
+	}
+	return len(n.gpus.used)
+}
+
+// GPUMemory is the memory of each GPU in GB, 0 without GPUs
+func (n *Node) GPUMemory() float64 {
+	if n.gpus == nil {
+		return 0
+	}
+	return n.gpus.memory
+}
+
+// TotalGPUMemory is the memory of all the node's GPUs together
+func (n *Node) TotalGPUMemory() float64 {
+	return float64(n.GPUs()) * n.GPUMemory()
+}
+
+func (n *Node) UsedGPUMemory() float64 {
+	if n.gpus == nil {
+		return 0
+	}
+	used := 0.0
+	for _, gb := range n.gpus.used {
+		used += gb.float()
+	}
+	return used
+}
+
+// MaxFreeGPUMemory is the most GPU memory free on any one GPU, the largest
+// request the node can still take
+func (n *Node) MaxFreeGPUMemory() float64 {
+	if n.gpus == nil {
+		return 0
+	}
+	free := 0.0
+	for _, used := range n.gpus.used {
+		if gb := n.gpus.memory - used.float(); gb > free {
+			free = gb
+		}
+	}
+	return free
+}
+
+// GPUsInUse counts the GPUs with any memory allocated
+func (n *Node) GPUsInUse() int {
+	if n.gpus == nil {
+		return 0
+	}
+	inUse := 0
+	for _, used := range n.gpus.used {
+		if used > 0 {
+			inUse++
+		}
+	}
+	return inUse
+}
+
+// GPUMemoryUtilization is the share of the node's GPU memory allocated
+func (n *Node) GPUMemoryUtilization() float64 {
+	return Ratio(n.UsedGPUMemory(), n.TotalGPUMemory())
+}
+
+// GPUUtilization is the share of the node's GPUs with any memory
+// allocated, however little
+func (n *Node) GPUUtilization() float64 {
+	return Ratio(float64(n.GPUsInUse()), float64(n.GPUs()))
+}
+
+// fitsGPU reports whether one GPU has room for c's GPU memory request.
+// Containers without one fit anywhere, and no node without GPUs takes a
+// container with one.
+func (n *Node) fitsGPU(c *container.Container) bool {
+	return c.GPUMemoryRequest() <= 0 || n.bestGPU(c) >= 0
+}
+
+// FitsGPUWithout reports whether one GPU would have room for c's GPU
+// memory request once victims, containers on the node, are gone
+func (n *Node) FitsGPUWithout(c *container.Container, victims []*container.Container) bool {
+	if c.GPUMemoryRequest() <= 0 {
+		return true
+	}
+	if n.gpus == nil {
+		return false
+	}
+	used := append([]milli(nil), n.gpus.used...)
+	for _, victim := range victims {
+		if gpu, ok := n.gpus.assigned[victim.ID()]; ok {
+			used[gpu] -= toMilli(victim.GPUMemoryRequest())
+		}
+	}
+	for _, gb := range used {
+		if n.gpus.memory-gb.float() >= c.GPUMemoryRequest() {
+			return true
+		}
+	}
+	return false
+}
+
+// bestGPU returns the fitting GPU left with the least free memory after
+// placing c, so small requests fill shared GPUs first, or -1 if none fits
+func (n *Node) bestGPU(c *container.Container) int {
+	if n.gpus == nil {
+		return -1
+	}
+	best := -1
+	bestLeftover := 0.0
+	for i, used := range n.gpus.used {
+		leftover := n.gpus.memory - used.float() - c.GPUMemoryRequest()
+		if leftover < 0 {
+			continue
+		}
+		if best < 0 || leftover < bestLeftover {
+			best = i
+			bestLeftover = leftover
+		}
+	}
+	return best
+}
+
+// assignGPU allocates GPU memory to a container that was just added
+func (n *Node) assignGPU(c *container.Container) {
+	if n.gpus == nil || c.GPUMemoryRequest() <= 0 {
+		return
+	}
+	gpu := n.bestGPU(c)
+	if gpu < 0 {
+		return
+	}
+	n.gpus.assigned[c.ID()] = gpu
+	n.gpus.used[gpu] += toMilli(c.GPUMemoryRequest())
+}
+
+// releaseGPU frees the GPU memory of a container that was just removed
+func (n *Node) releaseGPU(c *container.Container) {
+	if n.gpus == nil {
+		return
+	}
+	gpu, ok := n.gpus.assigned[c.ID()]
+	if !ok {
+		return
+	}
+	delete(n.gpus.assigned, c.ID())
+	n.gpus.used[gpu] -= toMilli(c.GPUMemoryRequest())
+}
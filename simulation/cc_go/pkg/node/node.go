@@ -2,12 +2,24 @@
 package node
 
 import (
+	"cc_go/pkg/clock"
 	"cc_go/pkg/container"
+	"cc_go/pkg/logging"
 	"fmt"
 	"math"
+	"sort"
+	"sync/atomic"
 	"time"
 )
 
+// lastID is the number in the most recently assigned node ID; like
+// container IDs, a run numbers its nodes itself, see SetID
+var lastID uint64
+
+// defaultImagePullRate is the image pull speed, in MB/s, of nodes that do
+// not set their own
+const defaultImagePullRate = 100.0
+
 type Node struct {
 	id              string
 	name            string
@@ -15,19 +27,51 @@ type Node struct {
 	totalMemory     float64
 	totalNetwork    float64
 	totalIO         float64
-	usedCPU         float64
-	usedMemory      float64
-	usedNetwork     float64
-	usedIO          float64
+	nicBandwidth    float64 // Mbps the NIC carries; 0 for totalNetwork
+	usedCPU         milli
+	usedMemory      milli
+	usedNetwork     milli
+	usedIO          milli
+	totalDisk       float64 // storage capacity in GB, distinct from IOPS
+	usedDisk        milli
+	reservedCPU     milli // held for non-container processes, see Reserve
+	reservedMemory  milli
+	reservedNetwork milli
+	reservedIO      milli
 	containers      []*container.Container
+	containerIndex  map[string]int // container ID to position in containers
 	creationTime    time.Time
+	clock           clock.Clock // the run's clock; the wall clock unless set
 	loadHistory     []float64
+	smoothedLoad    float64 // exponential moving average of the recorded loads
+	loadSmoothing   float64 // weight of the newest load in smoothedLoad
 	healthScore     float64
+	idleWatts       float64
+	wattsPerUtil    float64
+	hourlyCost      float64
+	cordoned        bool
+	zone            string // failure domain (rack or zone); empty if unknown
+	images          map[string]bool // images cached on the node
+	imagePullRate   float64         // MB/s
+	maxContainers   int // 0 means no limit on the container count
+	reserveFraction float64 // share of each resource CanFit keeps free as headroom
+	numa            *numa // socket tracking; nil for a single socket
+	gpus            *gpus // GPU memory tracking; nil without GPUs
+	onChange        func(n *Node) // set by the Pool holding this node
+	poolKey         float64       // free CPU the Pool holding this node last sorted it by
+	whatIf          bool          // a clone: placements leave the containers themselves untouched
 }
 
+// NewNode creates an empty node. A total of zero marks that resource as not
+// constrained on this node; negative totals are invalid and clamped to zero.
 func NewNode(name string, cpu, memory, network, io float64) *Node {
+	cpu = validTotal(name, "cpu", cpu)
+	memory = validTotal(name, "memory", memory)
+	network = validTotal(name, "network", network)
+	io = validTotal(name, "io", io)
+	
 	return &Node{
-		id:           fmt.Sprintf("node-%d", time.Now().UnixNano()),
+		id:           fmt.Sprintf("node-%d", atomic.AddUint64(&lastID, 1)),
 		name:         name,
 		totalCPU:     cpu,
 		totalMemory:  memory,
@@ -38,20 +82,60 @@ func NewNode(name string, cpu, memory, network, io float64) *Node {
 		usedNetwork:  0,
 		usedIO:       0,
 		containers:   make([]*container.Container, 0),
+		containerIndex: make(map[string]int),
 		creationTime: time.Now(),
+		clock:        clock.Real{},
 		loadHistory:  make([]float64, 0),
+		loadSmoothing: DefaultLoadSmoothing,
 		healthScore:  1.0,
+		images:       make(map[string]bool),
+		imagePullRate: defaultImagePullRate,
+	}
+}
+
+func validTotal(name, resource string, total float64) float64 {
+	if total < 0 {
+		logging.Warnf("Node %s has negative %s capacity %.2f, treating it as unconstrained", name, resource, total)
+		return 0
+	}
+	return total
+}
+
+// Ratio returns value/total, treating a zero total as an unconstrained
+// resource that contributes nothing rather than dividing by zero.
+func Ratio(value, total float64) float64 {
+	if total <= 0 {
+		return 0.0
 	}
+	return value / total
 }
 
 func (n *Node) ID() string {
 	return n.id
 }
 
+// SetID replaces the node's ID; a run numbers the nodes of its cluster in
+// order, so seeded runs assign the same IDs
+func (n *Node) SetID(id string) {
+	n.id = id
+}
+
+// SetClock has the node tell time, e.g. for placements and uptime, by c
+// instead of the wall clock; its uptime counts from now on c
+func (n *Node) SetClock(c clock.Clock) {
+	n.clock = c
+	n.creationTime = c.Now()
+}
+
 func (n *Node) Name() string {
 	return n.name
 }
 
+// HasName reports whether name is the node's name or ID
+func (n *Node) HasName(name string) bool {
+	return name == n.name || name == n.id
+}
+
 func (n *Node) TotalCPU() float64 {
 	return n.totalCPU
 }
@@ -68,36 +152,171 @@ func (n *Node) TotalIO() float64 {
 	return n.totalIO
 }
 
+func (n *Node) TotalDisk() float64 {
+	return n.totalDisk
+}
+
 func (n *Node) AvailableCPU() float64 {
-	return n.totalCPU - n.usedCPU
+	return n.totalCPU - (n.usedCPU + n.reservedCPU).float()
 }
 
 func (n *Node) AvailableMemory() float64 {
-	return n.totalMemory - n.usedMemory
+	return n.totalMemory - (n.usedMemory + n.reservedMemory).float()
 }
 
 func (n *Node) AvailableNetwork() float64 {
-	return n.totalNetwork - n.usedNetwork
+	return n.totalNetwork - (n.usedNetwork + n.reservedNetwork).float()
 }
 
 func (n *Node) AvailableIO() float64 {
-	return n.totalIO - n.usedIO
+	return n.totalIO - (n.usedIO + n.reservedIO).float()
+}
+
+func (n *Node) AvailableDisk() float64 {
+	return n.totalDisk - n.usedDisk.float()
 }
 
+// SetDiskCapacity gives the node gb of storage. Unlike IOPS, disk cannot be
+// overcommitted, so containers that need more than is free never fit.
+func (n *Node) SetDiskCapacity(gb float64) {
+	n.totalDisk = validTotal(n.name, "disk", gb)
+}
+
+func (n *Node) CPUUtilization() float64 {
+	return Ratio((n.usedCPU + n.reservedCPU).float(), n.totalCPU)
+}
+
+func (n *Node) MemoryUtilization() float64 {
+	return Ratio((n.usedMemory + n.reservedMemory).float(), n.totalMemory)
+}
+
+func (n *Node) NetworkUtilization() float64 {
+	return Ratio((n.usedNetwork + n.reservedNetwork).float(), n.totalNetwork)
+}
+
+func (n *Node) IOUtilization() float64 {
+	return Ratio((n.usedIO + n.reservedIO).float(), n.totalIO)
+}
+
+func (n *Node) DiskUtilization() float64 {
+	return Ratio(n.usedDisk.float(), n.totalDisk)
+}
+
+// Utilization is the equally weighted average of the four resource
+// utilizations
 func (n *Node) Utilization() float64 {
-	cpuUtil := n.usedCPU / n.totalCPU
-	memUtil := n.usedMemory / n.totalMemory
-	netUtil := n.usedNetwork / n.totalNetwork
-	ioUtil := n.usedIO / n.totalIO
+	cpuUtil := n.CPUUtilization()
+	memUtil := n.MemoryUtilization()
+	netUtil := n.NetworkUtilization()
+	ioUtil := n.IOUtilization()
 	
 	return (cpuUtil + memUtil + netUtil + ioUtil) / 4.0
 }
 
+// EffectiveUtilization is like Utilization but uses what the containers
+// actually consume right now (see Container.EffectiveUsage) rather than
+// what they requested, so it can exceed 1 when the node is over-committed.
+// Reservations count at their reserved amount.
+func (n *Node) EffectiveUtilization() float64 {
+	cpu, memory, network, io := n.EffectiveUsage()
+	cpu += n.reservedCPU.float()
+	memory += n.reservedMemory.float()
+	network += n.reservedNetwork.float()
+	io += n.reservedIO.float()
+	return (Ratio(cpu, n.totalCPU) + Ratio(memory, n.totalMemory) +
+		Ratio(network, n.totalNetwork) + Ratio(io, n.totalIO)) / 4.0
+}
+
+// EffectiveUsage sums what the node's containers actually consume right now
+func (n *Node) EffectiveUsage() (cpu, memory, network, io float64) {
+	now := n.clock.Now()
+	for _, c := range n.containers {
+		cpuUsage, memoryUsage, networkUsage, ioUsage := c.EffectiveUsage(now)
+		cpu += cpuUsage
+		memory += memoryUsage
+		network += networkUsage
+		io += ioUsage
+	}
+	return cpu, memory, network, io
+}
+
+// DominantUtilization is the utilization of the node's bottleneck resource,
+// i.e. the highest of the resource utilizations including disk
+func (n *Node) DominantUtilization() float64 {
+	return math.Max(
+		math.Max(math.Max(n.CPUUtilization(), n.MemoryUtilization()),
+			math.Max(n.NetworkUtilization(), n.IOUtilization())),
+		n.DiskUtilization(),
+	)
+}
+
+// Cordon stops new containers from being placed on the node; containers
+// already running there are left alone
+func (n *Node) Cordon() {
+	n.cordoned = true
+}
+
+func (n *Node) Uncordon() {
+	n.cordoned = false
+}
+
+func (n *Node) IsCordoned() bool {
+	return n.cordoned
+}
+
+// SetMaxContainers caps how many containers the node hosts regardless of
+// free resources, like a Kubernetes pod limit; 0 removes the cap
+func (n *Node) SetMaxContainers(max int) {
+	n.maxContainers = max
+}
+
+func (n *Node) MaxContainers() int {
+	return n.maxContainers
+}
+
+// SetReserveFraction keeps fraction of every resource free, so CanFit treats
+// total * (1 - fraction) as the usable capacity. Values outside [0, 1) are
+// clamped.
+func (n *Node) SetReserveFraction(fraction float64) {
+	n.reserveFraction = math.Min(math.Max(fraction, 0), 0.99)
+}
+
+func (n *Node) ReserveFraction() float64 {
+	return n.reserveFraction
+}
+
+// Headroom is the amount of a resource with the given total that the
+// reserve fraction holds back
+func (n *Node) Headroom(total float64) float64 {
+	return total * n.reserveFraction
+}
+
 func (n *Node) CanFit(c *container.Container) bool {
-	return c.CPURequest() <= n.AvailableCPU() &&
-		c.MemoryRequest() <= n.AvailableMemory() &&
-		c.NetworkRequest() <= n.AvailableNetwork() &&
-		c.IORequest() <= n.AvailableIO()
+	if n.cordoned {
+		return false
+	}
+	if n.maxContainers > 0 && len(n.containers) >= n.maxContainers {
+		return false
+	}
+	
+	return n.fitsResources(c)
+}
+
+// fitsResources checks only the resource requests against the capacity
+// outside the reserve, ignoring cordons and container caps
+func (n *Node) fitsResources(c *container.Container) bool {
+	return fits(c.CPURequest(), n.AvailableCPU()-n.Headroom(n.totalCPU), n.totalCPU) &&
+		fits(c.MemoryRequest(), n.AvailableMemory()-n.Headroom(n.totalMemory), n.totalMemory) &&
+		fits(c.NetworkRequest(), n.AvailableNetwork()-n.Headroom(n.totalNetwork), n.totalNetwork) &&
+		fits(c.IORequest(), n.AvailableIO()-n.Headroom(n.totalIO), n.totalIO) &&
+		fits(c.DiskRequest(), n.AvailableDisk()-n.Headroom(n.totalDisk), n.totalDisk) &&
+		n.fitsGPU(c)
+}
+
+// fits reports whether request fits in available; unconstrained (zero-total)
+// resources always fit
+func fits(request, available, total float64) bool {
+	return total == 0 || request <= available
 }
 
 func (n *Node) AddContainer(c *container.Container) bool {
@@ -105,47 +324,91 @@ func (n *Node) AddContainer(c *container.Container) bool {
 		return false
 	}
 	
-	n.usedCPU += c.CPURequest()
-	n.usedMemory += c.MemoryRequest()
-	n.usedNetwork += c.NetworkRequest()
-	n.usedIO += c.IORequest()
+	n.addUsage(c, 1)
+	n.pin(c)
+	n.assignGPU(c)
+	n.containerIndex[c.ID()] = len(n.containers)
 	n.containers = append(n.containers, c)
-	
-	// Update load history
-	n.loadHistory = append(n.loadHistory, n.Utilization())
-	if len(n.loadHistory) > 10 {
-		// Keep only the last 10 entries
-		n.loadHistory = n.loadHistory[1:]
+	if !n.whatIf {
+		c.MarkPlaced(n.clock.Now())
 	}
+	if n.onChange != nil {
+		n.onChange(n)
+	}
+	
+	n.recordLoad(n.Utilization())
 	
 	return true
 }
 
 func (n *Node) RemoveContainer(containerID string) bool {
-	for i, c := range n.containers {
-		if c.ID() == containerID {
-			n.usedCPU -= c.CPURequest()
-			n.usedMemory -= c.MemoryRequest()
-			n.usedNetwork -= c.NetworkRequest()
-			n.usedIO -= c.IORequest()
-			
-			// Remove the container from the slice
-			n.containers = append(n.containers[:i], n.containers[i+1:]...)
-			
-			// Update load history
-			n.loadHistory = append(n.loadHistory, n.Utilization())
-			if len(n.loadHistory) > 10 {
-				// Keep only the last 10 entries
-				n.loadHistory = n.loadHistory[1:]
-			}
-			
-			return true
-		}
+	i, ok := n.containerIndex[containerID]
+	if !ok {
+		return false
+	}
+	
+	n.removeAt(i)
+	return true
+}
+
+// RemoveContainerRef removes c itself, avoiding the ID lookup when the
+// caller already holds the container
+func (n *Node) RemoveContainerRef(c *container.Container) bool {
+	i, ok := n.containerIndex[c.ID()]
+	if !ok || n.containers[i] != c {
+		return false
 	}
 	
-	return false
+	n.removeAt(i)
+	return true
 }
 
+// removeAt removes the container at position i in constant time by moving
+// the last container into its slot, so container order is not preserved
+func (n *Node) removeAt(i int) {
+	c := n.containers[i]
+	n.addUsage(c, -1)
+	n.unpin(c)
+	n.releaseGPU(c)
+	
+	// Remove the container from the slice
+	last := len(n.containers) - 1
+	n.containers[i] = n.containers[last]
+	n.containerIndex[n.containers[i].ID()] = i
+	n.containers[last] = nil
+	n.containers = n.containers[:last]
+	delete(n.containerIndex, c.ID())
+	if n.onChange != nil {
+		n.onChange(n)
+	}
+	
+	n.recordLoad(n.Utilization())
+}
+
+// ObserveLoad adds the node's current effective utilization to its load
+// history, so LoadVariance reflects fluctuating usage between placements
+func (n *Node) ObserveLoad() {
+	n.recordLoad(n.EffectiveUtilization())
+}
+
+func (n *Node) recordLoad(load float64) {
+	if len(n.loadHistory) == 0 {
+		n.smoothedLoad = load
+	} else {
+		n.smoothedLoad += n.loadSmoothing * (load - n.smoothedLoad)
+	}
+	
+	n.loadHistory = append(n.loadHistory, load)
+	if len(n.loadHistory) > 10 {
+		// Keep only the last 10 entries
+		n.loadHistory = n.loadHistory[1:]
+	}
+}
+
+// Containers returns the containers running on the node in no particular
+// order: removing one moves the last container into its slot. The order is
+// still deterministic, so seeded runs repeat; code that needs an order,
+// like eviction by placement time, sorts a copy.
 func (n *Node) Containers() []*container.Container {
 	return n.containers
 }
@@ -155,7 +418,7 @@ func (n *Node) ContainerCount() int {
 }
 
 func (n *Node) UptimeHours() float64 {
-	return time.Since(n.creationTime).Hours()
+	return n.clock.Now().Sub(n.creationTime).Hours()
 }
 
 func (n *Node) LoadVariance() float64 {
@@ -180,6 +443,34 @@ func (n *Node) LoadVariance() float64 {
 	return math.Sqrt(variance)
 }
 
+// DefaultLoadSmoothing is the smoothing factor new nodes start with
+const DefaultLoadSmoothing = 0.3
+
+// SetLoadSmoothing sets alpha, the weight each new load sample gets in
+// SmoothedUtilization: 1 follows the latest sample exactly, values near 0
+// barely move. Values outside (0, 1] are ignored.
+func (n *Node) SetLoadSmoothing(alpha float64) {
+	if alpha <= 0 || alpha > 1 {
+		return
+	}
+	n.loadSmoothing = alpha
+}
+
+func (n *Node) LoadSmoothing() float64 {
+	return n.loadSmoothing
+}
+
+// SmoothedUtilization is an exponential moving average of the loads in the
+// node's history. Unlike LoadVariance's window it never forgets outright,
+// so a step change in load shows up gradually instead of all at once. A
+// node with no history yet reports its current effective utilization.
+func (n *Node) SmoothedUtilization() float64 {
+	if len(n.loadHistory) == 0 {
+		return n.EffectiveUtilization()
+	}
+	return n.smoothedLoad
+}
+
 func (n *Node) HealthScore() float64 {
 	return n.healthScore
 }
@@ -187,3 +478,141 @@ func (n *Node) HealthScore() float64 {
 func (n *Node) UpdateHealthScore(score float64) {
 	n.healthScore = math.Max(0.0, math.Min(1.0, score))
 }
+
+// SetPowerModel configures the node's linear power model: an empty node is
+// considered powered down, otherwise it draws idleWatts plus wattsPerUtil
+// scaled by its utilization.
+func (n *Node) SetPowerModel(idleWatts, wattsPerUtil float64) {
+	n.idleWatts = idleWatts
+	n.wattsPerUtil = wattsPerUtil
+}
+
+func (n *Node) IdleWatts() float64 {
+	return n.idleWatts
+}
+
+func (n *Node) WattsPerUtil() float64 {
+	return n.wattsPerUtil
+}
+
+func (n *Node) IsPoweredOn() bool {
+	return len(n.containers) > 0
+}
+
+func (n *Node) PowerDraw() float64 {
+	if !n.IsPoweredOn() {
+		return 0.0
+	}
+	
+	return n.idleWatts + n.wattsPerUtil*n.Utilization()
+}
+
+func (n *Node) SetHourlyCost(cost float64) {
+	n.hourlyCost = cost
+}
+
+// HasImage reports whether the node has image cached, so a container
+// using it starts without a pull
+func (n *Node) HasImage(image string) bool {
+	return n.images[image]
+}
+
+func (n *Node) CacheImage(image string) {
+	n.images[image] = true
+}
+
+// SetImagePullRate sets how fast the node pulls images, in MB/s
+func (n *Node) SetImagePullRate(mbPerSecond float64) {
+	n.imagePullRate = mbPerSecond
+}
+
+// PullDuration is how long c would wait for its image on this node: zero
+// if cached, otherwise the image size over the pull rate
+func (n *Node) PullDuration(c *container.Container) time.Duration {
+	if n.HasImage(c.Image()) || c.ImageSize() <= 0 || n.imagePullRate <= 0 {
+		return 0
+	}
+	return time.Duration(c.ImageSize() / n.imagePullRate * float64(time.Second))
+}
+
+// SetZone places the node in a failure domain, such as a rack or zone
+func (n *Node) SetZone(zone string) {
+	n.zone = zone
+}
+
+func (n *Node) Zone() string {
+	return n.zone
+}
+
+// HourlyCost is the price of running the node for an hour, in dollars
+func (n *Node) HourlyCost() float64 {
+	return n.hourlyCost
+}
+
+// LeftoverAfter returns the absolute free amount of each resource that
+// would remain on the node once c is placed; negative if c does not fit
+func (n *Node) LeftoverAfter(c *container.Container) (cpu, memory, network, io float64) {
+	return n.AvailableCPU() - c.CPURequest(),
+		n.AvailableMemory() - c.MemoryRequest(),
+		n.AvailableNetwork() - c.NetworkRequest(),
+		n.AvailableIO() - c.IORequest()
+}
+
+// MaxCapacity returns the largest total of each resource across nodes
+func MaxCapacity(nodes []*Node) (cpu, memory, network, io float64) {
+	for _, n := range nodes {
+		cpu = math.Max(cpu, n.totalCPU)
+		memory = math.Max(memory, n.totalMemory)
+		network = math.Max(network, n.totalNetwork)
+		io = math.Max(io, n.totalIO)
+	}
+	return cpu, memory, network, io
+}
+
+// MedianCapacity returns the median total of each resource across nodes
+func MedianCapacity(nodes []*Node) (cpu, memory, network, io float64) {
+	if len(nodes) == 0 {
+		return 0, 0, 0, 0
+	}
+	
+	median := func(total func(n *Node) float64) float64 {
+		values := make([]float64, len(nodes))
+		for i, n := range nodes {
+			values[i] = total(n)
+		}
+		sort.Float64s(values)
+		
+		mid := len(values) / 2
+		if len(values)%2 == 0 {
+			return (values[mid-1] + values[mid]) / 2.0
+		}
+		return values[mid]
+	}
+	
+	return median((*Node).TotalCPU), median((*Node).TotalMemory),
+		median((*Node).TotalNetwork), median((*Node).TotalIO)
+}
+
+// ClusterLoadVariance returns the standard deviation of utilization across
+// the given nodes, mirroring LoadVariance but measured across the cluster
+// rather than over one node's history.
+func ClusterLoadVariance(nodes []*Node) float64 {
+	if len(nodes) < 2 {
+		return 0.0
+	}
+	
+	mean := 0.0
+	for _, n := range nodes {
+		mean += n.Utilization()
+	}
+	mean /= float64(len(nodes))
+	
+	variance := 0.0
+	for _, n := range nodes {
+		diff := n.Utilization() - mean
+		variance += diff * diff
+	}
+	variance /= float64(len(nodes))
+	
+	return math.Sqrt(variance)
+}
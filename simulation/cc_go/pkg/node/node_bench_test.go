@@ -0,0 +1,36 @@
+// pkg/node/node_bench_test.go - Node bookkeeping benchmarks
+package node
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"cc_go/pkg/container"
+)
+
+// BenchmarkRemoveContainer measures removing a random container from a node
+// running 10k of them, putting it back each iteration so the node stays
+// full. Run it with
+//
+//	go test ./pkg/node -run '^$' -bench RemoveContainer
+func BenchmarkRemoveContainer(b *testing.B) {
+	const count = 10000
+	n := NewNode("big", count, count*100, count*10, count*10)
+	containers := make([]*container.Container, count)
+	for i := range containers {
+		containers[i] = container.NewContainer(fmt.Sprintf("c-%d", i), "img", 0.5, 50, 5, 5, "web", 0)
+		if !n.AddContainer(containers[i]) {
+			b.Fatal("container does not fit")
+		}
+	}
+	rng := rand.New(rand.NewSource(1))
+	
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c := containers[rng.Intn(count)]
+		n.RemoveContainerRef(c)
+		n.AddContainer(c)
+	}
+}
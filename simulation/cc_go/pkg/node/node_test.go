@@ -0,0 +1,172 @@
+// pkg/node/node_test.go - Node utilization and capacity tests
+package node
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"cc_go/pkg/container"
+)
+
+func finite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
+
+func TestZeroIONodeUtilizationIsFinite(t *testing.T) {
+	n := NewNode("no-io", 8, 8192, 1000, 0)
+	if !n.AddContainer(container.NewContainer("c", "img", 2, 2048, 100, 0, "web", 0)) {
+		t.Fatal("container requesting no IO does not fit a node without IO")
+	}
+	
+	figures := map[string]float64{
+		"IOUtilization":        n.IOUtilization(),
+		"Utilization":          n.Utilization(),
+		"EffectiveUtilization": n.EffectiveUtilization(),
+		"DominantUtilization":  n.DominantUtilization(),
+		"SmoothedUtilization":  n.SmoothedUtilization(),
+		"LoadVariance":         n.LoadVariance(),
+	}
+	for name, value := range figures {
+		if !finite(value) {
+			t.Errorf("%s = %g on a node with zero IO", name, value)
+		}
+	}
+	if got := n.IOUtilization(); got != 0 {
+		t.Errorf("IOUtilization = %g, want 0 for an unconstrained resource", got)
+	}
+	if want := (0.25 + 0.25 + 0.1) / 4; math.Abs(n.Utilization()-want) > 1e-9 {
+		t.Errorf("Utilization = %g, want %g with IO contributing nothing", n.Utilization(), want)
+	}
+}
+
+func TestDominantUtilizationOnCPUSkewedNode(t *testing.T) {
+	n := NewNode("skewed", 10, 10000, 1000, 1000)
+	n.AddContainer(container.NewContainer("cpu-heavy", "img", 9, 100, 10, 10, "batch", 0))
+	
+	if got := n.DominantUtilization(); math.Abs(got-0.9) > 1e-9 {
+		t.Errorf("DominantUtilization = %g, want the CPU utilization 0.9", got)
+	}
+	if got, want := n.Utilization(), (0.9+0.01+0.01+0.01)/4; math.Abs(got-want) > 1e-9 {
+		t.Errorf("Utilization = %g, want the mean %g", got, want)
+	}
+	// The mean calls the node a quarter full although only a tenth of its
+	// CPU is left
+	if n.Utilization() >= 0.5 || n.DominantUtilization() < 0.5 {
+		t.Errorf("average %g and dominant %g do not tell the CPU-bound node apart", n.Utilization(), n.DominantUtilization())
+	}
+}
+
+func TestReserveFractionCapsUtilization(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		n := NewNode(fmt.Sprintf("node-%d", i), float64(2+rng.Intn(30)), float64(1024*(1+rng.Intn(64))), 1000, 1000)
+		n.SetDiskCapacity(100)
+		n.SetReserveFraction(0.2)
+		
+		for attempt := 0; attempt < 500; attempt++ {
+			c := container.NewContainer("c", "img", rng.Float64()*2, rng.Float64()*4096, rng.Float64()*100, rng.Float64()*100, "web", 0)
+			c.SetDiskRequest(rng.Float64() * 10)
+			n.AddContainer(c)
+			
+			resources := map[string]float64{
+				"cpu":     n.CPUUtilization(),
+				"memory":  n.MemoryUtilization(),
+				"network": n.NetworkUtilization(),
+				"io":      n.IOUtilization(),
+				"disk":    n.DiskUtilization(),
+			}
+			for resource, utilization := range resources {
+				if utilization > 0.8+1e-9 {
+					t.Fatalf("%s: %s utilization %g above the 0.8 left by a 0.2 reserve", n.Name(), resource, utilization)
+				}
+			}
+		}
+		if n.DominantUtilization() < 0.7 {
+			t.Errorf("%s filled to only %g; the test did not approach the reserve", n.Name(), n.DominantUtilization())
+		}
+	}
+}
+
+func TestFractionalUsageReturnsToZero(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	n := NewNode("n", 64, 65536, 10000, 10000)
+	n.SetDiskCapacity(1000)
+	
+	// Requests such as 0.1 CPU have no exact float64 form, so summing and
+	// subtracting them as floats would leave residue behind
+	var placed []*container.Container
+	for round := 0; round < 50; round++ {
+		for i := 0; i < 100; i++ {
+			c := container.NewContainer("c", "img", 0.1*float64(1+rng.Intn(5)), 0.3+rng.Float64()*100, 0.7, rng.Float64(), "web", 0)
+			c.SetDiskRequest(0.01 * float64(rng.Intn(100)))
+			if n.AddContainer(c) {
+				placed = append(placed, c)
+			}
+		}
+		if err := n.CheckAccounting(); err != nil {
+			t.Fatalf("round %d: %v", round, err)
+		}
+		
+		// Remove a random half, so additions and removals interleave
+		rng.Shuffle(len(placed), func(i, j int) { placed[i], placed[j] = placed[j], placed[i] })
+		for _, c := range placed[len(placed)/2:] {
+			if !n.RemoveContainer(c.ID()) {
+				t.Fatalf("round %d: %s not on the node", round, c.ID())
+			}
+		}
+		placed = placed[:len(placed)/2]
+	}
+	for _, c := range placed {
+		n.RemoveContainer(c.ID())
+	}
+	
+	if err := n.CheckAccounting(); err != nil {
+		t.Fatal(err)
+	}
+	used := map[string]milli{
+		"cpu":     n.usedCPU,
+		"memory":  n.usedMemory,
+		"network": n.usedNetwork,
+		"io":      n.usedIO,
+		"disk":    n.usedDisk,
+	}
+	for resource, value := range used {
+		if value != 0 {
+			t.Errorf("empty node still uses %v %s", value.float(), resource)
+		}
+	}
+	if n.CPUUtilization() != 0 || n.MemoryUtilization() != 0 || n.DiskUtilization() != 0 {
+		t.Errorf("empty node utilization cpu %g memory %g disk %g, want exactly 0",
+			n.CPUUtilization(), n.MemoryUtilization(), n.DiskUtilization())
+	}
+}
+
+func TestSmoothedUtilizationConvergesAfterStep(t *testing.T) {
+	n := NewNode("n", 10, 10000, 1000, 1000)
+	n.SetLoadSmoothing(0.3)
+	for i := 0; i < 10; i++ {
+		n.recordLoad(0.2)
+	}
+	
+	previous := n.SmoothedUtilization()
+	for step := 1; step <= 30; step++ {
+		n.recordLoad(0.9)
+		smoothed := n.SmoothedUtilization()
+		if want := previous + 0.3*(0.9-previous); math.Abs(smoothed-want) > 1e-12 {
+			t.Fatalf("step %d: smoothed %g, want %g", step, smoothed, want)
+		}
+		if smoothed <= previous || smoothed >= 0.9 {
+			t.Fatalf("step %d: smoothed %g, want strictly between the previous %g and the new load", step, smoothed, previous)
+		}
+		previous = smoothed
+	}
+	if math.Abs(previous-0.9) > 1e-3 {
+		t.Errorf("smoothed %g after 30 samples at 0.9, want it converged", previous)
+	}
+	// The raw window has already forgotten the old load
+	if variance := n.LoadVariance(); variance > 1e-12 {
+		t.Errorf("load variance %g over 10 equal samples, want 0", variance)
+	}
+}
@@ -0,0 +1,106 @@
+// pkg/node/numa.go - NUMA socket tracking
+package node
+
+import (
+	"cc_go/pkg/container"
+)
+
+// numa splits a node's CPU and memory evenly over its sockets and tracks
+// what pinned containers use on each. Unpinned containers only count
+// against the node's aggregate capacity.
+type numa struct {
+	sockets int
+	cpu     []milli        // pinned CPU per socket
+	memory  []milli        // pinned memory per socket
+	pinned  map[string]int // pinned container ID to its socket, -1 if split across sockets
+}
+
+// SetSockets divides the node into count NUMA sockets; 1 or less models
+// the node as a single socket. It must be called while the node is empty.
+func (n *Node) SetSockets(count int) {
+	if count <= 1 {
+		n.numa = nil
+		return
+	}
+	n.numa = &numa{
+		sockets: count,
+		cpu:     make([]milli, count),
+		memory:  make([]milli, count),
+		pinned:  make(map[string]int),
+	}
+}
+
+func (n *Node) Sockets() int {
+	if n.numa == nil {
+		return 1
+	}
+	return n.numa.sockets
+}
+
+// FitsSocket reports whether one socket alone has room for c's CPU and
+// memory. A single-socket node is the whole node, so it only needs CanFit.
+func (n *Node) FitsSocket(c *container.Container) bool {
+	return n.numa == nil || n.bestSocket(c) >= 0
+}
+
+// CrossSocket reports whether c was pinned but had to be split across
+// sockets because no single one could hold it
+func (n *Node) CrossSocket(c *container.Container) bool {
+	if n.numa == nil {
+		return false
+	}
+	socket, ok := n.numa.pinned[c.ID()]
+	return ok && socket < 0
+}
+
+// bestSocket returns the fitting socket left with the least free CPU after
+// placing c, or -1 if no socket can hold it
+func (n *Node) bestSocket(c *container.Container) int {
+	cpuTotal := n.totalCPU / float64(n.numa.sockets)
+	memoryTotal := n.totalMemory / float64(n.numa.sockets)
+	
+	best := -1
+	bestLeftover := 0.0
+	for s := 0; s < n.numa.sockets; s++ {
+		cpuFree := cpuTotal - n.Headroom(cpuTotal) - n.numa.cpu[s].float()
+		memoryFree := memoryTotal - n.Headroom(memoryTotal) - n.numa.memory[s].float()
+		if !fits(c.CPURequest(), cpuFree, cpuTotal) || !fits(c.MemoryRequest(), memoryFree, memoryTotal) {
+			continue
+		}
+		leftover := cpuFree - c.CPURequest()
+		if best < 0 || leftover < bestLeftover {
+			best = s
+			bestLeftover = leftover
+		}
+	}
+	return best
+}
+
+// pin assigns a pinned container that was just added to a socket
+func (n *Node) pin(c *container.Container) {
+	if n.numa == nil || !c.NUMAPinned() {
+		return
+	}
+	socket := n.bestSocket(c)
+	n.numa.pinned[c.ID()] = socket
+	if socket >= 0 {
+		n.numa.cpu[socket] += toMilli(c.CPURequest())
+		n.numa.memory[socket] += toMilli(c.MemoryRequest())
+	}
+}
+
+// unpin releases the socket of a container that was just removed
+func (n *Node) unpin(c *container.Container) {
+	if n.numa == nil {
+		return
+	}
+	socket, ok := n.numa.pinned[c.ID()]
+	if !ok {
+		return
+	}
+	delete(n.numa.pinned, c.ID())
+	if socket >= 0 {
+		n.numa.cpu[socket] -= toMilli(c.CPURequest())
+		n.numa.memory[socket] -= toMilli(c.MemoryRequest())
+	}
+}
@@ -0,0 +1,157 @@
+// pkg/node/pool.go - Capacity-indexed node pool
+package node
+
+import (
+	"cc_go/pkg/container"
+	"math"
+	"sort"
+)
+
+// Pool keeps a set of nodes ordered by free CPU so that Candidates can skip
+// every node without enough CPU left instead of scanning the whole cluster.
+// The order is kept up to date as containers are added to or removed from
+// the pooled nodes. A node can belong to at most one pool at a time.
+//
+// Each node remembers the key it is sorted by, so the pool finds it by
+// binary search rather than a scan, and a node that moves only shifts the
+// nodes it passes.
+type Pool struct {
+	nodes []*Node   // ordered by cpuKey, most free CPU first
+	keys  []float64 // cpuKey of each node as of its last change
+}
+
+func NewPool(nodes []*Node) *Pool {
+	p := &Pool{nodes: make([]*Node, len(nodes)), keys: make([]float64, len(nodes))}
+	copy(p.nodes, nodes)
+	
+	sort.SliceStable(p.nodes, func(i, j int) bool {
+		return cpuKey(p.nodes[i]) > cpuKey(p.nodes[j])
+	})
+	for i, n := range p.nodes {
+		p.keys[i] = cpuKey(n)
+		n.poolKey = p.keys[i]
+		n.onChange = p.reposition
+	}
+	
+	return p
+}
+
+// Nodes returns every node in the pool, most free CPU first
+func (p *Pool) Nodes() []*Node {
+	return p.nodes
+}
+
+// Candidates returns the nodes that can fit c, most free CPU first. Only
+// nodes with enough free CPU are examined.
+func (p *Pool) Candidates(c *container.Container) []*Node {
+	end := p.withFreeCPU(c.CPURequest())
+	candidates := make([]*Node, 0, end)
+	for _, n := range p.nodes[:end] {
+		if n.CanFit(c) {
+			candidates = append(candidates, n)
+		}
+	}
+	
+	return candidates
+}
+
+// WithFreeCPU returns a copy of the nodes with at least cpu free, most free
+// CPU first
+func (p *Pool) WithFreeCPU(cpu float64) []*Node {
+	return append([]*Node(nil), p.nodes[:p.withFreeCPU(cpu)]...)
+}
+
+// withFreeCPU returns how many nodes have at least cpu free; nodes are
+// sorted by free CPU, so those form a prefix
+func (p *Pool) withFreeCPU(cpu float64) int {
+	return sort.Search(len(p.keys), func(i int) bool {
+		return p.keys[i] < cpu
+	})
+}
+
+// Add puts n, e.g. a node that joined the cluster mid-run, in the pool
+func (p *Pool) Add(n *Node) {
+	key := cpuKey(n)
+	at := sort.Search(len(p.keys), func(i int) bool {
+		return p.keys[i] < key
+	})
+	p.nodes = append(p.nodes, nil)
+	copy(p.nodes[at+1:], p.nodes[at:])
+	p.keys = append(p.keys, 0)
+	copy(p.keys[at+1:], p.keys[at:])
+	p.nodes[at], p.keys[at] = n, key
+	n.poolKey = key
+	n.onChange = p.reposition
+}
+
+// Remove takes n out of the pool and detaches the pool from it
+func (p *Pool) Remove(n *Node) bool {
+	i := p.find(n)
+	if i < 0 {
+		return false
+	}
+	
+	p.nodes = append(p.nodes[:i], p.nodes[i+1:]...)
+	p.keys = append(p.keys[:i], p.keys[i+1:]...)
+	n.onChange = nil
+	return true
+}
+
+// Close detaches the pool from its nodes so they stop updating it
+func (p *Pool) Close() {
+	for _, n := range p.nodes {
+		n.onChange = nil
+	}
+}
+
+// find returns the position of n, or -1 if it is not in the pool. Only the
+// nodes sorted under n's key are compared.
+func (p *Pool) find(n *Node) int {
+	start := sort.Search(len(p.keys), func(i int) bool {
+		return p.keys[i] <= n.poolKey
+	})
+	for i := start; i < len(p.keys) && p.keys[i] == n.poolKey; i++ {
+		if p.nodes[i] == n {
+			return i
+		}
+	}
+	return -1
+}
+
+// reposition moves n to its new place after its free CPU changed, behind
+// the nodes with as much free CPU
+func (p *Pool) reposition(n *Node) {
+	i := p.find(n)
+	if i < 0 {
+		return
+	}
+	
+	// The nodes on either side are still in order, so the new place is a
+	// binary search away on the side n moves to
+	key := cpuKey(n)
+	if i > 0 && p.keys[i-1] < key {
+		to := sort.Search(i, func(j int) bool {
+			return p.keys[j] < key
+		})
+		copy(p.nodes[to+1:i+1], p.nodes[to:i])
+		copy(p.keys[to+1:i+1], p.keys[to:i])
+		i = to
+	} else if i < len(p.keys)-1 && p.keys[i+1] >= key {
+		to := i + sort.Search(len(p.keys)-i-1, func(j int) bool {
+			return p.keys[i+1+j] < key
+		})
+		copy(p.nodes[i:to], p.nodes[i+1:to+1])
+		copy(p.keys[i:to], p.keys[i+1:to+1])
+		i = to
+	}
+	p.nodes[i], p.keys[i] = n, key
+	n.poolKey = key
+}
+
+// cpuKey is the node's free CPU; an unconstrained node sorts first
+func cpuKey(n *Node) float64 {
+	if n.totalCPU == 0 {
+		return math.Inf(1)
+	}
+	return n.AvailableCPU()
+}
@@ -0,0 +1,73 @@
+// pkg/node/pool_test.go - Capacity-indexed node pool tests
+package node
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"cc_go/pkg/container"
+)
+
+// checkPool fails unless the pool is ordered by free CPU and every node
+// is found where it is
+func checkPool(t *testing.T, p *Pool) {
+	t.Helper()
+	if len(p.keys) != len(p.nodes) {
+		t.Fatalf("pool has %d keys for %d nodes", len(p.keys), len(p.nodes))
+	}
+	for i, n := range p.nodes {
+		if p.keys[i] != cpuKey(n) || n.poolKey != cpuKey(n) {
+			t.Fatalf("%s has %g free but is sorted by %g (remembers %g)", n.Name(), cpuKey(n), p.keys[i], n.poolKey)
+		}
+		if found := p.find(n); found != i {
+			t.Fatalf("%s is at %d, found at %d", n.Name(), i, found)
+		}
+		if i > 0 && cpuKey(p.nodes[i-1]) < cpuKey(n) {
+			t.Fatalf("%s (%g free) sorts after %s (%g free)", n.Name(), cpuKey(n), p.nodes[i-1].Name(), cpuKey(p.nodes[i-1]))
+		}
+	}
+}
+
+func TestPoolStaysOrderedUnderChurn(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	nodes := make([]*Node, 50)
+	for i := range nodes {
+		nodes[i] = NewNode(fmt.Sprintf("node-%d", i), float64(4+rng.Intn(12)), 100000, 100000, 100000)
+	}
+	p := NewPool(nodes)
+	defer p.Close()
+	checkPool(t, p)
+	
+	var placed []*container.Container
+	hosts := make(map[*container.Container]*Node)
+	for step := 0; step < 5000; step++ {
+		switch {
+		case len(placed) > 0 && rng.Intn(3) == 0:
+			i := rng.Intn(len(placed))
+			c := placed[i]
+			hosts[c].RemoveContainerRef(c)
+			placed[i] = placed[len(placed)-1]
+			placed = placed[:len(placed)-1]
+		case rng.Intn(100) == 0:
+			n := NewNode(fmt.Sprintf("joined-%d", step), float64(4+rng.Intn(12)), 100000, 100000, 100000)
+			p.Add(n)
+		default:
+			c := container.NewContainer("c", "img", 0.25*float64(1+rng.Intn(8)), 1, 1, 1, "web", 0)
+			if candidates := p.Candidates(c); len(candidates) > 0 {
+				n := candidates[rng.Intn(len(candidates))]
+				n.AddContainer(c)
+				placed = append(placed, c)
+				hosts[c] = n
+			}
+		}
+		checkPool(t, p)
+	}
+	
+	for _, n := range append([]*Node(nil), p.Nodes()...) {
+		if n.ContainerCount() == 0 && !p.Remove(n) {
+			t.Fatalf("could not remove %s", n.Name())
+		}
+	}
+	checkPool(t, p)
+}
@@ -0,0 +1,93 @@
+// pkg/node/pressure.go - Memory pressure eviction
+package node
+
+import (
+	"cc_go/pkg/container"
+	"sort"
+)
+
+// EffectiveMemory is the memory the node's containers actually use now,
+// which can exceed their requests under usage noise or usage profiles
+func (n *Node) EffectiveMemory() float64 {
+	now := n.clock.Now()
+	memory := 0.0
+	for _, c := range n.containers {
+		_, memoryUsage, _, _ := c.EffectiveUsage(now)
+		memory += memoryUsage
+	}
+	return memory
+}
+
+// ReclaimMemory shrinks the usage of containers using more memory than
+// they requested back towards their requests, like the kernel reclaiming
+// page cache, until amount MB are freed or nothing more can be taken. It
+// goes through containers in eviction order and returns the MB reclaimed.
+func (n *Node) ReclaimMemory(amount float64) float64 {
+	now := n.clock.Now()
+	reclaimed := 0.0
+	for _, c := range n.byEvictionOrder() {
+		if reclaimed >= amount {
+			break
+		}
+		reclaimed += c.ReclaimMemory(amount-reclaimed, now)
+	}
+	return reclaimed
+}
+
+// HandleMemoryPressure simulates the kernel under memory pressure: while
+// effective memory use exceeds the node's total, it first reclaims memory
+// used beyond requests (see ReclaimMemory) and then, as the OOM killer,
+// evicts containers by QoS class (BestEffort, then Burstable, then
+// Guaranteed), within a class lowest priority first and, within a
+// priority, the most recently placed. It returns the MB reclaimed and the
+// evicted containers so the caller can count or resubmit them. Nodes with
+// unconstrained memory never reclaim or evict.
+func (n *Node) HandleMemoryPressure() (float64, []*container.Container) {
+	if n.totalMemory == 0 {
+		return 0, nil
+	}
+	
+	now := n.clock.Now()
+	memory := n.EffectiveMemory()
+	if memory <= n.totalMemory {
+		return 0, nil
+	}
+	reclaimed := n.ReclaimMemory(memory - n.totalMemory)
+	memory -= reclaimed
+	
+	evicted := make([]*container.Container, 0)
+	for _, c := range n.byEvictionOrder() {
+		if memory <= n.totalMemory {
+			break
+		}
+		_, memoryUsage, _, _ := c.EffectiveUsage(now)
+		// Evicting a container that uses no memory frees nothing
+		if memoryUsage == 0 {
+			continue
+		}
+		if n.RemoveContainerRef(c) {
+			memory -= memoryUsage
+			evicted = append(evicted, c)
+		}
+	}
+	
+	return reclaimed, evicted
+}
+
+// byEvictionOrder returns the node's containers in the order memory
+// pressure takes from them
+func (n *Node) byEvictionOrder() []*container.Container {
+	candidates := make([]*container.Container, len(n.containers))
+	copy(candidates, n.containers)
+	sort.Slice(candidates, func(i, j int) bool {
+		iRank, jRank := container.QoSRank(candidates[i].QoSClass()), container.QoSRank(candidates[j].QoSClass())
+		if iRank != jRank {
+			return iRank < jRank
+		}
+		if candidates[i].Priority() != candidates[j].Priority() {
+			return candidates[i].Priority() < candidates[j].Priority()
+		}
+		return candidates[i].PlacedAt().After(candidates[j].PlacedAt())
+	})
+	return candidates
+}
@@ -0,0 +1,51 @@
+// pkg/node/reservation.go - Capacity reserved for non-container processes
+package node
+
+// Reserve sets capacity aside for something that is not a container, such
+// as the kubelet or a log shipper. Like an invisible container, it is no
+// longer available to containers and counts towards the node's
+// utilization. Reservations add up. It returns false, leaving the node
+// unchanged, if an amount is negative or more than is free.
+func (n *Node) Reserve(cpu, memory, network, io float64) bool {
+	free := func(amount, available, total float64) bool {
+		return amount >= 0 && (total == 0 || amount <= available)
+	}
+	if !free(cpu, n.AvailableCPU(), n.totalCPU) ||
+		!free(memory, n.AvailableMemory(), n.totalMemory) ||
+		!free(network, n.AvailableNetwork(), n.totalNetwork) ||
+		!free(io, n.AvailableIO(), n.totalIO) {
+		return false
+	}
+	
+	n.reservedCPU += toMilli(cpu)
+	n.reservedMemory += toMilli(memory)
+	n.reservedNetwork += toMilli(network)
+	n.reservedIO += toMilli(io)
+	if n.onChange != nil {
+		n.onChange(n)
+	}
+	return true
+}
+
+// Unreserve releases reserved capacity; releasing more than is reserved
+// releases all of it
+func (n *Node) Unreserve(cpu, memory, network, io float64) {
+	release := func(reserved *milli, amount float64) {
+		*reserved -= toMilli(amount)
+		if *reserved < 0 {
+			*reserved = 0
+		}
+	}
+	release(&n.reservedCPU, cpu)
+	release(&n.reservedMemory, memory)
+	release(&n.reservedNetwork, network)
+	release(&n.reservedIO, io)
+	if n.onChange != nil {
+		n.onChange(n)
+	}
+}
+
+// Reserved returns the capacity currently reserved outside containers
+func (n *Node) Reserved() (cpu, memory, network, io float64) {
+	return n.reservedCPU.float(), n.reservedMemory.float(), n.reservedNetwork.float(), n.reservedIO.float()
+}
@@ -0,0 +1,29 @@
+// pkg/node/reservation_test.go - Reserved capacity tests
+package node
+
+import (
+	"testing"
+
+	"cc_go/pkg/container"
+)
+
+func TestReservationRejectsFullNodeRequest(t *testing.T) {
+	n := NewNode("n", 4, 8192, 1000, 1000)
+	if !n.Reserve(1, 0, 0, 0) {
+		t.Fatal("reserving 1 CPU on an empty node failed")
+	}
+	
+	full := container.NewContainer("full", "img", n.TotalCPU(), 512, 10, 10, "web", 0)
+	if n.CanFit(full) || n.AddContainer(full) {
+		t.Fatalf("container requesting all %g CPUs placed beside a 1-CPU reservation", n.TotalCPU())
+	}
+	if rest := container.NewContainer("rest", "img", n.TotalCPU()-1, 512, 10, 10, "web", 0); !n.AddContainer(rest) {
+		t.Fatal("container requesting the unreserved CPU does not fit")
+	}
+	if n.CPUUtilization() != 1 {
+		t.Errorf("CPU utilization %g with the rest placed, want 1 counting the reservation", n.CPUUtilization())
+	}
+	if err := n.CheckAccounting(); err != nil {
+		t.Error(err)
+	}
+}
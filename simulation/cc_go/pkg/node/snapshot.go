@@ -0,0 +1,157 @@
+// pkg/node/snapshot.go - Point-in-time cluster state
+package node
+
+import (
+	"time"
+)
+
+// ResourceUsage is the amount of one resource used by containers, reserved
+// for other processes, and in total
+type ResourceUsage struct {
+	Used     float64 `json:"used"`
+	Reserved float64 `json:"reserved,omitempty"`
+	Total    float64 `json:"total"`
+}
+
+func (r ResourceUsage) Available() float64 {
+	return r.Total - r.Used - r.Reserved
+}
+
+// NodeSnapshot is a copy of one node's state; it does not change when the
+// node does
+type NodeSnapshot struct {
+	ID             string        `json:"id"`
+	Name           string        `json:"name"`
+	Zone           string        `json:"zone,omitempty"`
+	CPU            ResourceUsage `json:"cpu"`
+	Memory         ResourceUsage `json:"memory"`
+	Network        ResourceUsage `json:"network"`
+	IO             ResourceUsage `json:"io"`
+	Disk           ResourceUsage `json:"disk"`
+	Utilization    float64       `json:"utilization"`
+	ContainerCount int           `json:"container_count"`
+	HealthScore    float64       `json:"health_score"`
+	Cordoned       bool          `json:"cordoned"`
+}
+
+// ClusterSnapshot is the state of every node at one moment, in the order
+// the nodes were given
+type ClusterSnapshot struct {
+	Taken time.Time      `json:"taken"`
+	Nodes []NodeSnapshot `json:"nodes"`
+}
+
+// Snapshot captures the state of nodes, taken at the time on their clock.
+// The caller must keep the nodes from changing meanwhile, e.g. by holding
+// the benchmark lock.
+func Snapshot(nodes []*Node) ClusterSnapshot {
+	var snapshot ClusterSnapshot
+	SnapshotInto(&snapshot, nodes)
+	return snapshot
+}
+
+// SnapshotInto is Snapshot reusing dst's node slice, so frequent snapshots
+// do not allocate once the slice is large enough
+func SnapshotInto(dst *ClusterSnapshot, nodes []*Node) {
+	dst.Taken = time.Time{}
+	if len(nodes) > 0 {
+		dst.Taken = nodes[0].clock.Now()
+	}
+	dst.Nodes = dst.Nodes[:0]
+	for _, n := range nodes {
+		dst.Nodes = append(dst.Nodes, NodeSnapshot{
+			ID:             n.id,
+			Name:           n.name,
+			Zone:           n.zone,
+			CPU:            ResourceUsage{Used: n.usedCPU.float(), Reserved: n.reservedCPU.float(), Total: n.totalCPU},
+			Memory:         ResourceUsage{Used: n.usedMemory.float(), Reserved: n.reservedMemory.float(), Total: n.totalMemory},
+			Network:        ResourceUsage{Used: n.usedNetwork.float(), Reserved: n.reservedNetwork.float(), Total: n.totalNetwork},
+			IO:             ResourceUsage{Used: n.usedIO.float(), Reserved: n.reservedIO.float(), Total: n.totalIO},
+			Disk:           ResourceUsage{Used: n.usedDisk.float(), Total: n.totalDisk},
+			Utilization:    n.Utilization(),
+			ContainerCount: len(n.containers),
+			HealthScore:    n.healthScore,
+			Cordoned:       n.cordoned,
+		})
+	}
+}
+
+// SnapshotChange is one field that differs between two snapshots of a
+// node. Booleans are reported as 0 or 1; a node present in only one
+// snapshot is reported with the field "present".
+type SnapshotChange struct {
+	Node   string  `json:"node"`
+	Field  string  `json:"field"`
+	Before float64 `json:"before"`
+	After  float64 `json:"after"`
+}
+
+// Diff lists what changed from s to other, matching nodes by name. Two
+// snapshots of an unchanged cluster give no changes.
+func (s ClusterSnapshot) Diff(other ClusterSnapshot) []SnapshotChange {
+	var changes []SnapshotChange
+	
+	after := make(map[string]*NodeSnapshot, len(other.Nodes))
+	for i := range other.Nodes {
+		after[other.Nodes[i].Name] = &other.Nodes[i]
+	}
+	
+	seen := make(map[string]bool, len(s.Nodes))
+	for i := range s.Nodes {
+		before := &s.Nodes[i]
+		seen[before.Name] = true
+		current, ok := after[before.Name]
+		if !ok {
+			changes = append(changes, SnapshotChange{Node: before.Name, Field: "present", Before: 1, After: 0})
+			continue
+		}
+		changes = appendNodeChanges(changes, before, current)
+	}
+	
+	for i := range other.Nodes {
+		if !seen[other.Nodes[i].Name] {
+			changes = append(changes, SnapshotChange{Node: other.Nodes[i].Name, Field: "present", Before: 0, After: 1})
+		}
+	}
+	
+	return changes
+}
+
+func appendNodeChanges(changes []SnapshotChange, before, after *NodeSnapshot) []SnapshotChange {
+	fields := []struct {
+		name          string
+		before, after float64
+	}{
+		{"cpu_used", before.CPU.Used, after.CPU.Used},
+		{"cpu_reserved", before.CPU.Reserved, after.CPU.Reserved},
+		{"cpu_total", before.CPU.Total, after.CPU.Total},
+		{"memory_used", before.Memory.Used, after.Memory.Used},
+		{"memory_reserved", before.Memory.Reserved, after.Memory.Reserved},
+		{"memory_total", before.Memory.Total, after.Memory.Total},
+		{"network_used", before.Network.Used, after.Network.Used},
+		{"network_reserved", before.Network.Reserved, after.Network.Reserved},
+		{"network_total", before.Network.Total, after.Network.Total},
+		{"io_used", before.IO.Used, after.IO.Used},
+		{"io_reserved", before.IO.Reserved, after.IO.Reserved},
+		{"io_total", before.IO.Total, after.IO.Total},
+		{"disk_used", before.Disk.Used, after.Disk.Used},
+		{"disk_total", before.Disk.Total, after.Disk.Total},
+		{"container_count", float64(before.ContainerCount), float64(after.ContainerCount)},
+		{"health_score", before.HealthScore, after.HealthScore},
+		{"cordoned", boolValue(before.Cordoned), boolValue(after.Cordoned)},
+	}
+	
+	for _, field := range fields {
+		if field.before != field.after {
+			changes = append(changes, SnapshotChange{Node: before.Name, Field: field.name, Before: field.before, After: field.after})
+		}
+	}
+	return changes
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
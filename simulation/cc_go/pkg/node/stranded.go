@@ -0,0 +1,70 @@
+// pkg/node/stranded.go - Stranded (free but unusable) capacity
+package node
+
+import (
+	"cc_go/pkg/container"
+	"sort"
+)
+
+// A resource is stranded when the node can no longer fit the reference
+// container because some other resource ran out: the capacity is free, but
+// nothing of the usual shape can use it. The most-utilized resource is the
+// bottleneck and is never counted as stranded.
+
+func (n *Node) StrandedCPU(reference *container.Container) float64 {
+	return n.stranded(reference, n.CPUUtilization(), n.AvailableCPU())
+}
+
+func (n *Node) StrandedMemory(reference *container.Container) float64 {
+	return n.stranded(reference, n.MemoryUtilization(), n.AvailableMemory())
+}
+
+func (n *Node) StrandedNetwork(reference *container.Container) float64 {
+	return n.stranded(reference, n.NetworkUtilization(), n.AvailableNetwork())
+}
+
+func (n *Node) StrandedIO(reference *container.Container) float64 {
+	return n.stranded(reference, n.IOUtilization(), n.AvailableIO())
+}
+
+func (n *Node) StrandedDisk(reference *container.Container) float64 {
+	return n.stranded(reference, n.DiskUtilization(), n.AvailableDisk())
+}
+
+func (n *Node) stranded(reference *container.Container, utilization, available float64) float64 {
+	if reference == nil || n.fitsResources(reference) || utilization >= n.DominantUtilization() {
+		return 0
+	}
+	return available
+}
+
+// MedianContainer returns a container shaped like the median request of
+// everything running on nodes, or nil when nothing is running
+func MedianContainer(nodes []*Node) *container.Container {
+	var cpu, memory, network, io, disk []float64
+	for _, n := range nodes {
+		for _, c := range n.containers {
+			cpu = append(cpu, c.CPURequest())
+			memory = append(memory, c.MemoryRequest())
+			network = append(network, c.NetworkRequest())
+			io = append(io, c.IORequest())
+			disk = append(disk, c.DiskRequest())
+		}
+	}
+	if len(cpu) == 0 {
+		return nil
+	}
+	
+	median := func(values []float64) float64 {
+		sort.Float64s(values)
+		mid := len(values) / 2
+		if len(values)%2 == 0 {
+			return (values[mid-1] + values[mid]) / 2.0
+		}
+		return values[mid]
+	}
+	
+	reference := container.NewContainer("median", "", median(cpu), median(memory), median(network), median(io), "median", 0)
+	reference.SetDiskRequest(median(disk))
+	return reference
+}
@@ -0,0 +1,63 @@
+// pkg/node/units.go - Integer resource accounting
+package node
+
+import (
+	"cc_go/pkg/container"
+	"fmt"
+	"math"
+)
+
+// milli is a resource amount in thousandths of its public unit (millicores
+// for CPU, thousandths of a MB for memory, ...). Node usage is accumulated
+// in milli-units so that adding and removing the same containers any number
+// of times returns it to exactly zero, which float64 sums do not.
+type milli int64
+
+func toMilli(value float64) milli {
+	return milli(math.Round(value * 1000))
+}
+
+func (m milli) float() float64 {
+	return float64(m) / 1000
+}
+
+// CheckAccounting verifies that the node's recorded usage equals the sum of
+// its containers' requests exactly, so an empty node uses nothing
+func (n *Node) CheckAccounting() error {
+	var cpu, memory, network, io, disk milli
+	for _, c := range n.containers {
+		cpu += toMilli(c.CPURequest())
+		memory += toMilli(c.MemoryRequest())
+		network += toMilli(c.NetworkRequest())
+		io += toMilli(c.IORequest())
+		disk += toMilli(c.DiskRequest())
+	}
+	
+	checks := []struct {
+		resource       string
+		used, expected milli
+	}{
+		{"cpu", n.usedCPU, cpu},
+		{"memory", n.usedMemory, memory},
+		{"network", n.usedNetwork, network},
+		{"io", n.usedIO, io},
+		{"disk", n.usedDisk, disk},
+	}
+	for _, check := range checks {
+		if check.used != check.expected {
+			return fmt.Errorf("node %s: %s usage %v does not match its %d containers' requests %v",
+				n.name, check.resource, check.used.float(), len(n.containers), check.expected.float())
+		}
+	}
+	
+	return nil
+}
+
+// addUsage adds (sign 1) or removes (sign -1) c's requests from the node's usage
+func (n *Node) addUsage(c *container.Container, sign milli) {
+	n.usedCPU += sign * toMilli(c.CPURequest())
+	n.usedMemory += sign * toMilli(c.MemoryRequest())
+	n.usedNetwork += sign * toMilli(c.NetworkRequest())
+	n.usedIO += sign * toMilli(c.IORequest())
+	n.usedDisk += sign * toMilli(c.DiskRequest())
+}
@@ -2,18 +2,32 @@
 package scheduler
 
 import (
+	"cc_go/pkg/clock"
 	"cc_go/pkg/container"
 	"cc_go/pkg/node"
 	"math"
+	"runtime"
 	"sort"
+	"sync"
 	"time"
 )
 
+// parallelScoringThreshold is the candidate count above which node scores
+// are computed concurrently
+const parallelScoringThreshold = 256
+
+// stressedUtilization is the smoothed utilization above which the health
+// score treats a node as under sustained load, as the benchmark's health
+// model does
+const stressedUtilization = 0.8
+
 type AdaptiveScheduler struct {
 	// Historical data for performance tracking
-	containerHistory    map[string][]float64 // container type to resource usage patterns
+	containerHistory    map[string][]float64 // container type to its moving average of requests
+	observations        map[string]int       // placements averaged into each type's history
 	nodeHistory         map[string][]float64 // node ID to performance metrics
 	schedulingStartTime time.Time
+	clock               clock.Clock // the run's clock; the wall clock unless set
 	schedulerPhase      int // 0: startup, 1: normal, 2: high-load
 	
 	// Resource score weights (dynamically adjusted)
@@ -21,79 +35,210 @@ type AdaptiveScheduler struct {
 	memoryWeight float64
 	networkWeight float64
 	ioWeight     float64
+	diskWeight   float64
+	weightOffset ResourceWeights // added to every phase's weights; carried over by LoadState
+	
+	penalties InterferencePenalties
+	intensity IntensityThresholds
+	config    AdaptiveConfig
+	
+	name         string
+	interference *InterferenceModel // replaces the fixed penalties when learning
+	
+	observer        SchedulerObserver
+	reportedWeights map[string]ResourceWeights // last weights reported per container type
+}
+
+// IntensityThresholds are the request sizes above which a container counts
+// as intensive for a resource when judging interference
+type IntensityThresholds struct {
+	CPU     float64
+	Memory  float64
+	Network float64
+	IO      float64
+}
+
+// InterferencePenalties are the interference score deductions for each
+// co-located container that conflicts with the one being placed. They add
+// up per conflicting neighbor, so two memory-intensive peers cost twice as
+// much as one, but the total deduction never exceeds Max.
+type InterferencePenalties struct {
+	SameType float64
+	CPU      float64
+	Memory   float64
+	Network  float64
+	IO       float64
+	Max      float64
+}
+
+func DefaultInterferencePenalties() InterferencePenalties {
+	return InterferencePenalties{
+		SameType: 0.1,
+		CPU:      0.15,
+		Memory:   0.15,
+		Network:  0.15,
+		IO:       0.15,
+		Max:      0.9,
+	}
+}
+
+func init() {
+	Register("adaptive", func(opts Options) (Scheduler, error) {
+		s := NewAdaptiveScheduler()
+		return opts.reader("adaptive").adaptive(s)
+	})
 }
 
 func NewAdaptiveScheduler() *AdaptiveScheduler {
-	return &AdaptiveScheduler{
+	cpu, memory, network, io := container.IntensityThresholds()
+	
+	s := &AdaptiveScheduler{
 		containerHistory:    make(map[string][]float64),
+		observations:        make(map[string]int),
 		nodeHistory:         make(map[string][]float64),
 		schedulingStartTime: time.Now(),
+		clock:               clock.Real{},
 		schedulerPhase:      0,
-		cpuWeight:           0.25,
-		memoryWeight:        0.25,
-		networkWeight:       0.25,
-		ioWeight:            0.25,
+		penalties:           DefaultInterferencePenalties(),
+		intensity:           IntensityThresholds{CPU: cpu, Memory: memory, Network: network, IO: io},
+		config:              DefaultAdaptiveConfig(),
+		name:                "Adaptive",
 	}
+	s.setWeights(s.config.Weights.Normal)
+	return s
+}
+
+// UseRelativeIntensity sets the scheduler's intensity thresholds to
+// fraction of the cluster's median node capacity, so "intensive" means the
+// same thing on small and large clusters alike. Unlike
+// container.SetIntensityThresholds it only affects this scheduler.
+func (s *AdaptiveScheduler) UseRelativeIntensity(nodes []*node.Node, fraction float64) {
+	cpu, memory, network, io := node.MedianCapacity(nodes)
+	s.intensity = IntensityThresholds{CPU: cpu * fraction, Memory: memory * fraction, Network: network * fraction, IO: io * fraction}
+}
+
+// SetClock times the scheduler's phases, and the learned interference if
+// any, by c, e.g. a run's simulated clock; the startup phase begins now on c
+func (s *AdaptiveScheduler) SetClock(c clock.Clock) {
+	s.clock = c
+	s.schedulingStartTime = c.Now()
+	if s.interference != nil {
+		s.interference.SetClock(c)
+	}
+}
+
+func (s *AdaptiveScheduler) Intensity() IntensityThresholds {
+	return s.intensity
+}
+
+func (s *AdaptiveScheduler) SetInterferencePenalties(penalties InterferencePenalties) {
+	s.penalties = penalties
 }
 
 func (s *AdaptiveScheduler) Name() string {
-	return "Adaptive"
+	return s.name
 }
 
 func (s *AdaptiveScheduler) Schedule(container *container.Container, nodes []*node.Node) (*node.Node, error) {
-	candidateNodes := make([]*node.Node, 0)
+	nodeScores, err := s.Explain(container, nodes)
+	if err != nil {
+		return nil, err
+	}
 	
+	// Record placement decision for future reference
+	bestNode := nodeScores[0].Node
+	s.recordPlacement(container, bestNode)
+	
+	return bestNode, nil
+}
+
+// Explain scores every node that can fit the container, best first, with
+// the base, interference and health terms behind each score
+func (s *AdaptiveScheduler) Explain(container *container.Container, nodes []*node.Node) ([]NodeScore, error) {
 	// Update scheduler phase based on runtime
 	s.updateSchedulerPhase()
 	
-	// Filter nodes that can accommodate the container
-	for _, n := range nodes {
-		if n.CanFit(container) {
-			candidateNodes = append(candidateNodes, n)
-		}
+	// Take container type into account for resource prediction
+	containerType := container.Type()
+	if _, exists := s.containerHistory[containerType]; exists && s.config.AdaptToContainers {
+		// Shift the phase weights towards this container type's usage pattern
+		s.adjustWeightsForContainer(containerType)
 	}
+	s.normalizeWeights()
+	s.reportWeights(containerType)
 	
-	if len(candidateNodes) == 0 {
-		return nil, ErrNoSuitableNode
-	}
+	// Calculate fitness scores for each node that can accommodate the container
+	nodeScores := s.scoreNodes(container, nodes)
 	
-	// Calculate fitness scores for each candidate node
-	nodeScores := make(map[*node.Node]float64)
-	for _, n := range candidateNodes {
-		nodeScores[n] = s.calculateFitnessScore(container, n)
+	if len(nodeScores) == 0 {
+		return nil, ErrNoSuitableNode
 	}
 	
 	// Sort by fitness score (higher is better)
-	sort.Slice(candidateNodes, func(i, j int) bool {
-		return nodeScores[candidateNodes[i]] > nodeScores[candidateNodes[j]]
+	sort.Slice(nodeScores, func(i, j int) bool {
+		return nodeScores[i].Score > nodeScores[j].Score
 	})
 	
-	// Take container type into account for resource prediction
-	containerType := container.Type()
-	if _, exists := s.containerHistory[containerType]; exists {
-		// Update weights based on historical performance of this container type
-		s.adjustWeightsForContainer(containerType)
+	return nodeScores, nil
+}
+
+// scoreNodes scores every node that can fit the container, in node order.
+// Above parallelScoringThreshold candidates the scores are computed by up to
+// GOMAXPROCS goroutines, each writing its own slots, so the result does not
+// depend on goroutine timing. Scoring only reads node and scheduler state;
+// the caller (the benchmark, holding its lock) keeps nodes from changing
+// meanwhile.
+func (s *AdaptiveScheduler) scoreNodes(container *container.Container, nodes []*node.Node) []NodeScore {
+	candidates := candidates(container, nodes)
+	
+	scores := make([]NodeScore, len(candidates))
+	if len(candidates) <= parallelScoringThreshold {
+		for i, n := range candidates {
+			scores[i] = s.calculateFitnessScore(container, n)
+		}
+		return scores
 	}
 	
-	// Record placement decision for future reference
-	bestNode := candidateNodes[0]
-	s.recordPlacement(container, bestNode)
+	workers := runtime.GOMAXPROCS(0)
+	chunk := (len(candidates) + workers - 1) / workers
+	var wg sync.WaitGroup
+	for start := 0; start < len(candidates); start += chunk {
+		end := start + chunk
+		if end > len(candidates) {
+			end = len(candidates)
+		}
+		
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				scores[i] = s.calculateFitnessScore(container, candidates[i])
+			}
+		}(start, end)
+	}
+	wg.Wait()
 	
-	return bestNode, nil
+	return scores
 }
 
-func (s *AdaptiveScheduler) calculateFitnessScore(container *container.Container, n *node.Node) float64 {
+func (s *AdaptiveScheduler) ScheduleBatch(containers []*container.Container, nodes []*node.Node) (map[*container.Container]*node.Node, error) {
+	return scheduleBatchSequentially(s, containers, nodes)
+}
+
+func (s *AdaptiveScheduler) calculateFitnessScore(container *container.Container, n *node.Node) NodeScore {
 	// Base score is weighted sum of normalized resource availability
-	cpuScore := (n.AvailableCPU() - container.CPURequest()) / n.TotalCPU()
-	memScore := (n.AvailableMemory() - container.MemoryRequest()) / n.TotalMemory()
-	netScore := (n.AvailableNetwork() - container.NetworkRequest()) / n.TotalNetwork()
-	ioScore := (n.AvailableIO() - container.IORequest()) / n.TotalIO()
+	cpuScore := availabilityScore(n.AvailableCPU()-container.CPURequest(), n.TotalCPU())
+	memScore := availabilityScore(n.AvailableMemory()-container.MemoryRequest(), n.TotalMemory())
+	netScore := availabilityScore(n.AvailableNetwork()-container.NetworkRequest(), n.TotalNetwork())
+	ioScore := availabilityScore(n.AvailableIO()-container.IORequest(), n.TotalIO())
+	diskScore := availabilityScore(n.AvailableDisk()-container.DiskRequest(), n.TotalDisk())
 	
 	// Apply current weights (these are dynamically adjusted)
 	baseScore := (cpuScore * s.cpuWeight) + 
 		(memScore * s.memoryWeight) + 
 		(netScore * s.networkWeight) + 
-		(ioScore * s.ioWeight)
+		(ioScore * s.ioWeight) +
+		(diskScore * s.diskWeight)
 	
 	// Consider interference score based on container affinity/anti-affinity
 	interferenceScore := s.calculateInterferenceScore(container, n)
@@ -101,44 +246,81 @@ func (s *AdaptiveScheduler) calculateFitnessScore(container *container.Container
 	// Consider node health and historical performance
 	nodeHealthScore := s.calculateNodeHealthScore(n)
 	
+	// Traffic beyond what the NIC carries is lost, so the node is worth
+	// that much less
+	contention := n.NetworkContentionWith(container)
+	
 	// Combine all factors
-	finalScore := baseScore * 0.6 + interferenceScore * 0.2 + nodeHealthScore * 0.2
-	return finalScore
+	blend := s.config.Blend
+	finalScore := baseScore * blend.Base + interferenceScore * blend.Interference + nodeHealthScore * blend.Health
+	finalScore *= 1 - contention
+	return NodeScore{
+		Node:  n,
+		Score: finalScore,
+		Components: []ScoreComponent{
+			{Name: "base", Value: baseScore},
+			{Name: "interference", Value: interferenceScore},
+			{Name: "health", Value: nodeHealthScore},
+			{Name: "contention", Value: contention},
+		},
+	}
+}
+
+// availabilityScore is the fraction of a resource left free; an
+// unconstrained (zero-total) resource counts as fully free, matching the
+// zero utilization node.Ratio reports for it
+func availabilityScore(remaining, total float64) float64 {
+	if total <= 0 {
+		return 1.0
+	}
+	return remaining / total
 }
 
 func (s *AdaptiveScheduler) calculateInterferenceScore(container *container.Container, n *node.Node) float64 {
 	// Higher score means less interference
-	score := 1.0
+	penalty := 0.0
 	
-	// Check for anti-affinity with containers already on this node
+	// Check for anti-affinity with containers already on this node; every
+	// conflicting neighbor adds its own penalty
 	existingContainers := n.Containers()
+	now := s.clock.Now()
 	
 	for _, existing := range existingContainers {
+		// Containers still starting up are not yet competing for resources
+		if !existing.IsReady(now) {
+			continue
+		}
+		
+		if s.interference != nil {
+			penalty += s.interference.Penalty(existing.Type(), container.Type())
+			continue
+		}
+		
 		// Containers of same type might interfere
 		if existing.Type() == container.Type() {
-			score -= 0.1
+			penalty += s.penalties.SameType
 		}
 		
 		// Adjust for specific resource competition
-		if existing.CPUIntensive() && container.CPUIntensive() {
-			score -= 0.15
+		if existing.CPURequest() > s.intensity.CPU && container.CPURequest() > s.intensity.CPU {
+			penalty += s.penalties.CPU
 		}
 		
-		if existing.MemoryIntensive() && container.MemoryIntensive() {
-			score -= 0.15
+		if existing.MemoryRequest() > s.intensity.Memory && container.MemoryRequest() > s.intensity.Memory {
+			penalty += s.penalties.Memory
 		}
 		
-		if existing.IOIntensive() && container.IOIntensive() {
-			score -= 0.15
+		if existing.IORequest() > s.intensity.IO && container.IORequest() > s.intensity.IO {
+			penalty += s.penalties.IO
 		}
 		
-		if existing.NetworkIntensive() && container.NetworkIntensive() {
-			score -= 0.15
+		if existing.NetworkRequest() > s.intensity.Network && container.NetworkRequest() > s.intensity.Network {
+			penalty += s.penalties.Network
 		}
 	}
 	
-	// Ensure score doesn't go negative
-	return math.Max(0.1, score)
+	// Cap the total so the score never reaches zero
+	return 1.0 - math.Min(penalty, s.penalties.Max)
 }
 
 func (s *AdaptiveScheduler) calculateNodeHealthScore(n *node.Node) float64 {
@@ -157,19 +339,24 @@ func (s *AdaptiveScheduler) calculateNodeHealthScore(n *node.Node) float64 {
 	loadVariance := n.LoadVariance()
 	variancePenalty := loadVariance * 0.2
 	
+	// Consider sustained load; the smoothed utilization moves gradually,
+	// so a single busy sample barely marks a node as stressed
+	stressPenalty := math.Max(0, n.SmoothedUtilization()-stressedUtilization) * 0.5
+	
 	// Consider node uptime and reliability
 	uptimeScore := math.Min(1.0, n.UptimeHours()/24.0) * 0.1
 	
-	return baseScore - variancePenalty + uptimeScore
+	return baseScore - variancePenalty - stressPenalty + uptimeScore
 }
 
 func (s *AdaptiveScheduler) updateSchedulerPhase() {
-	elapsedTime := time.Since(s.schedulingStartTime).Minutes()
+	previous := s.schedulerPhase
+	elapsedTime := s.clock.Now().Sub(s.schedulingStartTime)
 	
-	if elapsedTime < 1 {
+	if elapsedTime < s.config.StartupPhase {
 		// Startup phase - prefer spreading out containers
 		s.schedulerPhase = 0
-	} else if elapsedTime > 10 {
+	} else if elapsedTime > s.config.HighLoadAfter {
 		// High-load phase - focus on efficient packing
 		s.schedulerPhase = 2
 	} else {
@@ -178,59 +365,156 @@ func (s *AdaptiveScheduler) updateSchedulerPhase() {
 	}
 	
 	// Adjust weights based on phase
-	switch s.schedulerPhase {
+	s.setWeights(s.phaseWeights(s.schedulerPhase).plus(s.weightOffset))
+	
+	if s.schedulerPhase != previous {
+		s.notify(StateEvent{
+			Kind:   "phase",
+			From:   phaseNames[previous],
+			To:     phaseNames[s.schedulerPhase],
+			Detail: "base weights " + s.weights().String(),
+		})
+	}
+}
+
+// phaseWeights returns the configured base weights of a scheduler phase
+func (s *AdaptiveScheduler) phaseWeights(phase int) ResourceWeights {
+	switch phase {
 	case 0: // Startup
-		s.cpuWeight = 0.2
-		s.memoryWeight = 0.2
-		s.networkWeight = 0.3
-		s.ioWeight = 0.3
-	case 1: // Normal
-		s.cpuWeight = 0.25
-		s.memoryWeight = 0.25
-		s.networkWeight = 0.25
-		s.ioWeight = 0.25
+		return s.config.Weights.Startup
 	case 2: // High-load
-		s.cpuWeight = 0.3
-		s.memoryWeight = 0.3
-		s.networkWeight = 0.2
-		s.ioWeight = 0.2
+		return s.config.Weights.HighLoad
+	default: // Normal
+		return s.config.Weights.Normal
 	}
 }
 
 func (s *AdaptiveScheduler) adjustWeightsForContainer(containerType string) {
 	history := s.containerHistory[containerType]
-	if len(history) < 4 {
+	if len(history) < 5 {
 		return
 	}
 	
-	// history order: [cpu, memory, network, io]
+	// history order: [cpu, memory, network, io, disk]
 	cpuUsage := history[0]
 	memUsage := history[1]
 	netUsage := history[2]
 	ioUsage := history[3]
+	diskUsage := history[4]
 	
-	// Normalize to make sure weights sum to 1.0
-	total := cpuUsage + memUsage + netUsage + ioUsage
-	if total > 0 {
-		s.cpuWeight = 0.1 + (cpuUsage / total * 0.6)
-		s.memoryWeight = 0.1 + (memUsage / total * 0.6)
-		s.networkWeight = 0.1 + (netUsage / total * 0.6)
-		s.ioWeight = 0.1 + (ioUsage / total * 0.6)
+	total := cpuUsage + memUsage + netUsage + ioUsage + diskUsage
+	if total <= 0 {
+		return
 	}
+	
+	// Blend the container's usage profile with the phase baseline so that
+	// neither overrides the other
+	s.cpuWeight = (s.cpuWeight + 0.1 + (cpuUsage / total * 0.6)) / 2.0
+	s.memoryWeight = (s.memoryWeight + 0.1 + (memUsage / total * 0.6)) / 2.0
+	s.networkWeight = (s.networkWeight + 0.1 + (netUsage / total * 0.6)) / 2.0
+	s.ioWeight = (s.ioWeight + 0.1 + (ioUsage / total * 0.6)) / 2.0
+	s.diskWeight = (s.diskWeight + 0.1 + (diskUsage / total * 0.6)) / 2.0
+}
+
+// normalizeWeights rescales the resource weights so they sum to exactly 1.0,
+// falling back to equal weights if they have collapsed to zero
+func (s *AdaptiveScheduler) normalizeWeights() {
+	s.cpuWeight = math.Max(0.0, s.cpuWeight)
+	s.memoryWeight = math.Max(0.0, s.memoryWeight)
+	s.networkWeight = math.Max(0.0, s.networkWeight)
+	s.ioWeight = math.Max(0.0, s.ioWeight)
+	s.diskWeight = math.Max(0.0, s.diskWeight)
+	
+	total := s.cpuWeight + s.memoryWeight + s.networkWeight + s.ioWeight + s.diskWeight
+	if total <= 0 {
+		s.cpuWeight, s.memoryWeight, s.networkWeight, s.ioWeight, s.diskWeight = 0.2, 0.2, 0.2, 0.2, 0.2
+		return
+	}
+	
+	s.cpuWeight /= total
+	s.memoryWeight /= total
+	s.networkWeight /= total
+	s.ioWeight /= total
+	s.diskWeight = 1.0 - s.cpuWeight - s.memoryWeight - s.networkWeight - s.ioWeight
 }
 
 func (s *AdaptiveScheduler) recordPlacement(container *container.Container, n *node.Node) {
-	// Record container resource pattern
+	// Fold the container's requests into its type's moving average
 	containerType := container.Type()
-	s.containerHistory[containerType] = []float64{
+	s.observeRequests(containerType, []float64{
 		container.CPURequest(),
 		container.MemoryRequest(),
 		container.NetworkRequest(),
 		container.IORequest(),
-	}
+		container.DiskRequest(),
+	})
 	
 	// Update node history
 	s.nodeHistory[n.ID()] = []float64{
 		n.HealthScore(),
 	}
-}
\ No newline at end of file
+}
+// phaseNames names the values of schedulerPhase in state events
+var phaseNames = []string{"startup", "normal", "high-load"}
+
+// weightReportThreshold is how far a weight must move from the last
+// reported value before the change is reported again
+const weightReportThreshold = 0.1
+
+// SetObserver reports phase transitions and resource weight changes to
+// observer; nil stops the reports
+func (s *AdaptiveScheduler) SetObserver(observer SchedulerObserver) {
+	s.observer = observer
+	s.reportedWeights = make(map[string]ResourceWeights)
+}
+
+func (s *AdaptiveScheduler) notify(event StateEvent) {
+	if s.observer == nil {
+		return
+	}
+	event.Scheduler = s.Name()
+	s.observer.StateChanged(event)
+}
+
+func (s *AdaptiveScheduler) weights() ResourceWeights {
+	return ResourceWeights{CPU: s.cpuWeight, Memory: s.memoryWeight, Network: s.networkWeight, IO: s.ioWeight, Disk: s.diskWeight}
+}
+
+// reportWeights reports the weights used for a container of containerType
+// when they first appear or have moved noticeably since last reported
+func (s *AdaptiveScheduler) reportWeights(containerType string) {
+	if s.observer == nil {
+		return
+	}
+	
+	current := s.weights()
+	last, reported := s.reportedWeights[containerType]
+	if reported && !last.differs(current, weightReportThreshold) {
+		return
+	}
+	s.reportedWeights[containerType] = current
+	
+	event := StateEvent{Kind: "weights", To: current.String(), Detail: "type " + containerType}
+	if reported {
+		event.From = last.String()
+	}
+	s.notify(event)
+}
+
+// observeRequests moves the history of a container type towards requests
+// by the configured history decay. The first placements are averaged
+// plainly, each weighing 1/n until that is less than the decay, so the
+// history does not start out as whatever the first container asked for.
+func (s *AdaptiveScheduler) observeRequests(containerType string, requests []float64) {
+	s.observations[containerType]++
+	weight := math.Max(1/float64(s.observations[containerType]), s.config.HistoryDecay)
+	
+	history := s.containerHistory[containerType]
+	if len(history) != len(requests) {
+		s.containerHistory[containerType] = requests
+		return
+	}
+	for i, value := range requests {
+		history[i] += (value - history[i]) * weight
+	}
+}
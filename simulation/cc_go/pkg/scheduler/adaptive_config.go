@@ -0,0 +1,195 @@
+// pkg/scheduler/adaptive_config.go - Adaptive scheduler tuning options
+package scheduler
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// ResourceWeights weight each resource's availability in the adaptive base score
+type ResourceWeights struct {
+	CPU     float64
+	Memory  float64
+	Network float64
+	IO      float64
+	Disk    float64
+}
+
+// PhaseWeights are the resource weights the scheduler starts from in each
+// phase, before container-based adaptation adjusts them
+type PhaseWeights struct {
+	Startup  ResourceWeights
+	Normal   ResourceWeights
+	HighLoad ResourceWeights
+}
+
+// BlendWeights combine the base, interference and health terms into a
+// node's final score; they must sum to 1
+type BlendWeights struct {
+	Base         float64
+	Interference float64
+	Health       float64
+}
+
+// AdaptiveConfig holds the adaptive scheduler's tunable constants
+type AdaptiveConfig struct {
+	Weights           PhaseWeights
+	Blend             BlendWeights
+	StartupPhase      time.Duration // runtime spent in the startup phase
+	HighLoadAfter     time.Duration // runtime after which the high-load phase starts
+	AdaptToContainers bool          // shift weights towards each container type's usage
+	HistoryDecay      float64       // weight of each placement in a container type's moving average of requests
+}
+
+// DefaultAdaptiveConfig returns the values NewAdaptiveScheduler uses
+func DefaultAdaptiveConfig() AdaptiveConfig {
+	return AdaptiveConfig{
+		Weights: PhaseWeights{
+			Startup:  ResourceWeights{CPU: 0.2, Memory: 0.2, Network: 0.3, IO: 0.3, Disk: 0.1},
+			Normal:   ResourceWeights{CPU: 0.25, Memory: 0.25, Network: 0.25, IO: 0.25, Disk: 0.1},
+			HighLoad: ResourceWeights{CPU: 0.3, Memory: 0.3, Network: 0.2, IO: 0.2, Disk: 0.15},
+		},
+		Blend:             BlendWeights{Base: 0.6, Interference: 0.2, Health: 0.2},
+		StartupPhase:      1 * time.Minute,
+		HighLoadAfter:     10 * time.Minute,
+		AdaptToContainers: true,
+		HistoryDecay:      0.05,
+	}
+}
+
+// Validate rejects negative weights, blend coefficients that do not sum to
+// 1, phase thresholds out of order and a history decay outside (0, 1]
+func (c AdaptiveConfig) Validate() error {
+	phases := []struct {
+		name    string
+		weights ResourceWeights
+	}{
+		{"startup", c.Weights.Startup},
+		{"normal", c.Weights.Normal},
+		{"high-load", c.Weights.HighLoad},
+	}
+	for _, phase := range phases {
+		w := phase.weights
+		if w.CPU < 0 || w.Memory < 0 || w.Network < 0 || w.IO < 0 || w.Disk < 0 {
+			return fmt.Errorf("%s weights must not be negative", phase.name)
+		}
+	}
+	
+	b := c.Blend
+	if b.Base < 0 || b.Interference < 0 || b.Health < 0 {
+		return fmt.Errorf("blend coefficients must not be negative")
+	}
+	if sum := b.Base + b.Interference + b.Health; math.Abs(sum-1.0) > 1e-9 {
+		return fmt.Errorf("blend coefficients must sum to 1, got %g", sum)
+	}
+	
+	if c.StartupPhase < 0 || c.HighLoadAfter < c.StartupPhase {
+		return fmt.Errorf("phase thresholds must satisfy 0 <= startup phase (%v) <= high-load start (%v)", c.StartupPhase, c.HighLoadAfter)
+	}
+	
+	if c.HistoryDecay <= 0 || c.HistoryDecay > 1 {
+		return fmt.Errorf("history decay must be in (0, 1], got %g", c.HistoryDecay)
+	}
+	
+	return nil
+}
+
+// NewAdaptiveSchedulerWithConfig creates an adaptive scheduler with the
+// given weights, blend and phase thresholds instead of the defaults
+func NewAdaptiveSchedulerWithConfig(cfg AdaptiveConfig) (*AdaptiveScheduler, error) {
+	s := NewAdaptiveScheduler()
+	if err := s.SetConfig(cfg); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// SetConfig replaces the tuning constants; it should be called before any
+// scheduling happens
+func (s *AdaptiveScheduler) SetConfig(cfg AdaptiveConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	s.config = cfg
+	s.setWeights(cfg.Weights.Normal)
+	return nil
+}
+
+// adaptive applies the adaptive scheduler options to s: phase weights as
+// lists of CPU, memory, network, IO and disk weights, the blend as a list
+// of base, interference and health coefficients, the phase thresholds and
+// the history decay
+func (r *optionReader) adaptive(s *AdaptiveScheduler) (Scheduler, error) {
+	cfg := s.Config()
+	phases := []struct {
+		key     string
+		weights *ResourceWeights
+	}{
+		{"startup_weights", &cfg.Weights.Startup},
+		{"weights", &cfg.Weights.Normal},
+		{"high_load_weights", &cfg.Weights.HighLoad},
+	}
+	for _, phase := range phases {
+		if w, ok := r.floats(phase.key, 5); ok {
+			*phase.weights = ResourceWeights{CPU: w[0], Memory: w[1], Network: w[2], IO: w[3], Disk: w[4]}
+		}
+	}
+	if b, ok := r.floats("blend", 3); ok {
+		cfg.Blend = BlendWeights{Base: b[0], Interference: b[1], Health: b[2]}
+	}
+	if d, ok := r.duration("startup_phase"); ok {
+		cfg.StartupPhase = d
+	}
+	if d, ok := r.duration("high_load_after"); ok {
+		cfg.HighLoadAfter = d
+	}
+	if adapt, ok := r.bool("adapt_to_containers"); ok {
+		cfg.AdaptToContainers = adapt
+	}
+	if decay, ok := r.float("history_decay"); ok {
+		cfg.HistoryDecay = decay
+	}
+	
+	if err := r.done(); err != nil {
+		return nil, err
+	}
+	if err := s.SetConfig(cfg); err != nil {
+		return nil, fmt.Errorf("%s options: %v", r.scheduler, err)
+	}
+	return s, nil
+}
+
+// Config returns the tuning constants the scheduler runs with
+func (s *AdaptiveScheduler) Config() AdaptiveConfig {
+	return s.config
+}
+
+func (w ResourceWeights) String() string {
+	return fmt.Sprintf("cpu=%.3f memory=%.3f network=%.3f io=%.3f disk=%.3f", w.CPU, w.Memory, w.Network, w.IO, w.Disk)
+}
+
+// differs reports whether any weight is more than threshold away from o's
+func (w ResourceWeights) differs(o ResourceWeights, threshold float64) bool {
+	return math.Abs(w.CPU-o.CPU) > threshold || math.Abs(w.Memory-o.Memory) > threshold ||
+		math.Abs(w.Network-o.Network) > threshold || math.Abs(w.IO-o.IO) > threshold ||
+		math.Abs(w.Disk-o.Disk) > threshold
+}
+
+// plus adds o to every weight
+func (w ResourceWeights) plus(o ResourceWeights) ResourceWeights {
+	return ResourceWeights{w.CPU + o.CPU, w.Memory + o.Memory, w.Network + o.Network, w.IO + o.IO, w.Disk + o.Disk}
+}
+
+// minus subtracts o from every weight
+func (w ResourceWeights) minus(o ResourceWeights) ResourceWeights {
+	return ResourceWeights{w.CPU - o.CPU, w.Memory - o.Memory, w.Network - o.Network, w.IO - o.IO, w.Disk - o.Disk}
+}
+
+func (s *AdaptiveScheduler) setWeights(w ResourceWeights) {
+	s.cpuWeight = w.CPU
+	s.memoryWeight = w.Memory
+	s.networkWeight = w.Network
+	s.ioWeight = w.IO
+	s.diskWeight = w.Disk
+}
@@ -0,0 +1,111 @@
+// pkg/scheduler/adaptive_state.go - Adaptive scheduler state persistence
+package scheduler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+)
+
+// adaptiveStateVersion is bumped whenever the layout of adaptiveState changes
+const adaptiveStateVersion = 4
+
+var ErrStateVersionMismatch = errors.New("adaptive state version mismatch")
+
+type adaptiveWeights struct {
+	CPU     float64 `json:"cpu"`
+	Memory  float64 `json:"memory"`
+	Network float64 `json:"network"`
+	IO      float64 `json:"io"`
+	Disk    float64 `json:"disk"`
+}
+
+type adaptiveState struct {
+	Version          int                  `json:"version"`
+	ContainerHistory map[string][]float64 `json:"container_history"`
+	NodeHistory      map[string][]float64 `json:"node_history"`
+	Phase            int                  `json:"phase"`   // scheduler phase the weights were saved in
+	Weights          adaptiveWeights      `json:"weights"` // base weights of that phase, before per-type adjustment
+	Interference     map[string]float64   `json:"interference,omitempty"` // learned pair penalties
+}
+
+// SaveState writes the scheduler's learned history and current base
+// weights to path as JSON so a later run can warm-start from them. The
+// weights are those of the current phase before they are shifted towards
+// a container type, which the saved history redoes on its own.
+func (s *AdaptiveScheduler) SaveState(path string) error {
+	weights := s.phaseWeights(s.schedulerPhase).plus(s.weightOffset)
+	state := adaptiveState{
+		Version:          adaptiveStateVersion,
+		ContainerHistory: s.containerHistory,
+		NodeHistory:      s.nodeHistory,
+		Phase:            s.schedulerPhase,
+		Weights: adaptiveWeights{
+			CPU:     weights.CPU,
+			Memory:  weights.Memory,
+			Network: weights.Network,
+			IO:      weights.IO,
+			Disk:    weights.Disk,
+		},
+	}
+
+	if s.interference != nil {
+		state.Interference = s.interference.Estimates()
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// LoadState restores history and weights written by SaveState. The weights
+// are kept as an offset from the configured weights of the phase they were
+// saved in, and that offset applies on top of every phase's weights from
+// then on, so they survive the phase changes of the new run. On any error
+// (including a missing file or ErrStateVersionMismatch) the scheduler is
+// left untouched, so callers can log the error and start fresh.
+func (s *AdaptiveScheduler) LoadState(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var state adaptiveState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+
+	if state.Version != adaptiveStateVersion {
+		return fmt.Errorf("%w: file has version %d, expected %d",
+			ErrStateVersionMismatch, state.Version, adaptiveStateVersion)
+	}
+	if state.Phase < 0 || state.Phase >= len(phaseNames) {
+		return fmt.Errorf("adaptive state has unknown phase %d", state.Phase)
+	}
+
+	if state.ContainerHistory != nil {
+		s.containerHistory = state.ContainerHistory
+		// A saved history already averages many placements, so new ones
+		// only move it by the decay
+		s.observations = make(map[string]int, len(state.ContainerHistory))
+		for containerType := range state.ContainerHistory {
+			s.observations[containerType] = math.MaxInt32
+		}
+	}
+	if state.NodeHistory != nil {
+		s.nodeHistory = state.NodeHistory
+	}
+	saved := ResourceWeights{state.Weights.CPU, state.Weights.Memory, state.Weights.Network, state.Weights.IO, state.Weights.Disk}
+	s.weightOffset = saved.minus(s.phaseWeights(state.Phase))
+	s.setWeights(saved)
+	if s.interference != nil && state.Interference != nil {
+		s.interference.SetEstimates(state.Interference)
+	}
+
+	return nil
+}
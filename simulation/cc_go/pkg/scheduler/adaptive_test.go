@@ -0,0 +1,230 @@
+// pkg/scheduler/adaptive_test.go - Adaptive scheduler history and weight tests
+package scheduler
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+)
+
+// placeSampled schedules count containers of type "web" whose requests are
+// drawn uniformly from [min, max] of each resource
+func placeSampled(t *testing.T, s *AdaptiveScheduler, rng *rand.Rand, count int, min, max [5]float64) {
+	t.Helper()
+	nodes := []*node.Node{node.NewNode("big", 1000, 1000000, 100000, 100000)}
+	for i := 0; i < count; i++ {
+		var r [5]float64
+		for j := range r {
+			r[j] = min[j] + rng.Float64()*(max[j]-min[j])
+		}
+		c := container.NewContainer("web", "nginx", r[0], r[1], r[2], r[3], "web", 0)
+		c.SetDiskRequest(r[4])
+		if _, err := s.Schedule(c, nodes); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestAdaptiveHistoryConvergesToTemplateMean(t *testing.T) {
+	s := NewAdaptiveScheduler()
+	min := [5]float64{0.5, 128, 10, 10, 1}
+	max := [5]float64{1.5, 384, 30, 30, 3}
+	placeSampled(t, s, rand.New(rand.NewSource(1)), 2000, min, max)
+
+	history := s.containerHistory["web"]
+	for i, value := range history {
+		mean := (min[i] + max[i]) / 2
+		if math.Abs(value-mean) > 0.1*mean {
+			t.Errorf("history[%d] = %g, want within 10%% of the template mean %g", i, value, mean)
+		}
+	}
+}
+
+func TestAdaptiveHistoryIsNotTheLastContainer(t *testing.T) {
+	s := NewAdaptiveScheduler()
+	typical := [5]float64{1, 256, 20, 20, 2}
+	placeSampled(t, s, rand.New(rand.NewSource(1)), 100, typical, typical)
+
+	outlier := [5]float64{10, 2560, 200, 200, 20}
+	placeSampled(t, s, rand.New(rand.NewSource(1)), 1, outlier, outlier)
+
+	want := typical[0] + (outlier[0]-typical[0])*s.Config().HistoryDecay
+	if got := s.containerHistory["web"][0]; math.Abs(got-want) > 1e-9 {
+		t.Errorf("CPU history after one outlier = %g, want %g", got, want)
+	}
+}
+
+func TestAdaptiveWeightsSumToOne(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	types := []string{"web", "db", "batch", "cache"}
+	for run := 0; run < 20; run++ {
+		s := NewAdaptiveScheduler()
+		nodes := []*node.Node{
+			node.NewNode("a", 1000, 1000000, 100000, 100000),
+			node.NewNode("b", 500, 2000000, 50000, 200000),
+		}
+		for i := 0; i < 200; i++ {
+			// Requests span several orders of magnitude, and some are zero
+			scale := math.Pow(10, float64(rng.Intn(4)-2))
+			var r [5]float64
+			for j := range r {
+				if rng.Intn(5) > 0 {
+					r[j] = rng.Float64() * scale
+				}
+			}
+			c := container.NewContainer("c", "img", r[0], r[1], r[2], r[3], types[rng.Intn(len(types))], 0)
+			c.SetDiskRequest(r[4])
+			if _, err := s.Schedule(c, nodes); err != nil {
+				t.Fatal(err)
+			}
+			
+			w := s.weights()
+			for _, weight := range []float64{w.CPU, w.Memory, w.Network, w.IO, w.Disk} {
+				if weight < 0 || math.IsNaN(weight) {
+					t.Fatalf("run %d, container %d: weight %g in %s", run, i, weight, w)
+				}
+			}
+			if sum := w.CPU + w.Memory + w.Network + w.IO + w.Disk; math.Abs(sum-1) > 1e-12 {
+				t.Fatalf("run %d, container %d: weights sum to %.15f, want 1", run, i, sum)
+			}
+		}
+	}
+}
+
+func TestAdaptiveInterferenceGrowsWithEachMemoryIntensiveNeighbor(t *testing.T) {
+	s := NewAdaptiveScheduler()
+	memory := 2 * s.Intensity().Memory
+	incoming := container.NewContainer("incoming", "img", 0.01, memory, 0.01, 0.01, "cache", 0)
+	
+	n := node.NewNode("n", 100, 100*memory, 1000, 1000)
+	previous := s.calculateInterferenceScore(incoming, n)
+	for count := 1; count <= 3; count++ {
+		if !n.AddContainer(container.NewContainer("neighbor", "img", 0.01, memory, 0.01, 0.01, "db", 0)) {
+			t.Fatal("neighbor does not fit")
+		}
+		score := s.calculateInterferenceScore(incoming, n)
+		if score >= previous {
+			t.Errorf("%d memory-intensive neighbors score %g, not below %g for %d", count, score, previous, count-1)
+		}
+		previous = score
+	}
+}
+
+func TestAdaptiveParallelScoringMatchesSerial(t *testing.T) {
+	s := NewAdaptiveScheduler()
+	nodes := benchCluster(2000, 1)
+	for i, c := range benchProbes()[:16] {
+		candidateNodes := candidates(c, nodes)
+		if len(candidateNodes) <= parallelScoringThreshold {
+			t.Fatalf("probe %d has %d candidates, too few to score in parallel", i, len(candidateNodes))
+		}
+		
+		// Node uptime feeds the health score, so scores taken a moment
+		// apart differ in the last digits
+		parallel := s.scoreNodes(c, nodes)
+		best, serialBest, serialScore := 0, 0, math.Inf(-1)
+		for j, n := range candidateNodes {
+			serial := s.calculateFitnessScore(c, n).Score
+			if parallel[j].Node != n || math.Abs(parallel[j].Score-serial) > 1e-6 {
+				t.Fatalf("probe %d: slot %d holds %s at %g, serial scoring gives %s at %g",
+					i, j, parallel[j].Node.Name(), parallel[j].Score, n.Name(), serial)
+			}
+			if parallel[j].Score > parallel[best].Score {
+				best = j
+			}
+			if serial > serialScore {
+				serialBest, serialScore = j, serial
+			}
+		}
+		if best != serialBest {
+			t.Errorf("probe %d: parallel scoring chose %s, serial %s", i, parallel[best].Node.Name(), candidateNodes[serialBest].Name())
+		}
+		
+		chosen, err := s.Schedule(c, nodes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		chosen.AddContainer(c)
+	}
+}
+
+func TestAdaptiveHealthFollowsSustainedLoad(t *testing.T) {
+	s := NewAdaptiveScheduler()
+	n := loadedNode("n", 0)
+	for i := 0; i < 10; i++ {
+		n.ObserveLoad()
+	}
+	n.AddContainer(container.NewContainer("load", "load", 9.5, 9500, 950, 950, "load", 0))
+	afterSpike := s.calculateNodeHealthScore(n)
+	
+	for i := 0; i < 20; i++ {
+		n.ObserveLoad()
+	}
+	if sustained := s.calculateNodeHealthScore(n); sustained >= afterSpike {
+		t.Errorf("health %g after sustained load at %g, not below %g after the first busy sample",
+			sustained, n.SmoothedUtilization(), afterSpike)
+	}
+}
+
+func TestAdaptiveLoadedWeightsSurviveScheduling(t *testing.T) {
+	dir := t.TempDir()
+	saved := NewAdaptiveScheduler()
+	normal := saved.Config().Weights.Normal
+	// A state saved in the normal phase with CPU weighted well above the
+	// configured normal weights
+	state := fmt.Sprintf(`{"version": %d, "phase": 1, "weights": {"cpu": %g, "memory": %g, "network": %g, "io": %g, "disk": %g}}`,
+		adaptiveStateVersion, normal.CPU+0.5, normal.Memory, normal.Network, normal.IO, normal.Disk)
+	path := filepath.Join(dir, "state.json")
+	if err := os.WriteFile(path, []byte(state), 0644); err != nil {
+		t.Fatal(err)
+	}
+	
+	s := NewAdaptiveScheduler()
+	if err := s.LoadState(path); err != nil {
+		t.Fatal(err)
+	}
+	fresh := NewAdaptiveScheduler()
+	nodes := []*node.Node{loadedNode("a", 0.2), loadedNode("b", 0.5)}
+	for _, scheduler := range []*AdaptiveScheduler{s, fresh} {
+		if _, err := scheduler.Schedule(smallContainer(), nodes); err != nil {
+			t.Fatal(err)
+		}
+	}
+	
+	// A new run starts in the startup phase, shifted by the loaded offset
+	startup := s.Config().Weights.Startup
+	want := startup.plus(ResourceWeights{CPU: 0.5})
+	total := want.CPU + want.Memory + want.Network + want.IO + want.Disk
+	got := s.weights()
+	if math.Abs(got.CPU-want.CPU/total) > 1e-9 || math.Abs(got.Memory-want.Memory/total) > 1e-9 {
+		t.Errorf("weights after scheduling %s, want the startup weights shifted by the loaded offset", got)
+	}
+	if got.CPU <= fresh.weights().CPU {
+		t.Errorf("loaded CPU weight %g not above a fresh scheduler's %g", got.CPU, fresh.weights().CPU)
+	}
+	
+	// Saving and loading again keeps the same weights; both schedulers now
+	// shift them by the history of the scheduled type
+	resaved := filepath.Join(dir, "resaved.json")
+	if err := s.SaveState(resaved); err != nil {
+		t.Fatal(err)
+	}
+	reloaded := NewAdaptiveScheduler()
+	if err := reloaded.LoadState(resaved); err != nil {
+		t.Fatal(err)
+	}
+	for _, scheduler := range []*AdaptiveScheduler{s, reloaded} {
+		if _, err := scheduler.Schedule(smallContainer(), nodes); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if reloaded.weights().differs(s.weights(), 1e-9) {
+		t.Errorf("weights after a second save and load %s, want %s", reloaded.weights(), s.weights())
+	}
+}
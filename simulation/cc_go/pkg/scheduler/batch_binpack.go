@@ -0,0 +1,72 @@
+// pkg/scheduler/batch_binpack.go - First-fit-decreasing batch scheduler implementation
+package scheduler
+
+import (
+	"math"
+	"sort"
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+)
+
+// BatchBinPackScheduler packs a whole wave at once using first-fit-decreasing:
+// the largest containers are placed first, each on the first node that fits.
+type BatchBinPackScheduler struct{}
+
+func init() {
+	Register("batchbinpack", withoutOptions("batchbinpack", func() Scheduler {
+		return NewBatchBinPackScheduler()
+	}))
+}
+
+func NewBatchBinPackScheduler() *BatchBinPackScheduler {
+	return &BatchBinPackScheduler{}
+}
+
+func (s *BatchBinPackScheduler) Name() string {
+	return "BatchBinPack"
+}
+
+func (s *BatchBinPackScheduler) Schedule(container *container.Container, nodes []*node.Node) (*node.Node, error) {
+	// A single container is placed first-fit
+	predicates := Predicates()
+	for _, n := range nodes {
+		if admits(container, n, predicates) {
+			return n, nil
+		}
+	}
+
+	return nil, ErrNoSuitableNode
+}
+
+func (s *BatchBinPackScheduler) ScheduleBatch(containers []*container.Container, nodes []*node.Node) (map[*container.Container]*node.Node, error) {
+	sorted := make([]*container.Container, len(containers))
+	copy(sorted, containers)
+
+	// Sort containers by resource demand (descending), relative to the
+	// largest node so the four resources are comparable
+	maxCPU, maxMemory, maxNetwork, maxIO := largestCapacity(nodes)
+	demand := make(map[*container.Container]float64)
+	for _, c := range sorted {
+		demand[c] = node.Ratio(c.CPURequest(), maxCPU) +
+			node.Ratio(c.MemoryRequest(), maxMemory) +
+			node.Ratio(c.NetworkRequest(), maxNetwork) +
+			node.Ratio(c.IORequest(), maxIO)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return demand[sorted[i]] > demand[sorted[j]]
+	})
+
+	return scheduleBatchSequentially(s, sorted, nodes)
+}
+
+// largestCapacity returns the largest total of each resource across nodes
+func largestCapacity(nodes []*node.Node) (cpu, memory, network, io float64) {
+	for _, n := range nodes {
+		cpu = math.Max(cpu, n.TotalCPU())
+		memory = math.Max(memory, n.TotalMemory())
+		network = math.Max(network, n.TotalNetwork())
+		io = math.Max(io, n.TotalIO())
+	}
+
+	return cpu, memory, network, io
+}
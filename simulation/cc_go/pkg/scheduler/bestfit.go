@@ -0,0 +1,68 @@
+// pkg/scheduler/bestfit.go - Best-fit scheduler implementation
+package scheduler
+
+import (
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+)
+
+// BestFitScheduler is textbook best fit: it places each container on the
+// fitting node left with the least free capacity afterwards. Leftover space
+// is measured in absolute terms, each resource normalized by the largest
+// node's capacity, so unlike BinPack a nearly full large node can lose to
+// an emptier small one whose leftover is smaller.
+type BestFitScheduler struct{}
+
+func init() {
+	Register("bestfit", withoutOptions("bestfit", func() Scheduler {
+		return NewBestFitScheduler()
+	}))
+}
+
+func NewBestFitScheduler() *BestFitScheduler {
+	return &BestFitScheduler{}
+}
+
+func (s *BestFitScheduler) Name() string {
+	return "BestFit"
+}
+
+func (s *BestFitScheduler) Schedule(container *container.Container, nodes []*node.Node) (*node.Node, error) {
+	maxCPU, maxMemory, maxNetwork, maxIO := node.MaxCapacity(nodes)
+
+	var best *node.Node
+	var bestLeftover float64
+	for _, n := range candidates(container, nodes) {
+
+		// Unconstrained resources have no meaningful leftover and are skipped
+		cpu, memory, network, io := n.LeftoverAfter(container)
+		leftover := 0.0
+		if n.TotalCPU() > 0 {
+			leftover += node.Ratio(cpu, maxCPU)
+		}
+		if n.TotalMemory() > 0 {
+			leftover += node.Ratio(memory, maxMemory)
+		}
+		if n.TotalNetwork() > 0 {
+			leftover += node.Ratio(network, maxNetwork)
+		}
+		if n.TotalIO() > 0 {
+			leftover += node.Ratio(io, maxIO)
+		}
+
+		if best == nil || leftover < bestLeftover {
+			best = n
+			bestLeftover = leftover
+		}
+	}
+
+	if best == nil {
+		return nil, ErrNoSuitableNode
+	}
+
+	return best, nil
+}
+
+func (s *BestFitScheduler) ScheduleBatch(containers []*container.Container, nodes []*node.Node) (map[*container.Container]*node.Node, error) {
+	return scheduleBatchSequentially(s, containers, nodes)
+}
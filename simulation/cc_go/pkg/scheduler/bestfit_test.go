@@ -0,0 +1,36 @@
+// pkg/scheduler/bestfit_test.go - Best-fit scheduler tests
+package scheduler
+
+import (
+	"testing"
+
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+)
+
+func TestBestFitAndBinPackDisagreeOnNodeSize(t *testing.T) {
+	// The large node is the more utilized, but the small one is left with
+	// less absolute free capacity
+	large := node.NewNode("large", 100, 100000, 10000, 10000)
+	large.AddContainer(container.NewContainer("load", "load", 80, 80000, 8000, 8000, "load", 0))
+	small := node.NewNode("small", 10, 10000, 1000, 1000)
+	small.AddContainer(container.NewContainer("load", "load", 5, 5000, 500, 500, "load", 0))
+	nodes := []*node.Node{large, small}
+	
+	c := container.NewContainer("c", "img", 1, 1000, 100, 100, "web", 0)
+	bestFit, err := NewBestFitScheduler().Schedule(c, nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	binPack, err := NewBinPackScheduler().Schedule(c, nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	
+	if bestFit != small {
+		t.Errorf("BestFit chose %s, want the node with the least leftover (small)", bestFit.Name())
+	}
+	if binPack != large {
+		t.Errorf("BinPack chose %s, want the most utilized node (large)", binPack.Name())
+	}
+}
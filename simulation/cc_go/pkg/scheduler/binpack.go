@@ -7,25 +7,47 @@ import (
 	"cc_go/pkg/node"
 )
 
-type BinPackScheduler struct{}
+type BinPackScheduler struct {
+	dominant bool // sort by bottleneck rather than average utilization
+	resourceWeights [4]float64 // CPU, memory, network and IO weights of the utilization; equal weights give the plain average
+}
+
+func init() {
+	Register("binpack", func(opts Options) (Scheduler, error) {
+		s := NewBinPackScheduler()
+		r := opts.reader("binpack")
+		utilizationOptions(r, s.SetDominantUtilization, s.SetResourceWeights)
+		return r.result(s)
+	})
+}
 
 func NewBinPackScheduler() *BinPackScheduler {
 	return &BinPackScheduler{}
 }
 
+// SetDominantUtilization makes the scheduler rank nodes by their most
+// utilized resource instead of the average across resources
+func (s *BinPackScheduler) SetDominantUtilization(enabled bool) {
+	s.dominant = enabled
+}
+
+// SetResourceWeights weights the CPU, memory, network and IO utilization in
+// the sort key, e.g. {0, 1, 0, 0} to rank nodes on memory alone. It has no
+// effect while dominant utilization is enabled.
+func (s *BinPackScheduler) SetResourceWeights(weights [4]float64) error {
+	if err := validResourceWeights(weights); err != nil {
+		return err
+	}
+	s.resourceWeights = weights
+	return nil
+}
+
 func (s *BinPackScheduler) Name() string {
 	return "BinPack"
 }
 
 func (s *BinPackScheduler) Schedule(container *container.Container, nodes []*node.Node) (*node.Node, error) {
-	candidateNodes := make([]*node.Node, 0)
-	
-	// Filter nodes that can accommodate the container
-	for _, n := range nodes {
-		if n.CanFit(container) {
-			candidateNodes = append(candidateNodes, n)
-		}
-	}
+	candidateNodes := candidates(container, nodes)
 	
 	if len(candidateNodes) == 0 {
 		return nil, ErrNoSuitableNode
@@ -33,9 +55,18 @@ func (s *BinPackScheduler) Schedule(container *container.Container, nodes []*nod
 	
 	// Sort nodes by current utilization (descending)
 	sort.Slice(candidateNodes, func(i, j int) bool {
-		return candidateNodes[i].Utilization() > candidateNodes[j].Utilization()
+		return nodeUtilization(candidateNodes[i], s.dominant, s.resourceWeights) > nodeUtilization(candidateNodes[j], s.dominant, s.resourceWeights)
 	})
 	
 	// Place on the node with highest utilization that can still fit the container
 	return candidateNodes[0], nil
 }
+
+// Explain ranks the fitting nodes by utilization, most utilized first
+func (s *BinPackScheduler) Explain(container *container.Container, nodes []*node.Node) ([]NodeScore, error) {
+	return explainByUtilization(container, nodes, s.dominant, s.resourceWeights, true)
+}
+
+func (s *BinPackScheduler) ScheduleBatch(containers []*container.Container, nodes []*node.Node) (map[*container.Container]*node.Node, error) {
+	return scheduleBatchSequentially(s, containers, nodes)
+}
\ No newline at end of file
@@ -0,0 +1,56 @@
+// pkg/scheduler/binpack_test.go - BinPack and Spread resource weight tests
+package scheduler
+
+import (
+	"testing"
+
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+)
+
+// skewedNodes returns a CPU-heavy node, busier on average, and a
+// memory-heavy one
+func skewedNodes() []*node.Node {
+	cpuHeavy := node.NewNode("cpu-heavy", 10, 10000, 1000, 1000)
+	cpuHeavy.AddContainer(container.NewContainer("cpu-load", "load", 9, 1000, 0, 0, "load", 0))
+	memoryHeavy := node.NewNode("memory-heavy", 10, 10000, 1000, 1000)
+	memoryHeavy.AddContainer(container.NewContainer("memory-load", "load", 1, 6000, 0, 0, "load", 0))
+	return []*node.Node{cpuHeavy, memoryHeavy}
+}
+
+func TestResourceWeightsChangeChosenNode(t *testing.T) {
+	memoryOnly := [4]float64{0, 1, 0, 0}
+	tests := []struct {
+		name    string
+		weights *[4]float64
+		binpack string
+		spread  string
+	}{
+		{"equal weights", nil, "cpu-heavy", "memory-heavy"},
+		{"memory only", &memoryOnly, "memory-heavy", "cpu-heavy"},
+	}
+	for _, tt := range tests {
+		binpack, spread := NewBinPackScheduler(), NewSpreadScheduler()
+		if tt.weights != nil {
+			if err := binpack.SetResourceWeights(*tt.weights); err != nil {
+				t.Fatal(err)
+			}
+			if err := spread.SetResourceWeights(*tt.weights); err != nil {
+				t.Fatal(err)
+			}
+		}
+		
+		for _, check := range []struct {
+			s    Scheduler
+			want string
+		}{{binpack, tt.binpack}, {spread, tt.spread}} {
+			chosen, err := check.s.Schedule(smallContainer(), skewedNodes())
+			if err != nil {
+				t.Fatalf("%s, %s: %v", tt.name, check.s.Name(), err)
+			}
+			if chosen.Name() != check.want {
+				t.Errorf("%s, %s: chose %s, want %s", tt.name, check.s.Name(), chosen.Name(), check.want)
+			}
+		}
+	}
+}
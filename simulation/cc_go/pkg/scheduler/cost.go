@@ -0,0 +1,50 @@
+// pkg/scheduler/cost.go - Cost-aware scheduler implementation
+package scheduler
+
+import (
+	"sort"
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+)
+
+type CostAwareScheduler struct{}
+
+func init() {
+	Register("cost", withoutOptions("cost", func() Scheduler {
+		return NewCostAwareScheduler()
+	}))
+}
+
+func NewCostAwareScheduler() *CostAwareScheduler {
+	return &CostAwareScheduler{}
+}
+
+func (s *CostAwareScheduler) Name() string {
+	return "CostAware"
+}
+
+func (s *CostAwareScheduler) Schedule(container *container.Container, nodes []*node.Node) (*node.Node, error) {
+	candidateNodes := candidates(container, nodes)
+
+	if len(candidateNodes) == 0 {
+		return nil, ErrNoSuitableNode
+	}
+
+	// Sort nodes by hourly cost (ascending), packing nodes of the same price
+	// so cheap capacity is used up before anything more expensive
+	sort.Slice(candidateNodes, func(i, j int) bool {
+		ci := candidateNodes[i].HourlyCost()
+		cj := candidateNodes[j].HourlyCost()
+		if ci != cj {
+			return ci < cj
+		}
+		return candidateNodes[i].Utilization() > candidateNodes[j].Utilization()
+	})
+
+	// Place on the cheapest node that can still fit the container
+	return candidateNodes[0], nil
+}
+
+func (s *CostAwareScheduler) ScheduleBatch(containers []*container.Container, nodes []*node.Node) (map[*container.Container]*node.Node, error) {
+	return scheduleBatchSequentially(s, containers, nodes)
+}
\ No newline at end of file
@@ -0,0 +1,44 @@
+// pkg/scheduler/dryrun.go - Placement queries without side effects
+package scheduler
+
+import (
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+)
+
+// DryRunSchedule returns the node s would place c on, without placing it.
+// s only sees clones of nodes, and placing on a clone leaves c untouched,
+// so neither the cluster nor c changes. The node returned is the one of
+// nodes s chose.
+//
+// It takes the scheduler as an argument, since where c would go depends on
+// which scheduler places it. s itself still runs, so a stateful
+// scheduler may count or learn from the query: pass one whose state does
+// not matter, such as a fresh one from New, rather than a running one.
+func DryRunSchedule(s Scheduler, c *container.Container, nodes []*node.Node) (*node.Node, error) {
+	chosen, err := s.Schedule(c, node.CloneCluster(nodes))
+	if err != nil {
+		return nil, err
+	}
+	if chosen == nil {
+		return nil, ErrNoSuitableNode
+	}
+	for _, n := range nodes {
+		if n.ID() == chosen.ID() {
+			return n, nil
+		}
+	}
+	return nil, ErrNoSuitableNode
+}
+
+// CanFitAnywhere reports whether some node admits c under the predicates
+// every built-in scheduler filters by
+func CanFitAnywhere(c *container.Container, nodes []*node.Node) bool {
+	predicates := Predicates()
+	for _, n := range nodes {
+		if admits(c, n, predicates) {
+			return true
+		}
+	}
+	return false
+}
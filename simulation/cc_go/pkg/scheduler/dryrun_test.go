@@ -0,0 +1,44 @@
+// pkg/scheduler/dryrun_test.go - Placement queries without side effects
+package scheduler
+
+import (
+	"testing"
+
+	"cc_go/pkg/node"
+)
+
+func TestDryRunLeavesContainerAndNodesUntouched(t *testing.T) {
+	for _, name := range List() {
+		s, err := New(name, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodes := []*node.Node{loadedNode("low", 0.2), loadedNode("packed", 0.6), loadedNode("full", 0.9)}
+		c := smallContainer()
+		before := node.Snapshot(nodes)
+		
+		chosen, err := DryRunSchedule(s, c, nodes)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		found := false
+		for _, n := range nodes {
+			found = found || n == chosen
+		}
+		if !found {
+			t.Errorf("%s: chose %s, which is not one of the nodes passed in", name, chosen.Name())
+		}
+		
+		if !c.PlacedAt().IsZero() || c.NodeName() != "" {
+			t.Errorf("%s: dry run marked the container placed at %v on %q", name, c.PlacedAt(), c.NodeName())
+		}
+		if changes := before.Diff(node.Snapshot(nodes)); len(changes) > 0 {
+			t.Errorf("%s: dry run changed the nodes: %+v", name, changes)
+		}
+		for _, n := range nodes {
+			if err := n.CheckAccounting(); err != nil {
+				t.Errorf("%s: %v", name, err)
+			}
+		}
+	}
+}
@@ -1,8 +1,130 @@
 // pkg/scheduler/errors.go - Scheduler error definitions
 package scheduler
 
-import "errors"
+import (
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
 
 var (
 	ErrNoSuitableNode = errors.New("no suitable node found")
 )
+
+// NoFitError is ErrNoSuitableNode with the context needed to see why: the
+// container's request and the most of each resource free on any
+// schedulable node at the time. Unconstrained resources have an infinite
+// maximum. RejectedBy counts the nodes each predicate kept the container
+// off. errors.Is(err, ErrNoSuitableNode) still holds.
+type NoFitError struct {
+	ContainerID   string
+	ContainerType string
+	Requested     ResourceVector
+	MaxFree       ResourceVector
+	RejectedBy    map[string]int
+}
+
+// ResourceVector holds an amount of each resource
+type ResourceVector struct {
+	CPU     float64
+	Memory  float64
+	Network float64
+	IO      float64
+	Disk    float64
+	GPUMemory float64 // on a single GPU, which nodes without GPUs have none of
+}
+
+// NewNoFitError describes the failure to place c on nodes. Free capacity is
+// measured outside each node's reserve; cordoned nodes, and for a pinned
+// container every other node, are skipped.
+func NewNoFitError(c *container.Container, nodes []*node.Node) *NoFitError {
+	e := &NoFitError{
+		ContainerID:   c.ID(),
+		ContainerType: c.Type(),
+		Requested:     ResourceVector{c.CPURequest(), c.MemoryRequest(), c.NetworkRequest(), c.IORequest(), c.DiskRequest(), c.GPUMemoryRequest()},
+		RejectedBy:    make(map[string]int),
+	}
+	
+	_, rejections := FilterExplained(c, nodes, Predicates()...)
+	for _, r := range rejections {
+		e.RejectedBy[r.Predicate]++
+	}
+	
+	for _, n := range nodes {
+		if n.IsCordoned() || !OnNamedNode.Admit(c, n) {
+			continue
+		}
+		e.MaxFree.CPU = math.Max(e.MaxFree.CPU, usable(n, n.AvailableCPU(), n.TotalCPU()))
+		e.MaxFree.Memory = math.Max(e.MaxFree.Memory, usable(n, n.AvailableMemory(), n.TotalMemory()))
+		e.MaxFree.Network = math.Max(e.MaxFree.Network, usable(n, n.AvailableNetwork(), n.TotalNetwork()))
+		e.MaxFree.IO = math.Max(e.MaxFree.IO, usable(n, n.AvailableIO(), n.TotalIO()))
+		e.MaxFree.Disk = math.Max(e.MaxFree.Disk, usable(n, n.AvailableDisk(), n.TotalDisk()))
+		e.MaxFree.GPUMemory = math.Max(e.MaxFree.GPUMemory, n.MaxFreeGPUMemory())
+	}
+	
+	return e
+}
+
+// usable is what n has free of a resource outside its reserve
+func usable(n *node.Node, available, total float64) float64 {
+	if total == 0 {
+		return math.Inf(1)
+	}
+	return math.Max(available-n.Headroom(total), 0)
+}
+
+// Error names the resources no node has enough of, e.g. "wanted 4 CPU, max
+// free anywhere was 3.2"; if every resource fits somewhere, the request
+// only fails in combination or on container caps
+func (e *NoFitError) Error() string {
+	type resource struct {
+		unit            string
+		requested, free float64
+	}
+	resources := []resource{
+		{"CPU", e.Requested.CPU, e.MaxFree.CPU},
+		{"MB memory", e.Requested.Memory, e.MaxFree.Memory},
+		{"Mbps network", e.Requested.Network, e.MaxFree.Network},
+		{"IOPS", e.Requested.IO, e.MaxFree.IO},
+		{"GB disk", e.Requested.Disk, e.MaxFree.Disk},
+	}
+	if e.Requested.GPUMemory > 0 {
+		resources = append(resources, resource{"GB GPU memory", e.Requested.GPUMemory, e.MaxFree.GPUMemory})
+	}
+	
+	short := make([]string, 0)
+	wanted := make([]string, 0)
+	for _, r := range resources {
+		wanted = append(wanted, fmt.Sprintf("%.4g %s", r.requested, r.unit))
+		if r.requested > r.free {
+			short = append(short, fmt.Sprintf("wanted %.4g %s, max free anywhere was %.4g", r.requested, r.unit, r.free))
+		}
+	}
+	
+	detail := strings.Join(short, "; ")
+	if len(short) == 0 {
+		detail = "wanted " + strings.Join(wanted, ", ") + "; each fits somewhere, but not all on one node"
+	}
+	// Name the predicates only when something besides capacity turned nodes away
+	if len(e.RejectedBy) > 1 || (len(e.RejectedBy) == 1 && e.RejectedBy[Fits.Name] == 0) {
+		names := make([]string, 0, len(e.RejectedBy))
+		for name := range e.RejectedBy {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		counts := make([]string, len(names))
+		for i, name := range names {
+			counts[i] = fmt.Sprintf("%s %d", name, e.RejectedBy[name])
+		}
+		detail += "; nodes rejected by " + strings.Join(counts, ", ")
+	}
+	return fmt.Sprintf("%v for container %s (%s): %s", ErrNoSuitableNode, e.ContainerID, e.ContainerType, detail)
+}
+
+func (e *NoFitError) Unwrap() error {
+	return ErrNoSuitableNode
+}
@@ -0,0 +1,66 @@
+// pkg/scheduler/explain.go - Scheduler decision explanations
+package scheduler
+
+import (
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ScoreComponent is one named term that contributed to a node's score
+type ScoreComponent struct {
+	Name  string
+	Value float64
+}
+
+// NodeScore is a candidate node together with the score a scheduler gave it
+type NodeScore struct {
+	Node       *node.Node
+	Score      float64
+	Components []ScoreComponent
+}
+
+func (s NodeScore) String() string {
+	parts := make([]string, len(s.Components))
+	for i, component := range s.Components {
+		parts[i] = fmt.Sprintf("%s=%.3f", component.Name, component.Value)
+	}
+	return fmt.Sprintf("%s score=%.3f (%s)", s.Node.Name(), s.Score, strings.Join(parts, " "))
+}
+
+// Explainer is implemented by schedulers that can report how they rank the
+// candidate nodes for a container. Explain does not place the container;
+// the returned scores are ordered best first, so the first entry is the
+// node Schedule would pick given the same cluster state.
+type Explainer interface {
+	Explain(container *container.Container, nodes []*node.Node) ([]NodeScore, error)
+}
+
+// explainByUtilization ranks the fitting nodes by utilization, which is the
+// whole of the BinPack and Spread decision
+func explainByUtilization(c *container.Container, nodes []*node.Node, dominant bool, weights [4]float64, descending bool) ([]NodeScore, error) {
+	scores := make([]NodeScore, 0)
+	for _, n := range candidates(c, nodes) {
+		utilization := nodeUtilization(n, dominant, weights)
+		scores = append(scores, NodeScore{
+			Node:       n,
+			Score:      utilization,
+			Components: []ScoreComponent{{Name: "utilization", Value: utilization}},
+		})
+	}
+
+	if len(scores) == 0 {
+		return nil, ErrNoSuitableNode
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool {
+		if descending {
+			return scores[i].Score > scores[j].Score
+		}
+		return scores[i].Score < scores[j].Score
+	})
+
+	return scores, nil
+}
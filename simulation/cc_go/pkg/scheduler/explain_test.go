@@ -0,0 +1,42 @@
+// pkg/scheduler/explain_test.go - Node score sanity tests
+package scheduler
+
+import (
+	"math"
+	"testing"
+
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+)
+
+func TestZeroIONodeScoresAreFinite(t *testing.T) {
+	for _, name := range List() {
+		s, err := New(name, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		explainer, ok := s.(Explainer)
+		if !ok {
+			continue
+		}
+		noIO := node.NewNode("no-io", 10, 10000, 1000, 0)
+		noIO.AddContainer(container.NewContainer("load", "load", 2, 2000, 200, 0, "load", 0))
+		nodes := []*node.Node{noIO, node.NewNode("empty-no-io", 10, 10000, 1000, 0)}
+		
+		scores, err := explainer.Explain(container.NewContainer("probe", "probe", 0.1, 10, 1, 0, "web", 0), nodes)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		for _, score := range scores {
+			if math.IsNaN(score.Score) || math.IsInf(score.Score, 0) {
+				t.Errorf("%s: %s", name, score)
+			}
+			for _, component := range score.Components {
+				if math.IsNaN(component.Value) || math.IsInf(component.Value, 0) {
+					t.Errorf("%s: component %s = %g on %s", name, component.Name, component.Value, score.Node.Name())
+				}
+			}
+		}
+	}
+}
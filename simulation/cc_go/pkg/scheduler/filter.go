@@ -0,0 +1,125 @@
+// pkg/scheduler/filter.go - Node filtering before scoring
+package scheduler
+
+import (
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+	"sync"
+)
+
+// Predicate decides whether a node may host a container at all, before any
+// scoring. Name identifies it when explaining why nodes were rejected.
+type Predicate struct {
+	Name  string
+	Admit func(c *container.Container, n *node.Node) bool
+}
+
+// OnNamedNode admits only the node a container is pinned to, by its name
+// or ID, and any node for containers that are not pinned
+var OnNamedNode = Predicate{
+	Name:  "node name",
+	Admit: func(c *container.Container, n *node.Node) bool { return c.NodeName() == "" || n.HasName(c.NodeName()) },
+}
+
+// Fits admits nodes with enough free capacity for the container
+var Fits = Predicate{
+	Name:  "fit",
+	Admit: func(c *container.Container, n *node.Node) bool { return n.CanFit(c) },
+}
+
+// CachesImage admits nodes that already hold the container's image
+var CachesImage = Predicate{
+	Name:  "image",
+	Admit: func(c *container.Container, n *node.Node) bool { return n.HasImage(c.Image()) },
+}
+
+// FitsSocket admits nodes where a single NUMA socket can hold the container
+var FitsSocket = Predicate{
+	Name:  "socket",
+	Admit: func(c *container.Container, n *node.Node) bool { return n.FitsSocket(c) },
+}
+
+var (
+	predicatesMu sync.RWMutex
+	predicates   []Predicate
+)
+
+// RegisterPredicate adds a predicate every built-in scheduler applies after
+// OnNamedNode and Fits, e.g. to keep containers off nodes with a matching taint. Like
+// Register it is meant to be called from init. It panics if admit is nil.
+func RegisterPredicate(p Predicate) {
+	predicatesMu.Lock()
+	defer predicatesMu.Unlock()
+	
+	if p.Admit == nil {
+		panic("scheduler: RegisterPredicate admit is nil for " + p.Name)
+	}
+	// Copy on write, so filters running meanwhile keep a stable slice
+	registered := make([]Predicate, len(predicates), len(predicates)+1)
+	copy(registered, predicates)
+	predicates = append(registered, p)
+}
+
+// Predicates returns what the built-in schedulers filter nodes by:
+// OnNamedNode and Fits followed by the registered predicates
+func Predicates() []Predicate {
+	predicatesMu.RLock()
+	defer predicatesMu.RUnlock()
+	
+	return append([]Predicate{OnNamedNode, Fits}, predicates...)
+}
+
+// Filter returns the nodes every predicate admits, in their original order.
+// Predicates are tried in order and a node's first rejection ends its check.
+func Filter(c *container.Container, nodes []*node.Node, predicates ...Predicate) []*node.Node {
+	admitted := make([]*node.Node, 0, len(nodes))
+	for _, n := range nodes {
+		if rejectedBy(c, n, predicates) == "" {
+			admitted = append(admitted, n)
+		}
+	}
+	return admitted
+}
+
+// Rejection is a node a predicate kept a container off
+type Rejection struct {
+	Node      *node.Node
+	Predicate string
+}
+
+// FilterExplained is Filter that also reports, for every rejected node,
+// the predicate that rejected it
+func FilterExplained(c *container.Container, nodes []*node.Node, predicates ...Predicate) ([]*node.Node, []Rejection) {
+	admitted := make([]*node.Node, 0, len(nodes))
+	rejections := make([]Rejection, 0)
+	for _, n := range nodes {
+		if name := rejectedBy(c, n, predicates); name != "" {
+			rejections = append(rejections, Rejection{Node: n, Predicate: name})
+			continue
+		}
+		admitted = append(admitted, n)
+	}
+	return admitted, rejections
+}
+
+// candidates filters nodes by the built-in predicates plus extra
+func candidates(c *container.Container, nodes []*node.Node, extra ...Predicate) []*node.Node {
+	return Filter(c, nodes, append(Predicates(), extra...)...)
+}
+
+// admits reports whether every predicate admits n, for schedulers that
+// stop at the first acceptable node
+func admits(c *container.Container, n *node.Node, predicates []Predicate) bool {
+	return rejectedBy(c, n, predicates) == ""
+}
+
+// rejectedBy returns the name of the first predicate that rejects n, or ""
+// if all admit it
+func rejectedBy(c *container.Container, n *node.Node, predicates []Predicate) string {
+	for _, p := range predicates {
+		if !p.Admit(c, n) {
+			return p.Name
+		}
+	}
+	return ""
+}
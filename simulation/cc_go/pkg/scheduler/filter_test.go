@@ -0,0 +1,50 @@
+// pkg/scheduler/filter_test.go - Node name pinning tests
+package scheduler
+
+import (
+	"errors"
+	"testing"
+
+	"cc_go/pkg/node"
+)
+
+func TestPinnedContainerLandsOnlyOnItsNode(t *testing.T) {
+	for _, name := range List() {
+		s, err := New(name, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pinned := loadedNode("pinned", 0.8)
+		nodes := []*node.Node{loadedNode("empty", 0), pinned, loadedNode("light", 0.1)}
+
+		for _, target := range []string{pinned.Name(), pinned.ID()} {
+			c := smallContainer()
+			c.SetNodeName(target)
+			chosen, err := s.Schedule(c, nodes)
+			if err != nil {
+				t.Errorf("%s: pinned to %s: %v", name, target, err)
+				continue
+			}
+			if chosen != pinned {
+				t.Errorf("%s: pinned to %s, chose %s", name, target, chosen.Name())
+			}
+		}
+	}
+}
+
+func TestPinnedContainerFailsWhenItsNodeIsFull(t *testing.T) {
+	for _, name := range List() {
+		s, err := New(name, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodes := []*node.Node{loadedNode("empty", 0), loadedNode("full", 1)}
+
+		c := smallContainer()
+		c.SetNodeName("full")
+		chosen, err := s.Schedule(c, nodes)
+		if chosen != nil || !errors.Is(err, ErrNoSuitableNode) {
+			t.Errorf("%s: got %v, %v, want ErrNoSuitableNode", name, chosen, err)
+		}
+	}
+}
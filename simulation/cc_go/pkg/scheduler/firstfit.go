@@ -0,0 +1,66 @@
+// pkg/scheduler/firstfit.go - First-fit scheduler implementation
+package scheduler
+
+import (
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+)
+
+// FirstFitScheduler places each container on the first node, in list order,
+// that can fit it. No sorting is done, so a placement costs at most one
+// pass over the nodes. In next-fit mode the search resumes from the node
+// after the previous placement instead of always starting at the front.
+type FirstFitScheduler struct {
+	nextFit   bool
+	lastIndex int // index of the node used for the previous placement
+}
+
+func init() {
+	Register("firstfit", withoutOptions("firstfit", func() Scheduler {
+		return NewFirstFitScheduler()
+	}))
+	Register("nextfit", withoutOptions("nextfit", func() Scheduler {
+		s := NewFirstFitScheduler()
+		s.SetNextFit(true)
+		return s
+	}))
+}
+
+func NewFirstFitScheduler() *FirstFitScheduler {
+	return &FirstFitScheduler{}
+}
+
+// SetNextFit makes each search start just after the previous placement so
+// the first nodes in the list are not always filled first
+func (s *FirstFitScheduler) SetNextFit(enabled bool) {
+	s.nextFit = enabled
+}
+
+func (s *FirstFitScheduler) Name() string {
+	if s.nextFit {
+		return "NextFit"
+	}
+	return "FirstFit"
+}
+
+func (s *FirstFitScheduler) Schedule(container *container.Container, nodes []*node.Node) (*node.Node, error) {
+	start := 0
+	if s.nextFit && len(nodes) > 0 {
+		start = (s.lastIndex + 1) % len(nodes)
+	}
+	
+	predicates := Predicates()
+	for i := 0; i < len(nodes); i++ {
+		index := (start + i) % len(nodes)
+		if admits(container, nodes[index], predicates) {
+			s.lastIndex = index
+			return nodes[index], nil
+		}
+	}
+	
+	return nil, ErrNoSuitableNode
+}
+
+func (s *FirstFitScheduler) ScheduleBatch(containers []*container.Container, nodes []*node.Node) (map[*container.Container]*node.Node, error) {
+	return scheduleBatchSequentially(s, containers, nodes)
+}
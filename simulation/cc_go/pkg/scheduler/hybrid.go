@@ -0,0 +1,114 @@
+// pkg/scheduler/hybrid.go - Pack-until-threshold-then-spread scheduler
+package scheduler
+
+import (
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+	"fmt"
+	"sort"
+)
+
+// DefaultPackThreshold is the utilization up to which HybridScheduler packs
+const DefaultPackThreshold = 0.7
+
+// HybridScheduler bin-packs nodes until they reach a utilization threshold
+// and spreads once every fitting node has: it picks the most utilized
+// fitting node still below the threshold, and if there is none the least
+// utilized fitting node. Nodes are filled for cost, but never crammed
+// towards 100% while a fresher node is available.
+type HybridScheduler struct {
+	threshold float64
+	dominant bool // compare bottleneck rather than average utilization
+	resourceWeights [4]float64 // CPU, memory, network and IO weights of the utilization
+}
+
+func init() {
+	Register("hybrid", func(opts Options) (Scheduler, error) {
+		s := NewHybridScheduler()
+		r := opts.reader("hybrid")
+		utilizationOptions(r, s.SetDominantUtilization, s.SetResourceWeights)
+		if threshold, ok := r.float("pack_threshold"); ok {
+			r.check("pack_threshold", s.SetThreshold(threshold))
+		}
+		return r.result(s)
+	})
+}
+
+func NewHybridScheduler() *HybridScheduler {
+	return &HybridScheduler{threshold: DefaultPackThreshold}
+}
+
+// SetThreshold sets the utilization below which nodes are still packed; it
+// must be in (0, 1]
+func (s *HybridScheduler) SetThreshold(threshold float64) error {
+	if threshold <= 0 || threshold > 1 {
+		return fmt.Errorf("pack threshold must be in (0, 1], got %g", threshold)
+	}
+	s.threshold = threshold
+	return nil
+}
+
+func (s *HybridScheduler) Threshold() float64 {
+	return s.threshold
+}
+
+// SetDominantUtilization makes the scheduler compare nodes by their most
+// utilized resource instead of the average across resources
+func (s *HybridScheduler) SetDominantUtilization(enabled bool) {
+	s.dominant = enabled
+}
+
+// SetResourceWeights weights the CPU, memory, network and IO utilization
+// compared against the threshold. It has no effect while dominant
+// utilization is enabled.
+func (s *HybridScheduler) SetResourceWeights(weights [4]float64) error {
+	if err := validResourceWeights(weights); err != nil {
+		return err
+	}
+	s.resourceWeights = weights
+	return nil
+}
+
+func (s *HybridScheduler) Name() string {
+	return "Hybrid"
+}
+
+func (s *HybridScheduler) Schedule(container *container.Container, nodes []*node.Node) (*node.Node, error) {
+	scores, err := s.Explain(container, nodes)
+	if err != nil {
+		return nil, err
+	}
+	return scores[0].Node, nil
+}
+
+// Explain ranks the fitting nodes below the threshold first, most utilized
+// first, followed by the rest, least utilized first. A node's score is
+// 1 + utilization below the threshold and 1 - utilization at or above it.
+func (s *HybridScheduler) Explain(container *container.Container, nodes []*node.Node) ([]NodeScore, error) {
+	scores := make([]NodeScore, 0)
+	for _, n := range candidates(container, nodes) {
+		utilization := nodeUtilization(n, s.dominant, s.resourceWeights)
+		score := 1 - utilization
+		if utilization < s.threshold {
+			score = 1 + utilization
+		}
+		scores = append(scores, NodeScore{
+			Node:       n,
+			Score:      score,
+			Components: []ScoreComponent{{Name: "utilization", Value: utilization}},
+		})
+	}
+	
+	if len(scores) == 0 {
+		return nil, ErrNoSuitableNode
+	}
+	
+	sort.SliceStable(scores, func(i, j int) bool {
+		return scores[i].Score > scores[j].Score
+	})
+	return scores, nil
+}
+
+func (s *HybridScheduler) ScheduleBatch(containers []*container.Container, nodes []*node.Node) (map[*container.Container]*node.Node, error) {
+	return scheduleBatchSequentially(s, containers, nodes)
+}
@@ -0,0 +1,115 @@
+// pkg/scheduler/hybrid_test.go - Hybrid scheduler threshold boundary tests
+package scheduler
+
+import (
+	"testing"
+
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+)
+
+// loadedNode returns a node whose CPU, memory, network and IO are all used
+// to the given fraction, so its utilization is that fraction
+func loadedNode(name string, fraction float64) *node.Node {
+	n := node.NewNode(name, 10, 10000, 1000, 1000)
+	if fraction > 0 {
+		n.AddContainer(container.NewContainer(name+"-load", "load", 10*fraction, 10000*fraction, 1000*fraction, 1000*fraction, "load", 0))
+	}
+	return n
+}
+
+func smallContainer() *container.Container {
+	return container.NewContainer("probe", "probe", 0.1, 10, 1, 1, "web", 0)
+}
+
+func TestHybridPacksBelowThreshold(t *testing.T) {
+	s := NewHybridScheduler()
+	nodes := []*node.Node{loadedNode("low", 0.2), loadedNode("packed", 0.6), loadedNode("full", 0.9)}
+	
+	chosen, err := s.Schedule(smallContainer(), nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chosen.Name() != "packed" {
+		t.Errorf("chose %s, want the most utilized node below the threshold (packed)", chosen.Name())
+	}
+}
+
+func TestHybridNodeAtThresholdIsNotPacked(t *testing.T) {
+	s := NewHybridScheduler()
+	atThreshold := loadedNode("at", 0.7)
+	if err := s.SetThreshold(atThreshold.Utilization()); err != nil {
+		t.Fatal(err)
+	}
+	nodes := []*node.Node{atThreshold, loadedNode("below", 0.3)}
+	
+	chosen, err := s.Schedule(smallContainer(), nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chosen.Name() != "below" {
+		t.Errorf("chose %s, want below: a node exactly at the threshold is no longer packed", chosen.Name())
+	}
+}
+
+func TestHybridNodeJustBelowThresholdIsPacked(t *testing.T) {
+	s := NewHybridScheduler()
+	justBelow := loadedNode("just-below", 0.69)
+	if err := s.SetThreshold(justBelow.Utilization() + 1e-9); err != nil {
+		t.Fatal(err)
+	}
+	nodes := []*node.Node{loadedNode("low", 0.3), justBelow, loadedNode("over", 0.8)}
+	
+	chosen, err := s.Schedule(smallContainer(), nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chosen.Name() != "just-below" {
+		t.Errorf("chose %s, want just-below", chosen.Name())
+	}
+}
+
+func TestHybridSpreadsWhenAllAtOrAboveThreshold(t *testing.T) {
+	s := NewHybridScheduler()
+	nodes := []*node.Node{loadedNode("busiest", 0.9), loadedNode("least", 0.75), loadedNode("at", 0.8)}
+	if err := s.SetThreshold(0.75); err != nil {
+		t.Fatal(err)
+	}
+	
+	chosen, err := s.Schedule(smallContainer(), nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chosen.Name() != "least" {
+		t.Errorf("chose %s, want the least utilized node (least)", chosen.Name())
+	}
+}
+
+func TestHybridSkipsNodesThatCannotFit(t *testing.T) {
+	s := NewHybridScheduler()
+	nodes := []*node.Node{loadedNode("packed", 0.6), loadedNode("empty", 0)}
+	big := container.NewContainer("big", "big", 5, 10, 1, 1, "batch", 0)
+	
+	chosen, err := s.Schedule(big, nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chosen.Name() != "empty" {
+		t.Errorf("chose %s, want empty: packed cannot fit the container", chosen.Name())
+	}
+}
+
+func TestHybridThresholdValidation(t *testing.T) {
+	s := NewHybridScheduler()
+	for _, threshold := range []float64{0, -0.1, 1.01} {
+		if err := s.SetThreshold(threshold); err == nil {
+			t.Errorf("SetThreshold(%g) succeeded, want an error", threshold)
+		}
+	}
+	if err := s.SetThreshold(1); err != nil {
+		t.Errorf("SetThreshold(1): %v", err)
+	}
+	if s.Threshold() != 1 {
+		t.Errorf("threshold is %g after SetThreshold(1)", s.Threshold())
+	}
+}
@@ -0,0 +1,89 @@
+// pkg/scheduler/image_locality.go - Image-locality scheduler option
+package scheduler
+
+import (
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+)
+
+// ImageLocalityScheduler wraps another scheduler and, whenever some node
+// that already caches the container's image can fit it, offers the inner
+// scheduler only those nodes. Placing there skips the image pull, cutting
+// startup time; otherwise the inner scheduler sees the whole cluster.
+type ImageLocalityScheduler struct {
+	inner Scheduler
+}
+
+func NewImageLocalityScheduler(inner Scheduler) *ImageLocalityScheduler {
+	return &ImageLocalityScheduler{inner: inner}
+}
+
+func (s *ImageLocalityScheduler) Name() string {
+	return s.inner.Name() + "+ImageLocality"
+}
+
+func (s *ImageLocalityScheduler) Schedule(c *container.Container, nodes []*node.Node) (*node.Node, error) {
+	cached := candidates(c, nodes, CachesImage)
+	
+	if len(cached) > 0 {
+		if n, err := s.inner.Schedule(c, cached); err == nil && n != nil {
+			return n, nil
+		}
+	}
+	
+	return s.inner.Schedule(c, nodes)
+}
+
+// ScheduleBatch lets the inner scheduler plan the wave, so a batch scheduler
+// keeps its packing, then moves every container planned onto a node without
+// its image to a node caching it, if one has room next to the rest of the
+// plan. The inner scheduler picks among those nodes as in Schedule.
+func (s *ImageLocalityScheduler) ScheduleBatch(containers []*container.Container, nodes []*node.Node) (map[*container.Container]*node.Node, error) {
+	placements, err := s.inner.ScheduleBatch(containers, nodes)
+	if err != nil {
+		return placements, err
+	}
+	
+	// Replay the plan on copies of the nodes, so moves see what it leaves
+	// free without touching the live nodes
+	clones := node.CloneCluster(nodes)
+	live := make(map[*node.Node]*node.Node, len(nodes))
+	planned := make(map[*node.Node]*node.Node, len(nodes))
+	for i, clone := range clones {
+		live[clone] = nodes[i]
+		planned[nodes[i]] = clone
+	}
+	for _, c := range containers {
+		if n, ok := placements[c]; ok && planned[n] != nil {
+			planned[n].AddContainer(c)
+		}
+	}
+	
+	for _, c := range containers {
+		from := planned[placements[c]]
+		if from == nil || from.HasImage(c.Image()) {
+			continue
+		}
+		
+		from.RemoveContainerRef(c)
+		to := from
+		if cached := candidates(c, clones, CachesImage); len(cached) > 0 {
+			if n, err := s.inner.Schedule(c, cached); err == nil && n != nil {
+				to = n
+			}
+		}
+		to.AddContainer(c)
+		placements[c] = live[to]
+	}
+	
+	return placements, nil
+}
+
+// Unwrap returns the scheduler an ImageLocalityScheduler wraps, or s itself,
+// so callers can reach scheduler-specific settings behind the option
+func Unwrap(s Scheduler) Scheduler {
+	if locality, ok := s.(*ImageLocalityScheduler); ok {
+		return locality.inner
+	}
+	return s
+}
@@ -0,0 +1,97 @@
+// pkg/scheduler/image_locality_test.go - Image-locality batch tests
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+)
+
+func imageWave() []*container.Container {
+	return []*container.Container{
+		container.NewContainer("big", "other", 5, 100, 1, 1, "batch", 0),
+		container.NewContainer("api-1", "api", 2, 100, 1, 1, "web", 0),
+		container.NewContainer("api-2", "api", 2, 100, 1, 1, "web", 0),
+		container.NewContainer("small", "other", 1, 100, 1, 1, "batch", 0),
+	}
+}
+
+func emptyCluster(size int) []*node.Node {
+	nodes := make([]*node.Node, size)
+	for i := range nodes {
+		nodes[i] = node.NewNode(fmt.Sprintf("node-%d", i), 10, 10000, 1000, 1000)
+	}
+	return nodes
+}
+
+func TestImageLocalityBatchKeepsInnerPlanWithoutCachedImages(t *testing.T) {
+	nodes := emptyCluster(3)
+	wave := imageWave()
+	want, err := NewBatchBinPackScheduler().ScheduleBatch(wave, nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := NewImageLocalityScheduler(NewBatchBinPackScheduler()).ScheduleBatch(wave, nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	
+	for _, c := range wave {
+		if got[c] != want[c] {
+			t.Errorf("%s planned on %v, the inner scheduler planned %v", c.Name(), got[c], want[c])
+		}
+	}
+}
+
+func TestImageLocalityBatchMovesContainersToCachedImages(t *testing.T) {
+	nodes := emptyCluster(3)
+	nodes[2].CacheImage("api")
+	wave := imageWave()
+	
+	placements, err := NewImageLocalityScheduler(NewBatchBinPackScheduler()).ScheduleBatch(wave, nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range wave {
+		n := placements[c]
+		switch {
+		case n == nil:
+			t.Errorf("%s was not placed", c.Name())
+		case c.Image() == "api" && n != nodes[2]:
+			t.Errorf("%s planned on %s, not on the node caching its image", c.Name(), n.Name())
+		case c.Image() == "other" && n != nodes[0]:
+			t.Errorf("%s planned on %s, not where first-fit-decreasing put it", c.Name(), n.Name())
+		}
+	}
+	for _, n := range nodes {
+		if n.ContainerCount() > 0 {
+			t.Errorf("planning left containers on live node %s", n.Name())
+		}
+	}
+}
+
+func TestImageLocalityBatchRespectsCachedNodeCapacity(t *testing.T) {
+	nodes := emptyCluster(2)
+	nodes[1].CacheImage("api")
+	nodes[1].AddContainer(container.NewContainer("resident", "other", 7, 100, 1, 1, "batch", 0))
+	
+	// Only one 2-CPU container fits next to the resident
+	placements, err := NewImageLocalityScheduler(NewBatchBinPackScheduler()).ScheduleBatch(imageWave(), nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	onCached := 0
+	for c, n := range placements {
+		if n == nodes[1] {
+			onCached++
+			if c.Image() != "api" {
+				t.Errorf("%s moved to the caching node", c.Name())
+			}
+		}
+	}
+	if onCached != 1 {
+		t.Errorf("%d containers planned on the caching node with room for one", onCached)
+	}
+}
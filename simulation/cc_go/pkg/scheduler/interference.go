@@ -0,0 +1,226 @@
+// pkg/scheduler/interference.go - Interference penalties learned from feedback
+package scheduler
+
+import (
+	"cc_go/pkg/clock"
+	"cc_go/pkg/node"
+	"encoding/csv"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// FeedbackReceiver is implemented by schedulers that learn from how their
+// placements turned out. The benchmark hands it the cluster after every
+// cleanup round.
+type FeedbackReceiver interface {
+	Feedback(nodes []*node.Node)
+}
+
+// interferenceSnapshotEvery is how many feedback rounds pass between two
+// entries of the interference history
+const interferenceSnapshotEvery = 10
+
+// InterferenceModel learns a penalty for each pair of container types from
+// the load variance of the nodes they share. After every feedback round
+// each pair co-located on a node moves its estimate towards that node's
+// instability, so pairs that keep turning up on unstable nodes end up
+// penalized while pairs that coexist quietly drift towards zero.
+type InterferenceModel struct {
+	mu        sync.RWMutex
+	prior     float64 // penalty of a pair never observed together
+	rate      float64 // fraction of the gap closed per observation
+	scale     float64 // load variance counted as fully unstable
+	ceiling   float64 // penalty of a pair that always destabilizes its node
+	estimates map[string]float64
+	rounds    int
+	start     time.Time
+	clock     clock.Clock // the run's clock; the wall clock unless set
+	history   []InterferenceSnapshot
+}
+
+// InterferenceSnapshot is the learned matrix at one point of the run
+type InterferenceSnapshot struct {
+	Offset    time.Duration
+	Estimates map[string]float64
+}
+
+func NewInterferenceModel() *InterferenceModel {
+	return &InterferenceModel{
+		prior:     0.1,
+		rate:      0.05,
+		scale:     0.15,
+		ceiling:   0.3,
+		estimates: make(map[string]float64),
+		start:     time.Now(),
+		clock:     clock.Real{},
+	}
+}
+
+// SetClock has snapshot offsets and readiness judged by c, counting from
+// now on c
+func (m *InterferenceModel) SetClock(c clock.Clock) {
+	m.clock = c
+	m.start = c.Now()
+}
+
+// Prior is the penalty of a pair that has not been observed yet
+func (m *InterferenceModel) Prior() float64 {
+	return m.prior
+}
+
+func (m *InterferenceModel) SetRate(rate float64) {
+	m.rate = rate
+}
+
+// pairKey names an unordered pair of container types
+func pairKey(a, b string) string {
+	if b < a {
+		a, b = b, a
+	}
+	return a + "+" + b
+}
+
+// Penalty is the learned interference between containers of types a and b
+func (m *InterferenceModel) Penalty(a, b string) float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	
+	if estimate, ok := m.estimates[pairKey(a, b)]; ok {
+		return estimate
+	}
+	return m.prior
+}
+
+// Observe updates every type pair running together on n with the node's
+// current load variance
+func (m *InterferenceModel) Observe(n *node.Node) {
+	now := m.clock.Now()
+	counts := make(map[string]int)
+	for _, c := range n.Containers() {
+		if c.IsReady(now) {
+			counts[c.Type()]++
+		}
+	}
+	
+	types := make([]string, 0, len(counts))
+	for t := range counts {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	
+	instability := n.LoadVariance() / m.scale
+	if instability > 1 {
+		instability = 1
+	}
+	target := instability * m.ceiling
+	
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	
+	for i, a := range types {
+		for _, b := range types[i:] {
+			if a == b && counts[a] < 2 {
+				continue
+			}
+			key := pairKey(a, b)
+			estimate, ok := m.estimates[key]
+			if !ok {
+				estimate = m.prior
+			}
+			m.estimates[key] = estimate + m.rate*(target-estimate)
+		}
+	}
+}
+
+// Feedback observes every node and periodically records the matrix
+func (m *InterferenceModel) Feedback(nodes []*node.Node) {
+	for _, n := range nodes {
+		m.Observe(n)
+	}
+	
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	
+	m.rounds++
+	if m.rounds%interferenceSnapshotEvery == 0 {
+		m.history = append(m.history, InterferenceSnapshot{
+			Offset:    m.clock.Now().Sub(m.start),
+			Estimates: m.copyEstimates(),
+		})
+	}
+}
+
+func (m *InterferenceModel) copyEstimates() map[string]float64 {
+	estimates := make(map[string]float64, len(m.estimates))
+	for key, estimate := range m.estimates {
+		estimates[key] = estimate
+	}
+	return estimates
+}
+
+// Estimates returns a copy of the learned penalties keyed by "typeA+typeB"
+func (m *InterferenceModel) Estimates() map[string]float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	
+	return m.copyEstimates()
+}
+
+// SetEstimates replaces the learned penalties, e.g. with a saved state
+func (m *InterferenceModel) SetEstimates(estimates map[string]float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	
+	m.estimates = make(map[string]float64, len(estimates))
+	for key, estimate := range estimates {
+		m.estimates[key] = estimate
+	}
+}
+
+func (m *InterferenceModel) History() []InterferenceSnapshot {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	
+	return m.history
+}
+
+// SaveHistoryCSV writes one row per pair and snapshot, showing how the
+// learned matrix evolved over the run
+func (m *InterferenceModel) SaveHistoryCSV(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+	
+	if err := writer.Write([]string{"Offset(s)", "Pair", "Penalty"}); err != nil {
+		return err
+	}
+	
+	for _, snapshot := range m.History() {
+		pairs := make([]string, 0, len(snapshot.Estimates))
+		for pair := range snapshot.Estimates {
+			pairs = append(pairs, pair)
+		}
+		sort.Strings(pairs)
+		
+		for _, pair := range pairs {
+			record := []string{
+				strconv.FormatFloat(snapshot.Offset.Seconds(), 'f', 3, 64),
+				pair,
+				strconv.FormatFloat(snapshot.Estimates[pair], 'f', 4, 64),
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	
+	return writer.Error()
+}
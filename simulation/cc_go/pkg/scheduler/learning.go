@@ -0,0 +1,38 @@
+// pkg/scheduler/learning.go - Adaptive scheduler with learned interference
+package scheduler
+
+import (
+	"cc_go/pkg/node"
+)
+
+func init() {
+	Register("learning", func(opts Options) (Scheduler, error) {
+		s := NewLearningScheduler()
+		return opts.reader("learning").adaptive(s)
+	})
+}
+
+// NewLearningScheduler returns an adaptive scheduler whose interference
+// penalties are learned online from node load variance instead of taken
+// from the fixed per-resource constants
+func NewLearningScheduler() *AdaptiveScheduler {
+	s := NewAdaptiveScheduler()
+	s.name = "Learning"
+	s.interference = NewInterferenceModel()
+	return s
+}
+
+// Interference returns the learned interference model, or nil when the
+// scheduler uses the fixed penalties
+func (s *AdaptiveScheduler) Interference() *InterferenceModel {
+	return s.interference
+}
+
+// Feedback rewards or punishes past co-location decisions by how stable
+// the nodes they produced turned out; it does nothing without learning
+func (s *AdaptiveScheduler) Feedback(nodes []*node.Node) {
+	if s.interference == nil {
+		return
+	}
+	s.interference.Feedback(nodes)
+}
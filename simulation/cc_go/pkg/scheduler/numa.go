@@ -0,0 +1,46 @@
+// pkg/scheduler/numa.go - NUMA-aware scheduler implementation
+package scheduler
+
+import (
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+)
+
+// NUMAAwareScheduler bin-packs like BinPack, but only places a NUMA-pinned
+// container on a node where a single socket can hold its whole CPU and
+// memory request, so it never runs split across sockets. Unpinned
+// containers are placed on aggregate capacity as usual.
+type NUMAAwareScheduler struct {
+	binpack *BinPackScheduler
+}
+
+func init() {
+	Register("numa", withoutOptions("numa", func() Scheduler {
+		return NewNUMAAwareScheduler()
+	}))
+}
+
+func NewNUMAAwareScheduler() *NUMAAwareScheduler {
+	return &NUMAAwareScheduler{binpack: NewBinPackScheduler()}
+}
+
+func (s *NUMAAwareScheduler) Name() string {
+	return "NUMAAware"
+}
+
+func (s *NUMAAwareScheduler) Schedule(container *container.Container, nodes []*node.Node) (*node.Node, error) {
+	if !container.NUMAPinned() {
+		return s.binpack.Schedule(container, nodes)
+	}
+	
+	candidates := candidates(container, nodes, FitsSocket)
+	if len(candidates) == 0 {
+		return nil, ErrNoSuitableNode
+	}
+	
+	return s.binpack.Schedule(container, candidates)
+}
+
+func (s *NUMAAwareScheduler) ScheduleBatch(containers []*container.Container, nodes []*node.Node) (map[*container.Container]*node.Node, error) {
+	return scheduleBatchSequentially(s, containers, nodes)
+}
@@ -0,0 +1,23 @@
+// pkg/scheduler/observer.go - Scheduler state change reporting
+package scheduler
+
+// StateEvent describes one change of a scheduler's internal state
+type StateEvent struct {
+	Scheduler string
+	Kind      string // what changed, e.g. "phase" or "weights"
+	From      string // empty for the initial state
+	To        string
+	Detail    string
+}
+
+// SchedulerObserver receives the state changes of a scheduler, e.g. to
+// record them next to the placements they influenced
+type SchedulerObserver interface {
+	StateChanged(event StateEvent)
+}
+
+// Observable is implemented by schedulers that report their state changes;
+// a nil observer stops the reports
+type Observable interface {
+	SetObserver(observer SchedulerObserver)
+}
@@ -0,0 +1,281 @@
+// pkg/scheduler/optimizing.go - Simulated annealing batch scheduler implementation
+package scheduler
+
+import (
+	"math"
+	"math/rand"
+	"time"
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+)
+
+// OptimizingScheduler is an offline baseline: it starts from the
+// first-fit-decreasing plan for a wave and improves it with simulated
+// annealing, minimizing the number of unplaced containers plus the spread
+// of utilization across nodes. It is slow, but gives a quality ceiling the
+// online schedulers can be compared against.
+type OptimizingScheduler struct {
+	iterations  int
+	temperature float64 // initial temperature
+	cooling     float64 // temperature multiplier applied every iteration
+	rng         *rand.Rand
+
+	startObjective float64 // objective of the first-fit-decreasing plan, summed over waves
+	finalObjective float64 // objective after annealing, summed over waves
+	waves          int
+}
+
+func init() {
+	Register("optimizing", func(opts Options) (Scheduler, error) {
+		s := NewOptimizingScheduler()
+		r := opts.reader("optimizing")
+		if iterations, ok := r.int("iterations"); ok {
+			if iterations < 0 {
+				r.fail("iterations", "must not be negative, got %d", iterations)
+			}
+			s.SetIterations(iterations)
+		}
+		if temperature, ok := r.float("temperature"); ok {
+			if temperature <= 0 {
+				r.fail("temperature", "must be positive, got %g", temperature)
+			}
+			s.temperature = temperature
+		}
+		if cooling, ok := r.float("cooling"); ok {
+			if cooling <= 0 || cooling > 1 {
+				r.fail("cooling", "must be in (0, 1], got %g", cooling)
+			}
+			s.cooling = cooling
+		}
+		return r.result(s)
+	})
+}
+
+func NewOptimizingScheduler() *OptimizingScheduler {
+	return &OptimizingScheduler{
+		iterations:  2000,
+		temperature: 1.0,
+		cooling:     0.995,
+		rng:         rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (s *OptimizingScheduler) SetIterations(iterations int) {
+	s.iterations = iterations
+}
+
+// SetCooling sets the starting temperature and the factor it is multiplied
+// by after each iteration (between 0 and 1; closer to 1 cools slower)
+func (s *OptimizingScheduler) SetCooling(temperature, rate float64) {
+	s.temperature = temperature
+	s.cooling = rate
+}
+
+func (s *OptimizingScheduler) SetSeed(seed int64) {
+	s.rng = rand.New(rand.NewSource(seed))
+}
+
+func (s *OptimizingScheduler) Name() string {
+	return "Optimizing"
+}
+
+// Objective returns the mean objective per wave before and after annealing;
+// lower is better
+func (s *OptimizingScheduler) Objective() (start, final float64) {
+	if s.waves == 0 {
+		return 0, 0
+	}
+	return s.startObjective / float64(s.waves), s.finalObjective / float64(s.waves)
+}
+
+func (s *OptimizingScheduler) Schedule(c *container.Container, nodes []*node.Node) (*node.Node, error) {
+	placements, err := s.ScheduleBatch([]*container.Container{c}, nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	return placements[c], nil
+}
+
+func (s *OptimizingScheduler) ScheduleBatch(containers []*container.Container, nodes []*node.Node) (map[*container.Container]*node.Node, error) {
+	// Start from the first-fit-decreasing plan
+	initial, _ := NewBatchBinPackScheduler().ScheduleBatch(containers, nodes)
+
+	plan := newAnnealPlan(containers, nodes)
+	for i, c := range containers {
+		if n, ok := initial[c]; ok {
+			for j := range nodes {
+				if nodes[j] == n {
+					plan.place(i, j)
+					break
+				}
+			}
+		}
+	}
+
+	current := plan.objective()
+	s.startObjective += current
+	best := current
+	bestAssignment := append([]int(nil), plan.assignment...)
+
+	temperature := s.temperature
+	for iter := 0; iter < s.iterations && len(containers) > 0; iter++ {
+		// Move a random container to a random node (or off the cluster)
+		i := s.rng.Intn(len(containers))
+		from := plan.assignment[i]
+		to := s.rng.Intn(len(nodes)+1) - 1
+		if to == from || (to >= 0 && !plan.fits(i, to)) {
+			temperature *= s.cooling
+			continue
+		}
+
+		plan.move(i, to)
+		candidate := plan.objective()
+		delta := candidate - current
+		if delta <= 0 || (temperature > 0 && s.rng.Float64() < math.Exp(-delta/temperature)) {
+			current = candidate
+			if current < best {
+				best = current
+				copy(bestAssignment, plan.assignment)
+			}
+		} else {
+			plan.move(i, from)
+		}
+
+		temperature *= s.cooling
+	}
+
+	s.finalObjective += best
+	s.waves++
+
+	placements := make(map[*container.Container]*node.Node)
+	for i, j := range bestAssignment {
+		if j >= 0 {
+			placements[containers[i]] = nodes[j]
+		}
+	}
+
+	if len(placements) == 0 && len(containers) > 0 {
+		return placements, ErrNoSuitableNode
+	}
+
+	return placements, nil
+}
+
+// annealPlan is a tentative assignment of containers to nodes with its own
+// resource bookkeeping, so the real nodes are never touched while searching
+type annealPlan struct {
+	assignment []int          // node index per container, -1 when unplaced
+	requests   [][5]float64   // cpu, memory, network, io, disk per container
+	totals     [][5]float64   // capacity per node
+	usable     [][5]float64   // capacity outside the node's reserve
+	used       [][5]float64   // usage per node, including existing containers
+	cordoned   []bool
+	counts     []int // containers per node, including existing ones
+	limits     []int // node container caps, 0 for none
+	pinned     []bool // keeps its initial node: pinned to it by name, or needing GPU memory, which the plan does not track per GPU
+}
+
+func newAnnealPlan(containers []*container.Container, nodes []*node.Node) *annealPlan {
+	plan := &annealPlan{
+		assignment: make([]int, len(containers)),
+		requests:   make([][5]float64, len(containers)),
+		totals:     make([][5]float64, len(nodes)),
+		usable:     make([][5]float64, len(nodes)),
+		used:       make([][5]float64, len(nodes)),
+		cordoned:   make([]bool, len(nodes)),
+		counts:     make([]int, len(nodes)),
+		limits:     make([]int, len(nodes)),
+		pinned:     make([]bool, len(containers)),
+	}
+
+	for i, c := range containers {
+		plan.assignment[i] = -1
+		plan.requests[i] = [5]float64{c.CPURequest(), c.MemoryRequest(), c.NetworkRequest(), c.IORequest(), c.DiskRequest()}
+		plan.pinned[i] = c.GPUMemoryRequest() > 0 || c.NodeName() != ""
+	}
+	for j, n := range nodes {
+		plan.totals[j] = [5]float64{n.TotalCPU(), n.TotalMemory(), n.TotalNetwork(), n.TotalIO(), n.TotalDisk()}
+		for r, total := range plan.totals[j] {
+			plan.usable[j][r] = total - n.Headroom(total)
+		}
+		plan.used[j] = [5]float64{
+			n.TotalCPU() - n.AvailableCPU(),
+			n.TotalMemory() - n.AvailableMemory(),
+			n.TotalNetwork() - n.AvailableNetwork(),
+			n.TotalIO() - n.AvailableIO(),
+			n.TotalDisk() - n.AvailableDisk(),
+		}
+		plan.cordoned[j] = n.IsCordoned()
+		plan.counts[j] = n.ContainerCount()
+		plan.limits[j] = n.MaxContainers()
+	}
+
+	return plan
+}
+
+func (p *annealPlan) fits(i, j int) bool {
+	if p.pinned[i] || p.cordoned[j] || (p.limits[j] > 0 && p.counts[j] >= p.limits[j]) {
+		return false
+	}
+	for r := range p.requests[i] {
+		if p.totals[j][r] != 0 && p.used[j][r]+p.requests[i][r] > p.usable[j][r] {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *annealPlan) place(i, j int) {
+	p.assignment[i] = j
+	p.counts[j]++
+	for r := range p.requests[i] {
+		p.used[j][r] += p.requests[i][r]
+	}
+}
+
+func (p *annealPlan) move(i, j int) {
+	if from := p.assignment[i]; from >= 0 {
+		p.counts[from]--
+		for r := range p.requests[i] {
+			p.used[from][r] -= p.requests[i][r]
+		}
+	}
+	p.assignment[i] = -1
+	if j >= 0 {
+		p.place(i, j)
+	}
+}
+
+// objective is the number of unplaced containers plus the standard
+// deviation of node utilization; placing everything always dominates
+func (p *annealPlan) objective() float64 {
+	unplaced := 0
+	for _, j := range p.assignment {
+		if j < 0 {
+			unplaced++
+		}
+	}
+
+	utilizations := make([]float64, len(p.totals))
+	mean := 0.0
+	for j := range p.totals {
+		// Utilization averages the same four resources as Node.Utilization
+		for r := 0; r < 4; r++ {
+			utilizations[j] += node.Ratio(p.used[j][r], p.totals[j][r]) / 4.0
+		}
+		mean += utilizations[j]
+	}
+	if len(utilizations) == 0 {
+		return float64(unplaced)
+	}
+	mean /= float64(len(utilizations))
+
+	variance := 0.0
+	for _, u := range utilizations {
+		variance += (u - mean) * (u - mean)
+	}
+	variance /= float64(len(utilizations))
+
+	return float64(unplaced) + math.Sqrt(variance)
+}
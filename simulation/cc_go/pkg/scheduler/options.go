@@ -0,0 +1,182 @@
+// pkg/scheduler/options.go - Scheduler specific settings
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Options are one scheduler's own settings, its section of scheduler_config
+// in an experiment config. Values are as decoded from JSON (numbers are
+// float64, lists []any), although Go ints and float slices work as well.
+type Options map[string]any
+
+// optionReader reads Options for one scheduler, keeping the first error
+// and which keys were read so done can reject the rest
+type optionReader struct {
+	scheduler string
+	opts      Options
+	read      []string
+	err       error
+}
+
+func (o Options) reader(scheduler string) *optionReader {
+	return &optionReader{scheduler: scheduler, opts: o}
+}
+
+// value returns the value of key if it is set, recording key as known
+func (r *optionReader) value(key string) (any, bool) {
+	r.read = append(r.read, key)
+	v, ok := r.opts[key]
+	return v, ok && v != nil
+}
+
+func (r *optionReader) fail(key string, format string, args ...any) {
+	if r.err == nil {
+		r.err = fmt.Errorf("%s option %s: %s", r.scheduler, key, fmt.Sprintf(format, args...))
+	}
+}
+
+func (r *optionReader) float(key string) (float64, bool) {
+	v, ok := r.value(key)
+	if !ok {
+		return 0, false
+	}
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	}
+	r.fail(key, "want a number, got %v", v)
+	return 0, false
+}
+
+func (r *optionReader) int(key string) (int, bool) {
+	f, ok := r.float(key)
+	if ok && f != float64(int(f)) {
+		r.fail(key, "want a whole number, got %g", f)
+		return 0, false
+	}
+	return int(f), ok
+}
+
+func (r *optionReader) bool(key string) (bool, bool) {
+	v, ok := r.value(key)
+	if !ok {
+		return false, false
+	}
+	if b, isBool := v.(bool); isBool {
+		return b, true
+	}
+	r.fail(key, "want true or false, got %v", v)
+	return false, false
+}
+
+// duration reads a string such as "90s"
+func (r *optionReader) duration(key string) (time.Duration, bool) {
+	v, ok := r.value(key)
+	if !ok {
+		return 0, false
+	}
+	if s, isString := v.(string); isString {
+		d, err := time.ParseDuration(s)
+		if err == nil {
+			return d, true
+		}
+	}
+	r.fail(key, "want a duration such as \"90s\", got %v", v)
+	return 0, false
+}
+
+// floats reads a list of exactly n numbers
+func (r *optionReader) floats(key string, n int) ([]float64, bool) {
+	v, ok := r.value(key)
+	if !ok {
+		return nil, false
+	}
+	var values []float64
+	switch list := v.(type) {
+	case []float64:
+		values = list
+	case []any:
+		for _, item := range list {
+			f, isFloat := item.(float64)
+			if !isFloat {
+				r.fail(key, "want a list of numbers, got %v", v)
+				return nil, false
+			}
+			values = append(values, f)
+		}
+	default:
+		r.fail(key, "want a list of numbers, got %v", v)
+		return nil, false
+	}
+	if len(values) != n {
+		r.fail(key, "want %d numbers, got %d", n, len(values))
+		return nil, false
+	}
+	return values, true
+}
+
+// check records err, from applying a value that was read, against key
+func (r *optionReader) check(key string, err error) {
+	if err != nil {
+		r.fail(key, "%v", err)
+	}
+}
+
+// done returns the first error, or an error naming a key that was never
+// read and the keys that were
+func (r *optionReader) done() error {
+	if r.err != nil {
+		return r.err
+	}
+	known := make(map[string]bool, len(r.read))
+	for _, key := range r.read {
+		known[key] = true
+	}
+	unknown := make([]string, 0)
+	for key := range r.opts {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	if len(r.read) == 0 {
+		return fmt.Errorf("%s takes no options, got %s", r.scheduler, strings.Join(unknown, ", "))
+	}
+	return fmt.Errorf("unknown %s option %s (accepted: %s)", r.scheduler, strings.Join(unknown, ", "), strings.Join(r.read, ", "))
+}
+
+// result returns s, or the first error and unknown key instead
+func (r *optionReader) result(s Scheduler) (Scheduler, error) {
+	if err := r.done(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// withoutOptions adapts the constructor of a scheduler that takes no
+// options to a Factory
+func withoutOptions(name string, newScheduler func() Scheduler) Factory {
+	return func(opts Options) (Scheduler, error) {
+		return opts.reader(name).result(newScheduler())
+	}
+}
+
+// utilizationOptions applies the dominant and resource_weights options
+// shared by the schedulers ranking nodes by their utilization
+func utilizationOptions(r *optionReader, setDominant func(bool), setWeights func([4]float64) error) {
+	if dominant, ok := r.bool("dominant"); ok {
+		setDominant(dominant)
+	}
+	if weights, ok := r.floats("resource_weights", 4); ok {
+		r.check("resource_weights", setWeights([4]float64{weights[0], weights[1], weights[2], weights[3]}))
+	}
+}
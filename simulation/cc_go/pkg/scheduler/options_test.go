@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func decodeOptions(t *testing.T, data string) Options {
+	t.Helper()
+	var opts Options
+	if err := json.Unmarshal([]byte(data), &opts); err != nil {
+		t.Fatal(err)
+	}
+	return opts
+}
+
+func TestEveryFactoryAcceptsNoOptions(t *testing.T) {
+	for _, name := range List() {
+		if _, err := New(name, nil); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+func TestOptionsConfigureScheduler(t *testing.T) {
+	s, err := New("hybrid", decodeOptions(t, `{"pack_threshold": 0.5, "dominant": true, "resource_weights": [0, 1, 0, 0]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hybrid := s.(*HybridScheduler)
+	if hybrid.Threshold() != 0.5 || !hybrid.dominant || hybrid.resourceWeights != [4]float64{0, 1, 0, 0} {
+		t.Errorf("options not applied: threshold %g, dominant %v, weights %v", hybrid.Threshold(), hybrid.dominant, hybrid.resourceWeights)
+	}
+	
+	s, err = New("adaptive", decodeOptions(t, `{"blend": [0.5, 0.3, 0.2], "high_load_after": "5m"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg := s.(*AdaptiveScheduler).Config(); cfg.Blend.Interference != 0.3 || cfg.HighLoadAfter.Minutes() != 5 {
+		t.Errorf("options not applied: %+v", cfg)
+	}
+}
+
+func TestOptionsRejectUnknownAndInvalid(t *testing.T) {
+	cases := []struct {
+		name, options, want string
+	}{
+		{"binpack", `{"pack_threshold": 0.5}`, "unknown binpack option pack_threshold"},
+		{"firstfit", `{"dominant": true}`, "firstfit takes no options"},
+		{"hybrid", `{"pack_threshold": 1.5}`, "pack threshold must be in (0, 1]"},
+		{"optimizing", `{"iterations": "many"}`, "want a number"},
+		{"optimizing", `{"iterations": 2.5}`, "want a whole number"},
+		{"spread", `{"resource_weights": [1, 1]}`, "want 4 numbers"},
+		{"adaptive", `{"blend": [0.5, 0.5, 0.5]}`, "must sum to 1"},
+		{"adaptive", `{"startup_phase": 60}`, "want a duration"},
+	}
+	for _, tc := range cases {
+		_, err := New(tc.name, decodeOptions(t, tc.options))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s %s: got error %v, want one containing %q", tc.name, tc.options, err, tc.want)
+		}
+	}
+}
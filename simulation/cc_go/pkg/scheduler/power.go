@@ -0,0 +1,77 @@
+// pkg/scheduler/power.go - Energy-aware scheduler implementation
+package scheduler
+
+import (
+	"sort"
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+)
+
+type PowerAwareScheduler struct{}
+
+func init() {
+	Register("power", withoutOptions("power", func() Scheduler {
+		return NewPowerAwareScheduler()
+	}))
+}
+
+func NewPowerAwareScheduler() *PowerAwareScheduler {
+	return &PowerAwareScheduler{}
+}
+
+func (s *PowerAwareScheduler) Name() string {
+	return "PowerAware"
+}
+
+func (s *PowerAwareScheduler) Schedule(container *container.Container, nodes []*node.Node) (*node.Node, error) {
+	poweredNodes := make([]*node.Node, 0)
+	idleNodes := make([]*node.Node, 0)
+
+	// Filter nodes that can accommodate the container, split by power state
+	for _, n := range candidates(container, nodes) {
+		if n.IsPoweredOn() {
+			poweredNodes = append(poweredNodes, n)
+		} else {
+			idleNodes = append(idleNodes, n)
+		}
+	}
+
+	if len(poweredNodes) > 0 {
+		// Pack onto the node whose power draw grows least, breaking ties
+		// towards the most utilized node
+		sort.Slice(poweredNodes, func(i, j int) bool {
+			wi := marginalWatts(container, poweredNodes[i])
+			wj := marginalWatts(container, poweredNodes[j])
+			if wi != wj {
+				return wi < wj
+			}
+			return poweredNodes[i].Utilization() > poweredNodes[j].Utilization()
+		})
+		return poweredNodes[0], nil
+	}
+
+	if len(idleNodes) == 0 {
+		return nil, ErrNoSuitableNode
+	}
+
+	// Only wake a node when nothing powered fits; pick the cheapest to run
+	sort.Slice(idleNodes, func(i, j int) bool {
+		return idleNodes[i].IdleWatts() < idleNodes[j].IdleWatts()
+	})
+
+	return idleNodes[0], nil
+}
+
+func (s *PowerAwareScheduler) ScheduleBatch(containers []*container.Container, nodes []*node.Node) (map[*container.Container]*node.Node, error) {
+	return scheduleBatchSequentially(s, containers, nodes)
+}
+
+// marginalWatts estimates how much extra power n draws once c is placed on it
+func marginalWatts(c *container.Container, n *node.Node) float64 {
+	utilDelta := (node.Ratio(c.CPURequest(), n.TotalCPU()) +
+		node.Ratio(c.MemoryRequest(), n.TotalMemory()) +
+		node.Ratio(c.NetworkRequest(), n.TotalNetwork()) +
+		node.Ratio(c.IORequest(), n.TotalIO())) / 4.0
+
+	return n.WattsPerUtil() * utilDelta
+}
@@ -0,0 +1,110 @@
+// pkg/scheduler/priority_binpack.go - Priority-tiered bin-packing scheduler implementation
+package scheduler
+
+import (
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+	"sort"
+)
+
+// PriorityBinPackScheduler tiers the cluster by priority. Containers at or
+// above the high-priority threshold are packed onto reliable nodes (health
+// at least minHealth, when any fit), preferring nodes with little
+// low-priority load that might later have to be preempted. Best-effort
+// containers below the threshold are simply bin-packed wherever they fit.
+type PriorityBinPackScheduler struct {
+	highPriority int     // priorities at or above this are high priority
+	minHealth    float64 // health a node needs to host high-priority containers
+}
+
+func init() {
+	Register("prioritybinpack", func(opts Options) (Scheduler, error) {
+		s := NewPriorityBinPackScheduler()
+		r := opts.reader("prioritybinpack")
+		if priority, ok := r.int("high_priority"); ok {
+			s.SetHighPriority(priority)
+		}
+		if health, ok := r.float("min_health"); ok {
+			if health < 0 || health > 1 {
+				r.fail("min_health", "must be in [0, 1], got %g", health)
+			}
+			s.SetMinHealth(health)
+		}
+		return r.result(s)
+	})
+}
+
+func NewPriorityBinPackScheduler() *PriorityBinPackScheduler {
+	return &PriorityBinPackScheduler{
+		highPriority: 3,
+		minHealth:    0.8,
+	}
+}
+
+func (s *PriorityBinPackScheduler) SetHighPriority(priority int) {
+	s.highPriority = priority
+}
+
+func (s *PriorityBinPackScheduler) SetMinHealth(health float64) {
+	s.minHealth = health
+}
+
+func (s *PriorityBinPackScheduler) Name() string {
+	return "PriorityBinPack"
+}
+
+func (s *PriorityBinPackScheduler) Schedule(c *container.Container, nodes []*node.Node) (*node.Node, error) {
+	candidateNodes := candidates(c, nodes)
+	
+	if len(candidateNodes) == 0 {
+		return nil, ErrNoSuitableNode
+	}
+	
+	if c.Priority() < s.highPriority {
+		sort.Slice(candidateNodes, func(i, j int) bool {
+			return candidateNodes[i].Utilization() > candidateNodes[j].Utilization()
+		})
+		return candidateNodes[0], nil
+	}
+	
+	// Keep high-priority containers off unhealthy nodes unless nothing else fits
+	healthy := make([]*node.Node, 0, len(candidateNodes))
+	for _, n := range candidateNodes {
+		if n.HealthScore() >= s.minHealth {
+			healthy = append(healthy, n)
+		}
+	}
+	if len(healthy) > 0 {
+		candidateNodes = healthy
+	}
+	
+	sort.Slice(candidateNodes, func(i, j int) bool {
+		li, lj := s.lowPriorityShare(candidateNodes[i]), s.lowPriorityShare(candidateNodes[j])
+		if li != lj {
+			return li < lj
+		}
+		return candidateNodes[i].Utilization() > candidateNodes[j].Utilization()
+	})
+	
+	return candidateNodes[0], nil
+}
+
+func (s *PriorityBinPackScheduler) ScheduleBatch(containers []*container.Container, nodes []*node.Node) (map[*container.Container]*node.Node, error) {
+	return scheduleBatchSequentially(s, containers, nodes)
+}
+
+// lowPriorityShare is the fraction of n's containers below the
+// high-priority threshold
+func (s *PriorityBinPackScheduler) lowPriorityShare(n *node.Node) float64 {
+	if n.ContainerCount() == 0 {
+		return 0
+	}
+	
+	low := 0
+	for _, existing := range n.Containers() {
+		if existing.Priority() < s.highPriority {
+			low++
+		}
+	}
+	return float64(low) / float64(n.ContainerCount())
+}
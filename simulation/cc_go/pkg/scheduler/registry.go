@@ -0,0 +1,60 @@
+// pkg/scheduler/registry.go - Scheduler registry
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Factory creates a scheduler configured by opts, which it validates: it
+// rejects unknown keys and invalid values. Empty opts give the defaults.
+type Factory func(opts Options) (Scheduler, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Factory)
+)
+
+// Register makes a scheduler available to New under name. It is meant to be
+// called from init, so a custom scheduler only needs a file of its own. It
+// panics if name is registered twice or factory is nil.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	
+	if factory == nil {
+		panic("scheduler: Register factory is nil for " + name)
+	}
+	if _, exists := registry[name]; exists {
+		panic("scheduler: Register called twice for " + name)
+	}
+	registry[name] = factory
+}
+
+// New creates the scheduler registered under name, configured by opts; nil
+// opts give its defaults
+func New(name string, opts Options) (Scheduler, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+	
+	if !ok {
+		return nil, fmt.Errorf("unknown scheduler %q (available: %s)", name, strings.Join(List(), ", "))
+	}
+	return factory(opts)
+}
+
+// List returns the registered scheduler names in sorted order
+func List() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
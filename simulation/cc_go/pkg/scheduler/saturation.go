@@ -0,0 +1,137 @@
+// pkg/scheduler/saturation.go - Saturation-aware scheduler implementation
+package scheduler
+
+import (
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+	"math"
+	"sort"
+)
+
+// SaturationCurve is a soft capacity limit: a node whose busiest resource
+// would be above Knee after a placement is penalized by
+// Scale * ((u - Knee) / (1 - Knee))^Exponent, so the penalty is zero below
+// the knee and climbs steeply towards full saturation.
+type SaturationCurve struct {
+	Knee     float64 // utilization where the penalty starts, e.g. 0.85
+	Exponent float64 // higher is flatter near the knee and steeper near 1
+	Scale    float64 // penalty at full saturation
+}
+
+func DefaultSaturationCurve() SaturationCurve {
+	return SaturationCurve{Knee: 0.85, Exponent: 2, Scale: 2}
+}
+
+// Penalty returns the curve's penalty at utilization u
+func (s SaturationCurve) Penalty(u float64) float64 {
+	if u <= s.Knee || s.Knee >= 1 {
+		return 0
+	}
+	return s.Scale * math.Pow((u-s.Knee)/(1-s.Knee), s.Exponent)
+}
+
+// saturationAfter is the penalty for n's most utilized resource once c is
+// placed on it; any scheduler can subtract it from its own score. A node's
+// load is the larger of what its containers requested and what they use
+// right now, so a node in the middle of a burst counts as fuller.
+func saturationAfter(c *container.Container, n *node.Node, curve SaturationCurve) float64 {
+	cpu, memory, network, io := n.EffectiveUsage()
+	after := func(used, available, total, request float64) float64 {
+		return node.Ratio(math.Max(total-available, used)+request, total)
+	}
+	
+	peak := math.Max(
+		math.Max(after(cpu, n.AvailableCPU(), n.TotalCPU(), c.CPURequest()),
+			after(memory, n.AvailableMemory(), n.TotalMemory(), c.MemoryRequest())),
+		math.Max(after(network, n.AvailableNetwork(), n.TotalNetwork(), c.NetworkRequest()),
+			after(io, n.AvailableIO(), n.TotalIO(), c.IORequest())),
+	)
+	return curve.Penalty(peak)
+}
+
+// SaturationAwareScheduler bin-packs like BinPack, but subtracts a
+// saturation penalty so it stops filling a node well before 100% on any
+// resource as long as another node can take the container comfortably.
+// Usage bursts then have room to land without over-committing the node.
+type SaturationAwareScheduler struct {
+	curve SaturationCurve
+}
+
+func init() {
+	Register("saturationaware", func(opts Options) (Scheduler, error) {
+		s := NewSaturationAwareScheduler()
+		r := opts.reader("saturationaware")
+		if knee, ok := r.float("knee"); ok {
+			if knee <= 0 || knee >= 1 {
+				r.fail("knee", "must be in (0, 1), got %g", knee)
+			}
+			s.curve.Knee = knee
+		}
+		if exponent, ok := r.float("exponent"); ok {
+			if exponent <= 0 {
+				r.fail("exponent", "must be positive, got %g", exponent)
+			}
+			s.curve.Exponent = exponent
+		}
+		if scale, ok := r.float("scale"); ok {
+			if scale < 0 {
+				r.fail("scale", "must not be negative, got %g", scale)
+			}
+			s.curve.Scale = scale
+		}
+		return r.result(s)
+	})
+}
+
+func NewSaturationAwareScheduler() *SaturationAwareScheduler {
+	return &SaturationAwareScheduler{curve: DefaultSaturationCurve()}
+}
+
+func (s *SaturationAwareScheduler) SetCurve(curve SaturationCurve) {
+	s.curve = curve
+}
+
+func (s *SaturationAwareScheduler) Name() string {
+	return "SaturationAware"
+}
+
+func (s *SaturationAwareScheduler) Schedule(container *container.Container, nodes []*node.Node) (*node.Node, error) {
+	scores, err := s.Explain(container, nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	return scores[0].Node, nil
+}
+
+// Explain ranks the fitting nodes by utilization minus the saturation
+// penalty the placement would incur, best first
+func (s *SaturationAwareScheduler) Explain(container *container.Container, nodes []*node.Node) ([]NodeScore, error) {
+	scores := make([]NodeScore, 0)
+	for _, n := range candidates(container, nodes) {
+		utilization := n.Utilization()
+		penalty := saturationAfter(container, n, s.curve)
+		scores = append(scores, NodeScore{
+			Node:  n,
+			Score: utilization - penalty,
+			Components: []ScoreComponent{
+				{Name: "utilization", Value: utilization},
+				{Name: "saturation", Value: -penalty},
+			},
+		})
+	}
+
+	if len(scores) == 0 {
+		return nil, ErrNoSuitableNode
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool {
+		return scores[i].Score > scores[j].Score
+	})
+
+	return scores, nil
+}
+
+func (s *SaturationAwareScheduler) ScheduleBatch(containers []*container.Container, nodes []*node.Node) (map[*container.Container]*node.Node, error) {
+	return scheduleBatchSequentially(s, containers, nodes)
+}
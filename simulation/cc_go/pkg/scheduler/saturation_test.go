@@ -0,0 +1,64 @@
+// pkg/scheduler/saturation_test.go - Saturation-aware scheduler burst tests
+package scheduler
+
+import (
+	"math/rand"
+	"testing"
+
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+)
+
+// burstOvercommits places 40 containers on 8 nodes with room for 80, and
+// after every placement lets each running container burst to 1.25 times
+// its requests with probability 0.3. It returns how many times a node's
+// actual usage then exceeded its capacity on some resource.
+func burstOvercommits(t *testing.T, s Scheduler, seed int64) int {
+	t.Helper()
+	rng := rand.New(rand.NewSource(seed))
+	nodes := emptyCluster(8)
+	var running []*container.Container
+	overcommits := 0
+	for i := 0; i < 40; i++ {
+		c := container.NewContainer("c", "img", 1, 1000, 100, 100, "web", 0)
+		chosen, err := s.Schedule(c, nodes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		chosen.AddContainer(c)
+		running = append(running, c)
+		
+		for _, r := range running {
+			r.SetUsageFactor(1)
+			if rng.Float64() < 0.3 {
+				r.SetUsageFactor(1.25)
+			}
+		}
+		for _, n := range nodes {
+			if overcommitted(n) {
+				overcommits++
+			}
+		}
+	}
+	return overcommits
+}
+
+func overcommitted(n *node.Node) bool {
+	cpu, memory, network, io := n.EffectiveUsage()
+	return cpu > n.TotalCPU() || memory > n.TotalMemory() || network > n.TotalNetwork() || io > n.TotalIO()
+}
+
+func TestSaturationAwareOvercommitsLessThanBinPackUnderBursts(t *testing.T) {
+	saturation, binpack := 0, 0
+	for seed := int64(1); seed <= 20; seed++ {
+		saturation += burstOvercommits(t, NewSaturationAwareScheduler(), seed)
+		binpack += burstOvercommits(t, NewBinPackScheduler(), seed)
+	}
+	if binpack == 0 {
+		t.Fatal("BinPack never over-committed a node; the bursts are too small to compare")
+	}
+	if saturation >= binpack {
+		t.Errorf("SaturationAware over-committed nodes %d times, BinPack %d", saturation, binpack)
+	}
+	t.Logf("over-committed nodes after bursts: SaturationAware %d, BinPack %d", saturation, binpack)
+}
@@ -2,6 +2,8 @@
 package scheduler
 
 import (
+	"fmt"
+	"math"
 	"cc_go/pkg/container"
 	"cc_go/pkg/node"
 )
@@ -10,6 +12,112 @@ type Scheduler interface {
 	// Schedule attempts to schedule a container on available nodes
 	Schedule(container *container.Container, nodes []*node.Node) (*node.Node, error)
 	
+	// ScheduleBatch plans placements for a whole wave of containers without
+	// committing them. Containers missing from the returned map could not
+	// be placed; ErrNoSuitableNode is returned only if none could.
+	ScheduleBatch(containers []*container.Container, nodes []*node.Node) (map[*container.Container]*node.Node, error)
+	
 	// Name returns the name of the scheduler
 	Name() string
 }
+
+// scheduleBatchSequentially is the default ScheduleBatch: it schedules the
+// containers one at a time in the given order. Each placement is applied to
+// a clone of the cluster so later decisions see it, while the live nodes'
+// load history, and with it their variance and health, stay untouched.
+func scheduleBatchSequentially(s Scheduler, containers []*container.Container, nodes []*node.Node) (map[*container.Container]*node.Node, error) {
+	placements := make(map[*container.Container]*node.Node)
+	
+	clones := node.CloneCluster(nodes)
+	live := make(map[*node.Node]*node.Node, len(nodes))
+	for i, clone := range clones {
+		live[clone] = nodes[i]
+	}
+	
+	for _, c := range containers {
+		n, err := s.Schedule(c, clones)
+		if err != nil || live[n] == nil {
+			continue
+		}
+		// The caller commits the placements on the live nodes
+		if n.AddContainer(c) {
+			placements[c] = live[n]
+		}
+	}
+	
+	if len(placements) == 0 && len(containers) > 0 {
+		return placements, ErrNoSuitableNode
+	}
+	
+	return placements, nil
+}
+
+// nodeUtilization returns the sort key used by utilization-ordered
+// schedulers: the bottleneck resource if dominant, otherwise the CPU,
+// memory, network and IO utilizations weighted by weights
+func nodeUtilization(n *node.Node, dominant bool, weights [4]float64) float64 {
+	if dominant {
+		return n.DominantUtilization()
+	}
+	return weightedUtilization(n, weights)
+}
+
+// weightedUtilization is the weighted mean of the node's CPU, memory,
+// network and IO utilization. Equal weights (including all zero) give
+// exactly Utilization.
+func weightedUtilization(n *node.Node, weights [4]float64) float64 {
+	total := weights[0] + weights[1] + weights[2] + weights[3]
+	if total <= 0 || (weights[0] == weights[1] && weights[1] == weights[2] && weights[2] == weights[3]) {
+		return n.Utilization()
+	}
+	
+	return (weights[0]*n.CPUUtilization() + weights[1]*n.MemoryUtilization() +
+		weights[2]*n.NetworkUtilization() + weights[3]*n.IOUtilization()) / total
+}
+
+// validResourceWeights rejects negative weights and weights that are all zero
+func validResourceWeights(weights [4]float64) error {
+	total := 0.0
+	for _, w := range weights {
+		if w < 0 {
+			return fmt.Errorf("resource weights must not be negative, got %v", weights)
+		}
+		total += w
+	}
+	if total <= 0 {
+		return fmt.Errorf("resource weights must not all be zero")
+	}
+	return nil
+}
+
+// ScheduleFromPool schedules c considering only the pool's candidate nodes
+// for it, which saves scanning nodes that cannot fit on large clusters.
+// Schedulers that look at the whole cluster (e.g. WorstFit's smallest node)
+// only see the candidates.
+func ScheduleFromPool(s Scheduler, c *container.Container, pool *node.Pool) (*node.Node, error) {
+	candidates := pool.Candidates(c)
+	if len(candidates) == 0 {
+		return nil, ErrNoSuitableNode
+	}
+	
+	return s.Schedule(c, candidates)
+}
+
+// ScheduleBatchFromPool is ScheduleFromPool for a wave: the scheduler plans
+// the wave on the pool's nodes with enough free CPU for its smallest
+// container.
+func ScheduleBatchFromPool(s Scheduler, containers []*container.Container, pool *node.Pool) (map[*container.Container]*node.Node, error) {
+	if len(containers) == 0 {
+		return map[*container.Container]*node.Node{}, nil
+	}
+	smallest := containers[0].CPURequest()
+	for _, c := range containers[1:] {
+		smallest = math.Min(smallest, c.CPURequest())
+	}
+	
+	nodes := pool.WithFreeCPU(smallest)
+	if len(nodes) == 0 {
+		return map[*container.Container]*node.Node{}, ErrNoSuitableNode
+	}
+	return s.ScheduleBatch(containers, nodes)
+}
\ No newline at end of file
@@ -0,0 +1,232 @@
+// pkg/scheduler/scheduler_bench_test.go - Schedule latency benchmarks
+package scheduler
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"cc_go/pkg/container"
+	"cc_go/pkg/logging"
+	"cc_go/pkg/node"
+)
+
+// benchClusterSizes are the node counts every scheduler is measured against
+var benchClusterSizes = []int{10, 100, 1000}
+
+// benchCase is one row of the benchmark table. Schedulers registered with
+// Register are picked up automatically; add a row here only for a variant
+// that needs settings other than its factory's defaults.
+type benchCase struct {
+	name string
+	new  func() Scheduler
+}
+
+func benchCases() []benchCase {
+	var cases []benchCase
+	for _, name := range List() {
+		name := name
+		cases = append(cases, benchCase{name, func() Scheduler {
+			s, _ := New(name, nil)
+			return s
+		}})
+	}
+	return cases
+}
+
+// benchNodeShapes are the node sizes synthetic clusters cycle through
+var benchNodeShapes = []struct {
+	cpu, memory, network, io float64
+}{
+	{2, 4096, 1000, 5000},
+	{4, 8192, 2000, 10000},
+	{8, 16384, 4000, 20000},
+}
+
+// benchContainerShapes are the requests used both to fill the cluster and
+// as the containers being scheduled
+var benchContainerShapes = []struct {
+	kind                     string
+	cpu, memory, network, io float64
+}{
+	{"web", 0.5, 512, 50, 100},
+	{"database", 1, 2048, 20, 800},
+	{"batch", 1.5, 1024, 10, 300},
+	{"cache", 0.25, 1024, 100, 50},
+}
+
+func benchContainer(rng *rand.Rand, i int) *container.Container {
+	shape := benchContainerShapes[rng.Intn(len(benchContainerShapes))]
+	return container.NewContainer(fmt.Sprintf("%s-%d", shape.kind, i), shape.kind+":latest",
+		shape.cpu, shape.memory, shape.network, shape.io, shape.kind, rng.Intn(10))
+}
+
+// benchProbes returns the containers scheduled by the benchmarks, the same
+// for every run
+func benchProbes() []*container.Container {
+	rng := rand.New(rand.NewSource(2))
+	probes := make([]*container.Container, 64)
+	for i := range probes {
+		probes[i] = benchContainer(rng, i)
+	}
+	return probes
+}
+
+// benchCluster builds a cluster of size nodes with realistic occupancy:
+// each node is filled to between 30% and 80% of its CPU, so schedulers
+// have to filter out some nodes and score the rest. The same seed always
+// builds the same cluster.
+func benchCluster(size int, seed int64) []*node.Node {
+	rng := rand.New(rand.NewSource(seed))
+	nodes := make([]*node.Node, size)
+	placed := 0
+	for i := range nodes {
+		shape := benchNodeShapes[i%len(benchNodeShapes)]
+		n := node.NewNode(fmt.Sprintf("node-%d", i), shape.cpu, shape.memory, shape.network, shape.io)
+		n.SetZone(fmt.Sprintf("zone-%d", i%3))
+		
+		target := 0.3 + 0.5*rng.Float64()
+		for n.CPUUtilization() < target {
+			c := benchContainer(rng, placed)
+			if !n.AddContainer(c) {
+				break
+			}
+			n.CacheImage(c.Image())
+			placed++
+		}
+		nodes[i] = n
+	}
+	return nodes
+}
+
+// BenchmarkSchedule measures one Schedule call per iteration for every
+// registered scheduler and cluster size. Run it with
+//
+//	go test ./pkg/scheduler -run '^$' -bench Schedule
+//
+// and narrow it with e.g. -bench 'Schedule/binpack/nodes=1000'.
+func BenchmarkSchedule(b *testing.B) {
+	defer logging.SetDefault(logging.Default())
+	logging.SetDefault(logging.Discard{})
+	
+	for _, size := range benchClusterSizes {
+		nodes := benchCluster(size, 1)
+		probes := benchProbes()
+		
+		for _, bc := range benchCases() {
+			b.Run(fmt.Sprintf("%s/nodes=%d", bc.name, size), func(b *testing.B) {
+				s := bc.new()
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					s.Schedule(probes[i%len(probes)], nodes)
+				}
+			})
+		}
+	}
+}
+
+// benchmarkOnCluster runs BenchmarkSchedule's measurement for the named
+// schedulers on one cluster size, so a comparison can be run on its own
+func benchmarkOnCluster(b *testing.B, size int, names ...string) {
+	defer logging.SetDefault(logging.Default())
+	logging.SetDefault(logging.Discard{})
+	
+	nodes := benchCluster(size, 1)
+	probes := benchProbes()
+	
+	for _, name := range names {
+		b.Run(fmt.Sprintf("%s/nodes=%d", name, size), func(b *testing.B) {
+			s, err := New(name, nil)
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				s.Schedule(probes[i%len(probes)], nodes)
+			}
+		})
+	}
+}
+
+// BenchmarkFirstFitVsBinPack compares the latency of first-fit, which stops
+// at the first fitting node, with BinPack, which ranks all of them
+func BenchmarkFirstFitVsBinPack(b *testing.B) {
+	benchmarkOnCluster(b, 1000, "firstfit", "binpack")
+}
+
+// packCluster fills every node but every hundredth with small CPU-only
+// containers until none fits, leaving too little CPU for any probe
+func packCluster(nodes []*node.Node) {
+	for i, n := range nodes {
+		if i%100 == 0 {
+			continue
+		}
+		for n.AddContainer(container.NewContainer("filler", "filler", 0.125, 1, 1, 1, "filler", 0)) {
+		}
+	}
+}
+
+// BenchmarkScheduleFromPool measures a placement on a large cluster through
+// the node pool against a plain scan of every node, on the partly occupied
+// cluster of BenchmarkSchedule and on one where only 1% of the nodes have
+// room. Each iteration places the container and removes it again, so the
+// pool is repositioned twice.
+func BenchmarkScheduleFromPool(b *testing.B) {
+	defer logging.SetDefault(logging.Default())
+	logging.SetDefault(logging.Discard{})
+	
+	const size = 10000
+	probes := benchProbes()
+	for _, packed := range []bool{false, true} {
+		occupancy := "partly"
+		if packed {
+			occupancy = "packed"
+		}
+		for _, name := range []string{"binpack", "firstfit"} {
+			for _, pooled := range []bool{false, true} {
+				mode := "scan"
+				if pooled {
+					mode = "pool"
+				}
+				b.Run(fmt.Sprintf("%s/%s/%s/nodes=%d", name, occupancy, mode, size), func(b *testing.B) {
+					s, err := New(name, nil)
+					if err != nil {
+						b.Fatal(err)
+					}
+					nodes := benchCluster(size, 1)
+					if packed {
+						packCluster(nodes)
+					}
+					var pool *node.Pool
+					if pooled {
+						pool = node.NewPool(nodes)
+						defer pool.Close()
+					}
+					
+					b.ReportAllocs()
+					b.ResetTimer()
+					for i := 0; i < b.N; i++ {
+						c := probes[i%len(probes)]
+						var n *node.Node
+						if pooled {
+							n, err = ScheduleFromPool(s, c, pool)
+						} else {
+							n, err = s.Schedule(c, nodes)
+						}
+						if err == nil && n.AddContainer(c) {
+							n.RemoveContainerRef(c)
+						}
+					}
+				})
+			}
+		}
+	}
+}
+
+// BenchmarkAdaptiveLargeCluster measures the adaptive scheduler on 2000
+// nodes, where it scores candidates in parallel
+func BenchmarkAdaptiveLargeCluster(b *testing.B) {
+	benchmarkOnCluster(b, 2000, "adaptive")
+}
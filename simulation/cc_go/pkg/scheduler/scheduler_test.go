@@ -0,0 +1,168 @@
+// pkg/scheduler/scheduler_test.go - Batch scheduling tests
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+)
+
+// wave is twelve 3-CPU containers followed by six 7-CPU ones: placed one
+// at a time first-fit, the small ones fill nodes to 9 of 10 CPUs and
+// every large one needs a node of its own
+func wave() []*container.Container {
+	var containers []*container.Container
+	for i := 0; i < 12; i++ {
+		containers = append(containers, container.NewContainer(fmt.Sprintf("small-%d", i), "img", 3, 1, 1, 1, "web", 0))
+	}
+	for i := 0; i < 6; i++ {
+		containers = append(containers, container.NewContainer(fmt.Sprintf("large-%d", i), "img", 7, 1, 1, 1, "batch", 0))
+	}
+	return containers
+}
+
+func waveCluster() []*node.Node {
+	nodes := make([]*node.Node, 12)
+	for i := range nodes {
+		nodes[i] = node.NewNode(fmt.Sprintf("node-%d", i), 10, 10000, 1000, 1000)
+	}
+	return nodes
+}
+
+// packingEfficiency is the CPU utilization of the nodes the placements use
+func packingEfficiency(placements map[*container.Container]*node.Node) (occupied int, efficiency float64) {
+	used := make(map[*node.Node]float64)
+	for c, n := range placements {
+		used[n] += c.CPURequest()
+	}
+	var total float64
+	for n, cpu := range used {
+		efficiency += cpu
+		total += n.TotalCPU()
+	}
+	return len(used), efficiency / total
+}
+
+func TestBatchPacksTighterThanPerContainer(t *testing.T) {
+	s := NewBatchBinPackScheduler()
+	
+	nodes := waveCluster()
+	perContainer := make(map[*container.Container]*node.Node)
+	for _, c := range wave() {
+		n, err := s.Schedule(c, nodes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		n.AddContainer(c)
+		perContainer[c] = n
+	}
+	
+	batch, err := s.ScheduleBatch(wave(), waveCluster())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(batch) != len(perContainer) {
+		t.Fatalf("batch placed %d containers, per-container %d", len(batch), len(perContainer))
+	}
+	
+	perNodes, perEfficiency := packingEfficiency(perContainer)
+	batchNodes, batchEfficiency := packingEfficiency(batch)
+	t.Logf("per-container: %d nodes at %.3f, batch: %d nodes at %.3f", perNodes, perEfficiency, batchNodes, batchEfficiency)
+	if batchNodes >= perNodes || batchEfficiency <= perEfficiency {
+		t.Errorf("batch used %d nodes at %.3f efficiency, no better than per-container %d at %.3f",
+			batchNodes, batchEfficiency, perNodes, perEfficiency)
+	}
+}
+
+func TestBatchPlanningLeavesLiveNodesUntouched(t *testing.T) {
+	for _, name := range List() {
+		s, err := New(name, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodes := waveCluster()
+		nodes[0].AddContainer(container.NewContainer("existing", "img", 2, 1, 1, 1, "web", 0))
+		variance, smoothed := nodes[0].LoadVariance(), nodes[0].SmoothedUtilization()
+		
+		placements, err := s.ScheduleBatch(wave(), nodes)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		for c, n := range placements {
+			if n.ContainerCount() > 1 || (n != nodes[0] && n.ContainerCount() > 0) {
+				t.Errorf("%s: planning %s left containers on live node %s", name, c.ID(), n.Name())
+			}
+		}
+		if nodes[0].LoadVariance() != variance || nodes[0].SmoothedUtilization() != smoothed {
+			t.Errorf("%s: planning changed the live node's load history", name)
+		}
+	}
+}
+
+func TestBatchContainerFailingToCommitIsNotPlaced(t *testing.T) {
+	for _, name := range List() {
+		s, err := New(name, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodes := waveCluster()
+		batch := wave()
+		placements, err := s.ScheduleBatch(batch, nodes)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		for _, c := range batch {
+			if !c.PlacedAt().IsZero() {
+				t.Fatalf("%s: planning marked %s as placed", name, c.ID())
+			}
+		}
+		
+		// Something else takes the first planned node before the wave is
+		// committed, so its containers fail there
+		blocked := placements[batch[0]]
+		if blocked == nil {
+			t.Fatalf("%s: first container not planned", name)
+		}
+		blocked.AddContainer(container.NewContainer("blocker", "img", blocked.AvailableCPU(), 1, 1, 1, "web", 0))
+		for _, c := range batch {
+			n, ok := placements[c]
+			if !ok {
+				continue
+			}
+			committed := n.AddContainer(c)
+			if committed == c.PlacedAt().IsZero() {
+				t.Errorf("%s: %s committed %v on %s, placed at %v", name, c.ID(), committed, n.Name(), c.PlacedAt())
+			}
+			if n == blocked && committed {
+				t.Errorf("%s: %s committed on the blocked node", name, c.ID())
+			}
+		}
+	}
+}
+
+func TestBatchFromPoolPlansOnNodesWithRoom(t *testing.T) {
+	nodes := waveCluster()
+	for _, n := range nodes[:9] {
+		n.AddContainer(container.NewContainer("filler", "img", 8, 1, 1, 1, "filler", 0))
+	}
+	pool := node.NewPool(nodes)
+	defer pool.Close()
+	
+	containers := wave()
+	placements, err := ScheduleBatchFromPool(NewBatchBinPackScheduler(), containers, pool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only the three empty nodes have room for a 3-CPU container; each
+	// takes one large and one small container
+	if len(placements) != 6 {
+		t.Errorf("placed %d containers, want 6", len(placements))
+	}
+	for c, n := range placements {
+		if n.ContainerCount() > 0 {
+			t.Errorf("%s planned on %s, which has too little CPU free", c.ID(), n.Name())
+		}
+	}
+}
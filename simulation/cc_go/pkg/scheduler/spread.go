@@ -7,25 +7,47 @@ import (
 	"cc_go/pkg/node"
 )
 
-type SpreadScheduler struct{}
+type SpreadScheduler struct {
+	dominant bool // sort by bottleneck rather than average utilization
+	resourceWeights [4]float64 // CPU, memory, network and IO weights of the utilization; equal weights give the plain average
+}
+
+func init() {
+	Register("spread", func(opts Options) (Scheduler, error) {
+		s := NewSpreadScheduler()
+		r := opts.reader("spread")
+		utilizationOptions(r, s.SetDominantUtilization, s.SetResourceWeights)
+		return r.result(s)
+	})
+}
 
 func NewSpreadScheduler() *SpreadScheduler {
 	return &SpreadScheduler{}
 }
 
+// SetDominantUtilization makes the scheduler rank nodes by their most
+// utilized resource instead of the average across resources
+func (s *SpreadScheduler) SetDominantUtilization(enabled bool) {
+	s.dominant = enabled
+}
+
+// SetResourceWeights weights the CPU, memory, network and IO utilization in
+// the sort key, e.g. {0, 1, 0, 0} to rank nodes on memory alone. It has no
+// effect while dominant utilization is enabled.
+func (s *SpreadScheduler) SetResourceWeights(weights [4]float64) error {
+	if err := validResourceWeights(weights); err != nil {
+		return err
+	}
+	s.resourceWeights = weights
+	return nil
+}
+
 func (s *SpreadScheduler) Name() string {
 	return "Spread"
 }
 
 func (s *SpreadScheduler) Schedule(container *container.Container, nodes []*node.Node) (*node.Node, error) {
-	candidateNodes := make([]*node.Node, 0)
-	
-	// Filter nodes that can accommodate the container
-	for _, n := range nodes {
-		if n.CanFit(container) {
-			candidateNodes = append(candidateNodes, n)
-		}
-	}
+	candidateNodes := candidates(container, nodes)
 	
 	if len(candidateNodes) == 0 {
 		return nil, ErrNoSuitableNode
@@ -33,9 +55,18 @@ func (s *SpreadScheduler) Schedule(container *container.Container, nodes []*node
 	
 	// Sort nodes by current utilization (ascending)
 	sort.Slice(candidateNodes, func(i, j int) bool {
-		return candidateNodes[i].Utilization() < candidateNodes[j].Utilization()
+		return nodeUtilization(candidateNodes[i], s.dominant, s.resourceWeights) < nodeUtilization(candidateNodes[j], s.dominant, s.resourceWeights)
 	})
 	
 	// Place on the node with lowest utilization
 	return candidateNodes[0], nil
+}
+
+// Explain ranks the fitting nodes by utilization, least utilized first
+func (s *SpreadScheduler) Explain(container *container.Container, nodes []*node.Node) ([]NodeScore, error) {
+	return explainByUtilization(container, nodes, s.dominant, s.resourceWeights, false)
+}
+
+func (s *SpreadScheduler) ScheduleBatch(containers []*container.Container, nodes []*node.Node) (map[*container.Container]*node.Node, error) {
+	return scheduleBatchSequentially(s, containers, nodes)
 }
\ No newline at end of file
@@ -0,0 +1,86 @@
+// pkg/scheduler/vector_binpack.go - Vector bin-packing scheduler implementation
+package scheduler
+
+import (
+	"math"
+	"sort"
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+)
+
+// VectorBinPackScheduler treats requests and free capacity as vectors over
+// the resources and places each container on the node whose leftover space
+// has the most similar shape (cosine similarity). A memory-heavy container
+// goes where mostly memory is left, which strands less capacity than
+// packing by aggregate utilization alone.
+type VectorBinPackScheduler struct{}
+
+func init() {
+	Register("vectorbinpack", withoutOptions("vectorbinpack", func() Scheduler {
+		return NewVectorBinPackScheduler()
+	}))
+}
+
+func NewVectorBinPackScheduler() *VectorBinPackScheduler {
+	return &VectorBinPackScheduler{}
+}
+
+func (s *VectorBinPackScheduler) Name() string {
+	return "VectorBinPack"
+}
+
+func (s *VectorBinPackScheduler) Schedule(container *container.Container, nodes []*node.Node) (*node.Node, error) {
+	candidateNodes := candidates(container, nodes)
+	
+	if len(candidateNodes) == 0 {
+		return nil, ErrNoSuitableNode
+	}
+	
+	alignment := make(map[*node.Node]float64)
+	for _, n := range candidateNodes {
+		alignment[n] = shapeAlignment(container, n)
+	}
+	
+	// Sort by alignment (descending), packing the fuller node on ties
+	sort.Slice(candidateNodes, func(i, j int) bool {
+		ai := alignment[candidateNodes[i]]
+		aj := alignment[candidateNodes[j]]
+		if ai != aj {
+			return ai > aj
+		}
+		return candidateNodes[i].Utilization() > candidateNodes[j].Utilization()
+	})
+	
+	return candidateNodes[0], nil
+}
+
+func (s *VectorBinPackScheduler) ScheduleBatch(containers []*container.Container, nodes []*node.Node) (map[*container.Container]*node.Node, error) {
+	return scheduleBatchSequentially(s, containers, nodes)
+}
+
+// shapeAlignment is the cosine similarity between the container's request
+// and the node's free capacity, both taken as fractions of the node's total
+// so that resources measured in different units are comparable.
+// Unconstrained resources are left out.
+func shapeAlignment(c *container.Container, n *node.Node) float64 {
+	requests := []float64{c.CPURequest(), c.MemoryRequest(), c.NetworkRequest(), c.IORequest(), c.DiskRequest()}
+	available := []float64{n.AvailableCPU(), n.AvailableMemory(), n.AvailableNetwork(), n.AvailableIO(), n.AvailableDisk()}
+	totals := []float64{n.TotalCPU(), n.TotalMemory(), n.TotalNetwork(), n.TotalIO(), n.TotalDisk()}
+	
+	dot, requestNorm, freeNorm := 0.0, 0.0, 0.0
+	for i := range requests {
+		if totals[i] <= 0 {
+			continue
+		}
+		r := requests[i] / totals[i]
+		f := available[i] / totals[i]
+		dot += r * f
+		requestNorm += r * r
+		freeNorm += f * f
+	}
+	
+	if requestNorm == 0 || freeNorm == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(requestNorm) * math.Sqrt(freeNorm))
+}
@@ -0,0 +1,57 @@
+// pkg/scheduler/vector_binpack_test.go - Vector bin-packing tests
+package scheduler
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+)
+
+// strandedMemory fills a cluster of CPU-rich and memory-rich nodes with a
+// mix of CPU-heavy and memory-heavy containers, in random order, until the
+// scheduler places nothing more. It returns the memory left free on nodes
+// whose CPU ran out before it, so not even a memory-heavy container fits.
+func strandedMemory(t *testing.T, s Scheduler, seed int64) float64 {
+	t.Helper()
+	var nodes []*node.Node
+	for i := 0; i < 10; i++ {
+		nodes = append(nodes,
+			node.NewNode(fmt.Sprintf("cpu-rich-%d", i), 32, 8192, 1000, 1000),
+			node.NewNode(fmt.Sprintf("memory-rich-%d", i), 4, 65536, 1000, 1000))
+	}
+	
+	rng := rand.New(rand.NewSource(seed))
+	for failures := 0; failures < 50; {
+		c := container.NewContainer("cpu", "img", 2, 256, 1, 1, "compute", 0)
+		if rng.Intn(2) == 0 {
+			c = container.NewContainer("memory", "img", 0.25, 4096, 1, 1, "cache", 0)
+		}
+		n, err := s.Schedule(c, nodes)
+		if err != nil {
+			failures++
+			continue
+		}
+		n.AddContainer(c)
+	}
+	
+	reference := container.NewContainer("reference", "img", 0.25, 4096, 1, 1, "cache", 0)
+	stranded := 0.0
+	for _, n := range nodes {
+		stranded += n.StrandedMemory(reference)
+	}
+	return stranded
+}
+
+func TestVectorBinPackStrandsLessMemoryThanBinPack(t *testing.T) {
+	for seed := int64(1); seed <= 5; seed++ {
+		vector := strandedMemory(t, NewVectorBinPackScheduler(), seed)
+		binPack := strandedMemory(t, NewBinPackScheduler(), seed)
+		t.Logf("seed %d: stranded memory %.0f MB with VectorBinPack, %.0f MB with BinPack", seed, vector, binPack)
+		if vector >= binPack {
+			t.Errorf("seed %d: VectorBinPack stranded %.0f MB, not less than BinPack's %.0f MB", seed, vector, binPack)
+		}
+	}
+}
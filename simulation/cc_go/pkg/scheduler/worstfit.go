@@ -0,0 +1,86 @@
+// pkg/scheduler/worstfit.go - Worst-fit baseline scheduler implementation
+package scheduler
+
+import (
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+)
+
+// WorstFitScheduler places each container on the fitting node with the most
+// absolute free capacity of the container's dominant resource. Unlike Spread
+// it ignores node size, so large nodes keep attracting work.
+type WorstFitScheduler struct{}
+
+func init() {
+	Register("worstfit", withoutOptions("worstfit", func() Scheduler {
+		return NewWorstFitScheduler()
+	}))
+}
+
+func NewWorstFitScheduler() *WorstFitScheduler {
+	return &WorstFitScheduler{}
+}
+
+func (s *WorstFitScheduler) Name() string {
+	return "WorstFit"
+}
+
+func (s *WorstFitScheduler) Schedule(container *container.Container, nodes []*node.Node) (*node.Node, error) {
+	available := dominantResource(container, nodes)
+
+	var best *node.Node
+	var bestFree float64
+	for _, n := range candidates(container, nodes) {
+		free := available(n)
+		if best == nil || free > bestFree {
+			best = n
+			bestFree = free
+		}
+	}
+
+	if best == nil {
+		return nil, ErrNoSuitableNode
+	}
+
+	return best, nil
+}
+
+func (s *WorstFitScheduler) ScheduleBatch(containers []*container.Container, nodes []*node.Node) (map[*container.Container]*node.Node, error) {
+	return scheduleBatchSequentially(s, containers, nodes)
+}
+
+// dominantResource picks the resource whose request is the largest fraction
+// of the smallest node's capacity and returns an accessor for the free
+// amount of that resource on a node
+func dominantResource(c *container.Container, nodes []*node.Node) func(*node.Node) float64 {
+	resources := []struct {
+		request   float64
+		total     func(*node.Node) float64
+		available func(*node.Node) float64
+	}{
+		{c.CPURequest(), (*node.Node).TotalCPU, (*node.Node).AvailableCPU},
+		{c.MemoryRequest(), (*node.Node).TotalMemory, (*node.Node).AvailableMemory},
+		{c.NetworkRequest(), (*node.Node).TotalNetwork, (*node.Node).AvailableNetwork},
+		{c.IORequest(), (*node.Node).TotalIO, (*node.Node).AvailableIO},
+		{c.DiskRequest(), (*node.Node).TotalDisk, (*node.Node).AvailableDisk},
+	}
+
+	dominant := resources[0].available
+	highestShare := -1.0
+	for _, r := range resources {
+		smallest := 0.0
+		for _, n := range nodes {
+			if total := r.total(n); total > 0 && (smallest == 0 || total < smallest) {
+				smallest = total
+			}
+		}
+
+		share := node.Ratio(r.request, smallest)
+		if share > highestShare {
+			highestShare = share
+			dominant = r.available
+		}
+	}
+
+	return dominant
+}
@@ -0,0 +1,35 @@
+// pkg/scheduler/worstfit_test.go - Worst-fit scheduler tests
+package scheduler
+
+import (
+	"testing"
+
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+)
+
+func TestWorstFitIgnoresNodeSizeUnlikeSpread(t *testing.T) {
+	// The big node is half used but still has five times the small node's
+	// free CPU
+	small := node.NewNode("small", 10, 10000, 1000, 1000)
+	big := node.NewNode("big", 100, 100000, 10000, 10000)
+	big.AddContainer(container.NewContainer("load", "load", 50, 50000, 5000, 5000, "load", 0))
+	nodes := []*node.Node{small, big}
+	
+	c := container.NewContainer("cpu", "img", 1, 10, 1, 1, "web", 0)
+	worstFit, err := NewWorstFitScheduler().Schedule(c, nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spread, err := NewSpreadScheduler().Schedule(c, nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	
+	if worstFit != big {
+		t.Errorf("WorstFit chose %s, want the node with the most free CPU (big)", worstFit.Name())
+	}
+	if spread != small {
+		t.Errorf("Spread chose %s, want the least utilized node (small)", spread.Name())
+	}
+}
@@ -0,0 +1,85 @@
+// pkg/scheduler/zone_spread.go - Failure-domain aware spreading scheduler implementation
+package scheduler
+
+import (
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+	"sort"
+)
+
+// ZoneSpreadScheduler spreads containers that share a spread key (e.g.
+// replicas of one service) across failure domains: it prefers nodes in the
+// zone holding the fewest containers with that key, then the node holding
+// the fewest, then the least utilized node. Containers without a spread
+// key are placed like SpreadScheduler would. A node without a zone is
+// treated as a zone of its own.
+type ZoneSpreadScheduler struct{}
+
+func init() {
+	Register("zonespread", withoutOptions("zonespread", func() Scheduler {
+		return NewZoneSpreadScheduler()
+	}))
+}
+
+func NewZoneSpreadScheduler() *ZoneSpreadScheduler {
+	return &ZoneSpreadScheduler{}
+}
+
+func (s *ZoneSpreadScheduler) Name() string {
+	return "ZoneSpread"
+}
+
+func (s *ZoneSpreadScheduler) Schedule(c *container.Container, nodes []*node.Node) (*node.Node, error) {
+	zoneCounts, nodeCounts := spreadKeyCounts(c.SpreadKey(), nodes)
+	
+	candidateNodes := candidates(c, nodes)
+	
+	if len(candidateNodes) == 0 {
+		return nil, ErrNoSuitableNode
+	}
+	
+	sort.Slice(candidateNodes, func(i, j int) bool {
+		a, b := candidateNodes[i], candidateNodes[j]
+		if za, zb := zoneCounts[zoneOf(a)], zoneCounts[zoneOf(b)]; za != zb {
+			return za < zb
+		}
+		if nodeCounts[a] != nodeCounts[b] {
+			return nodeCounts[a] < nodeCounts[b]
+		}
+		return a.Utilization() < b.Utilization()
+	})
+	
+	return candidateNodes[0], nil
+}
+
+func (s *ZoneSpreadScheduler) ScheduleBatch(containers []*container.Container, nodes []*node.Node) (map[*container.Container]*node.Node, error) {
+	return scheduleBatchSequentially(s, containers, nodes)
+}
+
+// spreadKeyCounts counts the containers carrying key per zone and per
+// node; an empty key matches nothing
+func spreadKeyCounts(key string, nodes []*node.Node) (map[string]int, map[*node.Node]int) {
+	zoneCounts := make(map[string]int)
+	nodeCounts := make(map[*node.Node]int)
+	if key == "" {
+		return zoneCounts, nodeCounts
+	}
+	
+	for _, n := range nodes {
+		for _, existing := range n.Containers() {
+			if existing.SpreadKey() == key {
+				zoneCounts[zoneOf(n)]++
+				nodeCounts[n]++
+			}
+		}
+	}
+	
+	return zoneCounts, nodeCounts
+}
+
+func zoneOf(n *node.Node) string {
+	if n.Zone() == "" {
+		return "node:" + n.Name()
+	}
+	return n.Zone()
+}
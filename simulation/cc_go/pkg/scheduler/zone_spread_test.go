@@ -0,0 +1,70 @@
+// pkg/scheduler/zone_spread_test.go - Zone spreading tests
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+
+	"cc_go/pkg/container"
+	"cc_go/pkg/node"
+)
+
+// zonedCluster has three zones of four nodes; zone-a is empty while the
+// others are half full, so plain spreading would keep choosing zone-a
+func zonedCluster() []*node.Node {
+	var nodes []*node.Node
+	for _, zone := range []string{"zone-a", "zone-b", "zone-c"} {
+		for i := 0; i < 4; i++ {
+			fraction := 0.5
+			if zone == "zone-a" {
+				fraction = 0
+			}
+			n := loadedNode(fmt.Sprintf("%s-%d", zone, i), fraction)
+			n.SetZone(zone)
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+func replicas(count int) []*container.Container {
+	containers := make([]*container.Container, count)
+	for i := range containers {
+		containers[i] = container.NewContainer(fmt.Sprintf("api-%d", i), "api", 0.5, 100, 1, 1, "web", 0)
+		containers[i].SetSpreadKey("api")
+	}
+	return containers
+}
+
+func TestZoneSpreadPlacesReplicasInDistinctZones(t *testing.T) {
+	s := NewZoneSpreadScheduler()
+	nodes := zonedCluster()
+	
+	zones := make(map[string]bool)
+	for _, c := range replicas(3) {
+		n, err := s.Schedule(c, nodes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		n.AddContainer(c)
+		if zones[n.Zone()] {
+			t.Errorf("%s placed in %s, which already has a replica", c.ID(), n.Zone())
+		}
+		zones[n.Zone()] = true
+	}
+}
+
+func TestZoneSpreadBatchPlacesReplicasInDistinctZones(t *testing.T) {
+	placements, err := NewZoneSpreadScheduler().ScheduleBatch(replicas(3), zonedCluster())
+	if err != nil {
+		t.Fatal(err)
+	}
+	
+	zones := make(map[string]bool)
+	for _, n := range placements {
+		zones[n.Zone()] = true
+	}
+	if len(placements) != 3 || len(zones) != 3 {
+		t.Errorf("planned %d replicas across %d zones, want 3 across 3", len(placements), len(zones))
+	}
+}
@@ -0,0 +1,251 @@
+// pkg/workLoad/composite.go - Workload mixing several sources
+package workLoad
+
+import (
+	"cc_go/pkg/clock"
+	"cc_go/pkg/container"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Source is one workload of a CompositeWorkloadGenerator. Its weight sets
+// its share of the arrivals while it is active; with a Period it is only
+// active for the first Active of every Period, e.g. a batch burst of 10s
+// every minute. Start delays its first activity.
+type Source struct {
+	Name      string
+	Generator WorkloadGenerator
+	Weight    int
+	Start     time.Duration
+	Period    time.Duration // 0 for always active once started
+	Active    time.Duration // active part of each period
+}
+
+// active reports whether the source may emit elapsed into the run
+func (s Source) active(elapsed time.Duration) bool {
+	if elapsed < s.Start {
+		return false
+	}
+	if s.Period <= 0 {
+		return true
+	}
+	return (elapsed-s.Start)%s.Period < s.Active
+}
+
+// CompositeWorkloadGenerator interleaves the containers of several
+// workloads: each call draws from one of the sources that are active and
+// have containers left, picked by weight, and tags the container with the
+// source's name. It has more while any source has more, although none may
+// be active at that moment, in which case NextContainer returns nil.
+type CompositeWorkloadGenerator struct {
+	mu        sync.Mutex
+	sources   []Source
+	rng       *rand.Rand
+	startTime time.Time // set by the first NextContainer call
+	maxDuration time.Duration // zero means no time limit
+	limitStart  time.Time
+	clock       clock.Clock // the run's clock; the wall clock unless set
+}
+
+// NewCompositeWorkload mixes sources; their names must be unique and their
+// weights positive
+func NewCompositeWorkload(sources []Source) (*CompositeWorkloadGenerator, error) {
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("composite workload has no sources")
+	}
+	names := make(map[string]bool, len(sources))
+	for _, s := range sources {
+		if s.Generator == nil {
+			return nil, fmt.Errorf("workload source %q has no generator", s.Name)
+		}
+		if names[s.Name] {
+			return nil, fmt.Errorf("workload source %q is listed twice", s.Name)
+		}
+		names[s.Name] = true
+		if s.Weight <= 0 {
+			return nil, fmt.Errorf("workload source %q: weight must be positive, got %d", s.Name, s.Weight)
+		}
+		if s.Start < 0 || s.Period < 0 || s.Active < 0 {
+			return nil, fmt.Errorf("workload source %q: start, period and active must not be negative", s.Name)
+		}
+		if s.Period > 0 && (s.Active <= 0 || s.Active > s.Period) {
+			return nil, fmt.Errorf("workload source %q: active must be in (0, period], got %v of %v", s.Name, s.Active, s.Period)
+		}
+	}
+	
+	return &CompositeWorkloadGenerator{
+		sources: append([]Source(nil), sources...),
+		rng:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		clock:   clock.Real{},
+	}, nil
+}
+
+func (g *CompositeWorkloadGenerator) Sources() []Source {
+	return g.sources
+}
+
+func (g *CompositeWorkloadGenerator) HasNext() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	
+	if g.maxDuration > 0 && g.clock.Now().Sub(g.limitStart) >= g.maxDuration {
+		return false
+	}
+	for _, s := range g.sources {
+		if s.Generator.HasNext() {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *CompositeWorkloadGenerator) NextContainer() *container.Container {
+	if !g.HasNext() {
+		return nil
+	}
+	
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	
+	if g.startTime.IsZero() {
+		g.startTime = g.clock.Now()
+	}
+	elapsed := g.clock.Now().Sub(g.startTime)
+	
+	ready := make([]Source, 0, len(g.sources))
+	totalWeight := 0
+	for _, s := range g.sources {
+		if s.active(elapsed) && s.Generator.HasNext() {
+			ready = append(ready, s)
+			totalWeight += s.Weight
+		}
+	}
+	if totalWeight == 0 {
+		return nil
+	}
+	
+	r := g.rng.Intn(totalWeight)
+	for _, s := range ready {
+		r -= s.Weight
+		if r < 0 {
+			c := s.Generator.NextContainer()
+			if c != nil {
+				c.SetSource(s.Name)
+			}
+			return c
+		}
+	}
+	return nil
+}
+
+// SetSeed makes the mix reproducible, seeding every seedable source with
+// its own seed derived from seed
+func (g *CompositeWorkloadGenerator) SetSeed(seed int64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	
+	g.rng = rand.New(rand.NewSource(seed))
+	for i, s := range g.sources {
+		if seeded, ok := s.Generator.(interface{ SetSeed(int64) }); ok {
+			seeded.SetSeed(seed + int64(i) + 1)
+		}
+	}
+}
+
+// SetMaxDuration stops generation from every source once d has elapsed
+// since this call; a zero duration removes the time limit
+func (g *CompositeWorkloadGenerator) SetMaxDuration(d time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	
+	g.limitStart = g.clock.Now()
+	g.maxDuration = d
+}
+
+// SetClock times the sources' activity and the time limit by c, counting
+// from now on c, and passes c on to every source that keeps time
+func (g *CompositeWorkloadGenerator) SetClock(c clock.Clock) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	
+	g.clock = c
+	g.limitStart = c.Now()
+	g.startTime = time.Time{}
+	for _, s := range g.sources {
+		if clocked, ok := s.Generator.(interface{ SetClock(clock.Clock) }); ok {
+			clocked.SetClock(c)
+		}
+	}
+}
+
+// SetReferenceNode passes the reference node on to every source that
+// resolves percentage requests
+func (g *CompositeWorkloadGenerator) SetReferenceNode(size NodeSize) {
+	for _, s := range g.sources {
+		if referenced, ok := s.Generator.(interface{ SetReferenceNode(NodeSize) }); ok {
+			referenced.SetReferenceNode(size)
+		}
+	}
+}
+
+// ReplicaLimits merges the sources' replica limits; where two sources limit
+// the same type, the later source wins
+func (g *CompositeWorkloadGenerator) ReplicaLimits() map[string]ReplicaLimits {
+	limits := make(map[string]ReplicaLimits)
+	for _, s := range g.sources {
+		if limited, ok := s.Generator.(interface{ ReplicaLimits() map[string]ReplicaLimits }); ok {
+			for containerType, l := range limited.ReplicaLimits() {
+				limits[containerType] = l
+			}
+		}
+	}
+	return limits
+}
+
+// NextOfType draws a container of the given type from the first source
+// that generates it; nil means none does
+func (g *CompositeWorkloadGenerator) NextOfType(containerType string) *container.Container {
+	for _, s := range g.sources {
+		typed, ok := s.Generator.(interface{ NextOfType(string) *container.Container })
+		if !ok {
+			continue
+		}
+		if c := typed.NextOfType(containerType); c != nil {
+			c.SetSource(s.Name)
+			return c
+		}
+	}
+	return nil
+}
+
+// TypeWeights combines the sources' type weights, each scaled to the
+// source's share of the total weight
+func (g *CompositeWorkloadGenerator) TypeWeights() map[string]float64 {
+	totalWeight := 0
+	for _, s := range g.sources {
+		totalWeight += s.Weight
+	}
+	
+	weights := make(map[string]float64)
+	for _, s := range g.sources {
+		weighted, ok := s.Generator.(interface{ TypeWeights() map[string]float64 })
+		if !ok {
+			continue
+		}
+		sourceWeights := weighted.TypeWeights()
+		sum := 0.0
+		for _, w := range sourceWeights {
+			sum += w
+		}
+		if sum <= 0 {
+			continue
+		}
+		share := float64(s.Weight) / float64(totalWeight)
+		for containerType, w := range sourceWeights {
+			weights[containerType] += share * w / sum
+		}
+	}
+	return weights
+}
@@ -0,0 +1,275 @@
+// pkg/workLoad/jsonlines.go - Streaming JSON Lines workloads
+package workLoad
+
+import (
+	"bufio"
+	"bytes"
+	"cc_go/pkg/clock"
+	"cc_go/pkg/container"
+	"cc_go/pkg/logging"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// maxLoggedMalformed caps how many skipped lines are logged individually
+const maxLoggedMalformed = 10
+
+// ContainerSpec is one line of a JSON Lines workload: a single container
+// with fixed requests, in the units of the template fields
+type ContainerSpec struct {
+	Name            string            `json:"name"`
+	Image           string            `json:"image"`
+	CPU             float64           `json:"cpu"`
+	Memory          float64           `json:"memory"`
+	Network         float64           `json:"network"`
+	IO              float64           `json:"io"`
+	Disk            float64           `json:"disk"`
+	GPUMemory       float64           `json:"gpu_memory"`
+	Startup         float64           `json:"startup"` // seconds from placement until ready
+	Lifetime        float64           `json:"lifetime"` // seconds from placement until completion
+	Type            string            `json:"type"`
+	Priority        int               `json:"priority"`
+	ImageSize       float64           `json:"image_size"`
+	UsageProfile    string            `json:"usage_profile"`
+	SpreadKey       string            `json:"spread_key"`
+	DisruptionCost  float64           `json:"disruption_cost"`
+	PDBMinAvailable int               `json:"pdb_min_available"`
+	NUMAPinned      bool              `json:"numa_pinned"`
+	NodeName        string            `json:"node_name"`
+	Sidecars        []SidecarTemplate `json:"sidecars"`
+}
+
+// template is the spec as a single-valued template, so specs share the
+// template validation and container construction
+func (s ContainerSpec) template() ContainerTemplate {
+	return ContainerTemplate{
+		Name:            s.Name,
+		Image:           s.Image,
+		CPUMin:          s.CPU,
+		CPUMax:          s.CPU,
+		MemoryMin:       s.Memory,
+		MemoryMax:       s.Memory,
+		NetworkMin:      s.Network,
+		NetworkMax:      s.Network,
+		IOMin:           s.IO,
+		IOMax:           s.IO,
+		DiskMin:         s.Disk,
+		DiskMax:         s.Disk,
+		GPUMemoryMin:    s.GPUMemory,
+		GPUMemoryMax:    s.GPUMemory,
+		StartupMin:      s.Startup,
+		StartupMax:      s.Startup,
+		LifetimeMin:     s.Lifetime,
+		LifetimeMax:     s.Lifetime,
+		Type:            s.Type,
+		Priority:        s.Priority,
+		Weight:          1,
+		ImageSize:       s.ImageSize,
+		UsageProfile:    s.UsageProfile,
+		SpreadKey:       s.SpreadKey,
+		DisruptionCost:  s.DisruptionCost,
+		PDBMinAvailable: s.PDBMinAvailable,
+		NUMAPinned:      s.NUMAPinned,
+		NodeName:        s.NodeName,
+		Sidecars:        s.Sidecars,
+	}
+}
+
+// IsJSONLines reports whether path names a JSON Lines workload: "-" for
+// stdin, or a file ending in .jsonl or .ndjson
+func IsJSONLines(path string) bool {
+	if path == "-" {
+		return true
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".jsonl" || ext == ".ndjson"
+}
+
+// JSONLinesWorkloadGenerator streams containers from a JSON Lines file, one
+// ContainerSpec per line, in file order. Only the next container is held in
+// memory, so arbitrarily large traces can be replayed. Blank lines are
+// ignored; lines that do not decode to a valid spec are skipped and counted.
+type JSONLinesWorkloadGenerator struct {
+	name        string
+	reader      *bufio.Reader
+	closer      io.Closer // nil when reading stdin
+	next        *container.Container
+	done        bool
+	err         error // read error that ended the stream early
+	line        int
+	malformed   int
+	count       int
+	maxCount    int // zero means no limit
+	startTime   time.Time
+	maxDuration time.Duration // zero means no time limit
+	clock       clock.Clock   // the run's clock; the wall clock unless set
+}
+
+// NewJSONLinesWorkload opens a JSON Lines workload; "-" reads stdin
+func NewJSONLinesWorkload(filename string) (*JSONLinesWorkloadGenerator, error) {
+	if filename == "-" {
+		return NewJSONLinesWorkloadFromReader("stdin", os.Stdin), nil
+	}
+	
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	
+	g := NewJSONLinesWorkloadFromReader(filename, file)
+	g.closer = file
+	return g, nil
+}
+
+// NewJSONLinesWorkloadFromReader streams specs from r; name identifies the
+// source in log messages
+func NewJSONLinesWorkloadFromReader(name string, r io.Reader) *JSONLinesWorkloadGenerator {
+	return &JSONLinesWorkloadGenerator{
+		name:   name,
+		reader: bufio.NewReader(r),
+		clock:  clock.Real{},
+	}
+}
+
+// Close releases the underlying file; stdin is left open
+func (g *JSONLinesWorkloadGenerator) Close() error {
+	g.next = nil
+	return g.finish()
+}
+
+// finish stops reading and closes the file, keeping a container that was
+// already read
+func (g *JSONLinesWorkloadGenerator) finish() error {
+	g.done = true
+	if g.closer == nil {
+		return nil
+	}
+	closer := g.closer
+	g.closer = nil
+	return closer.Close()
+}
+
+func (g *JSONLinesWorkloadGenerator) SetMaxCount(count int) {
+	g.maxCount = count
+}
+
+// SetMaxDuration stops generation once d has elapsed since this call; a
+// zero duration removes the time limit
+func (g *JSONLinesWorkloadGenerator) SetMaxDuration(d time.Duration) {
+	g.startTime = g.clock.Now()
+	g.maxDuration = d
+}
+
+// SetClock measures the time limit on c, counting from now on c
+func (g *JSONLinesWorkloadGenerator) SetClock(c clock.Clock) {
+	g.clock = c
+	g.startTime = c.Now()
+}
+
+// Lines is the number of lines read so far
+func (g *JSONLinesWorkloadGenerator) Lines() int {
+	return g.line
+}
+
+// Malformed is the number of lines skipped so far
+func (g *JSONLinesWorkloadGenerator) Malformed() int {
+	return g.malformed
+}
+
+// Err returns the read error that ended the stream early, if any
+func (g *JSONLinesWorkloadGenerator) Err() error {
+	return g.err
+}
+
+func (g *JSONLinesWorkloadGenerator) HasNext() bool {
+	if g.maxDuration > 0 && g.clock.Now().Sub(g.startTime) >= g.maxDuration {
+		return false
+	}
+	if g.maxCount > 0 && g.count >= g.maxCount {
+		return false
+	}
+	if g.next == nil {
+		g.advance()
+	}
+	return g.next != nil
+}
+
+func (g *JSONLinesWorkloadGenerator) NextContainer() *container.Container {
+	if !g.HasNext() {
+		return nil
+	}
+	
+	c := g.next
+	g.next = nil
+	g.count++
+	return c
+}
+
+// advance reads up to the next valid spec, or to the end of the input
+func (g *JSONLinesWorkloadGenerator) advance() {
+	for !g.done {
+		data, err := g.reader.ReadBytes('\n')
+		if len(data) > 0 {
+			g.line++
+			if c, skip := g.parse(data); c != nil {
+				g.next = c
+			} else if !skip {
+				g.malformed++
+			}
+		}
+		
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				g.err = err
+				logging.Errorf("Stopped reading workload %s at line %d: %v", g.name, g.line, err)
+			}
+			g.finish()
+		}
+		if g.next != nil {
+			return
+		}
+	}
+}
+
+// parse decodes one line into a container. Blank lines are skipped without
+// counting as malformed.
+func (g *JSONLinesWorkloadGenerator) parse(data []byte) (*container.Container, bool) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, true
+	}
+	
+	spec, err := decodeSpec(data)
+	if err == nil {
+		template := spec.template()
+		if err = validateDefinition(WorkloadDefinition{Templates: []ContainerTemplate{template}}); err == nil {
+			return template.instantiate(spec.CPU, spec.Memory, spec.Network, spec.IO, spec.Disk, spec.GPUMemory, spec.Startup, spec.Lifetime), false
+		}
+	}
+	
+	if g.malformed < maxLoggedMalformed {
+		logging.Warnf("Skipping malformed line %d of workload %s: %v", g.line, g.name, err)
+	}
+	return nil, false
+}
+
+// decodeSpec decodes exactly one spec, rejecting unknown fields and
+// trailing data so typos do not silently become zero requests
+func decodeSpec(data []byte) (ContainerSpec, error) {
+	var spec ContainerSpec
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&spec); err != nil {
+		return spec, err
+	}
+	if decoder.More() {
+		return spec, fmt.Errorf("unexpected data after the container spec")
+	}
+	return spec, nil
+}
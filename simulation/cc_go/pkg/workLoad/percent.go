@@ -0,0 +1,73 @@
+// pkg/workLoad/percent.go - Requests given as a share of a node
+package workLoad
+
+import (
+	"fmt"
+)
+
+// NodeSize is the capacity percentage requests are resolved against; a zero
+// resource (e.g. unconstrained disk) resolves every percentage of it to 0
+type NodeSize struct {
+	CPU     float64
+	Memory  float64
+	Network float64
+	IO      float64
+	Disk    float64
+}
+
+// hasPercent reports whether any request of the template is a percentage
+func (t ContainerTemplate) hasPercent() bool {
+	return t.CPUPercent > 0 || t.MemoryPercent > 0 || t.NetworkPercent > 0 || t.IOPercent > 0 || t.DiskPercent > 0
+}
+
+// resolve turns the template's percentage requests into fixed absolute
+// ones against size; requests given as ranges are left alone
+func (t ContainerTemplate) resolve(size NodeSize) ContainerTemplate {
+	fixed := func(percent, capacity float64, min, max *float64) {
+		if percent > 0 {
+			*min = percent / 100 * capacity
+			*max = *min
+		}
+	}
+	fixed(t.CPUPercent, size.CPU, &t.CPUMin, &t.CPUMax)
+	fixed(t.MemoryPercent, size.Memory, &t.MemoryMin, &t.MemoryMax)
+	fixed(t.NetworkPercent, size.Network, &t.NetworkMin, &t.NetworkMax)
+	fixed(t.IOPercent, size.IO, &t.IOMin, &t.IOMax)
+	fixed(t.DiskPercent, size.Disk, &t.DiskMin, &t.DiskMax)
+	return t
+}
+
+// Resolve returns the definition with every percentage request replaced by
+// its absolute value on a node of the given size
+func (d WorkloadDefinition) Resolve(size NodeSize) WorkloadDefinition {
+	templates := make([]ContainerTemplate, len(d.Templates))
+	for i, template := range d.Templates {
+		templates[i] = template.resolve(size)
+	}
+	return WorkloadDefinition{Templates: templates, Replicas: d.Replicas}
+}
+
+// validatePercents rejects percentages outside (0, 100] and requests given
+// both as a percentage and as a range
+func validatePercents(t ContainerTemplate) error {
+	percents := []struct {
+		resource string
+		percent  float64
+		max      float64
+	}{
+		{"cpu", t.CPUPercent, t.CPUMax},
+		{"memory", t.MemoryPercent, t.MemoryMax},
+		{"network", t.NetworkPercent, t.NetworkMax},
+		{"io", t.IOPercent, t.IOMax},
+		{"disk", t.DiskPercent, t.DiskMax},
+	}
+	for _, p := range percents {
+		if p.percent < 0 || p.percent > 100 {
+			return fmt.Errorf("template %q has %s_percent %g outside (0, 100]", t.Name, p.resource, p.percent)
+		}
+		if p.percent > 0 && p.max > 0 {
+			return fmt.Errorf("template %q sets both %s_percent and a %s range", t.Name, p.resource, p.resource)
+		}
+	}
+	return nil
+}
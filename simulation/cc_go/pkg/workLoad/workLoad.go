@@ -1,10 +1,13 @@
 package workLoad
 
 import (
+	"cc_go/pkg/clock"
 	"cc_go/pkg/container"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"math/rand"
+	"sync"
 	"time"
 )
 
@@ -27,36 +30,237 @@ type ContainerTemplate struct {
 	NetworkMax     float64 `json:"network_max"`
 	IOMin          float64 `json:"io_min"`
 	IOMax          float64 `json:"io_max"`
+	DiskMin        float64 `json:"disk_min"`
+	DiskMax        float64 `json:"disk_max"`
+	GPUMemoryMin   float64 `json:"gpu_memory_min"` // GB on a single GPU
+	GPUMemoryMax   float64 `json:"gpu_memory_max"`
+	StartupMin     float64 `json:"startup_min"` // seconds from placement until ready
+	StartupMax     float64 `json:"startup_max"`
+	LifetimeMin    float64 `json:"lifetime_min"` // seconds from placement until completion
+	LifetimeMax    float64 `json:"lifetime_max"`
 	Type           string  `json:"type"`
 	Priority       int     `json:"priority"`
 	Weight         int     `json:"weight"`
+	ImageSize      float64 `json:"image_size"` // MB pulled on nodes that do not cache the image yet
+	UsageProfile   string  `json:"usage_profile"` // constant (default), ramp, sawtooth or spike
+	SpreadKey      string  `json:"spread_key"` // spread containers with the same key across zones
+	GroupSize      int     `json:"group_size"` // emit ordered groups of this many containers, placed in index order
+	DisruptionCost float64 `json:"disruption_cost"` // cost of evicting one container; 0 means 1
+	PDBMinAvailable int    `json:"pdb_min_available"` // evictions never leave fewer containers of this template running
+	NUMAPinned     bool    `json:"numa_pinned"` // needs its CPU and memory within one NUMA socket
+	NodeName       string  `json:"node_name"` // run only on the node with this name or ID
+	LimitFactor    float64 `json:"limit_factor"` // CPU and memory limits as a multiple of the requests; 1 for Guaranteed, 0 for no limits
+	Sidecars       []SidecarTemplate `json:"sidecars"` // co-located with every container of the template
+	CPUPercent     float64 `json:"cpu_percent"` // request as a percentage of the reference node, instead of cpu_min/cpu_max
+	MemoryPercent  float64 `json:"memory_percent"`
+	NetworkPercent float64 `json:"network_percent"`
+	IOPercent      float64 `json:"io_percent"`
+	DiskPercent    float64 `json:"disk_percent"`
+}
+
+// SidecarTemplate describes a sidecar with fixed requests
+type SidecarTemplate struct {
+	Name    string  `json:"name"`
+	Image   string  `json:"image"`
+	CPU     float64 `json:"cpu"`
+	Memory  float64 `json:"memory"`
+	Network float64 `json:"network"`
+	IO      float64 `json:"io"`
+	Disk    float64 `json:"disk"`
 }
 
 type WorkloadDefinition struct {
 	Templates []ContainerTemplate `json:"templates"`
+	Replicas  map[string]ReplicaLimits `json:"replicas"` // instance limits by container type
+}
+
+// ReplicaLimits bound how many containers of a type run at once: the
+// benchmark replaces completed containers to keep at least Min running and
+// turns away arrivals beyond Max. A zero Max means no cap.
+type ReplicaLimits struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
 }
 
+// FileWorkloadGenerator draws containers from the templates of a workload
+// file. Generation stops at whichever limit is reached first: maxCount
+// containers (SetMaxCount) or, if SetMaxDuration was called, the wall-clock
+// budget measured from that call.
 type FileWorkloadGenerator struct {
+	filename   string
+	mu         sync.Mutex // guards the templates, which Reload may swap
 	definition WorkloadDefinition
 	templates  []ContainerTemplate
 	weights    []int
 	totalWeight int
 	count      int
 	maxCount   int
+	rng        *rand.Rand
+	startTime  time.Time
+	maxDuration time.Duration // zero means no time limit
+	clock       clock.Clock   // the run's clock; the wall clock unless set
+	group       ContainerTemplate // template of the ordered group being emitted
+	groupName   string
+	groupNext   int // index of the next member to emit
+	groups      int // ordered groups started so far
+	reference   NodeSize // what percentage requests are resolved against
 }
 
 func NewWorkloadFromFile(filename string) (*FileWorkloadGenerator, error) {
-	data, err := ioutil.ReadFile(filename)
+	definition, err := LoadDefinition(filename)
 	if err != nil {
 		return nil, err
 	}
 	
+	g := newGenerator(definition)
+	g.filename = filename
+	
+	return g, nil
+}
+
+// NewWorkload creates a generator from an in-memory definition. It has no
+// file behind it, so Reload always fails.
+func NewWorkload(definition WorkloadDefinition) (*FileWorkloadGenerator, error) {
+	if err := validateDefinition(definition); err != nil {
+		return nil, fmt.Errorf("workload: %v", err)
+	}
+	
+	return newGenerator(definition), nil
+}
+
+func newGenerator(definition WorkloadDefinition) *FileWorkloadGenerator {
+	g := &FileWorkloadGenerator{
+		count:       0,
+		maxCount:    10000, // Large number as default
+		rng:         rand.New(rand.NewSource(time.Now().UnixNano())),
+		clock:       clock.Real{},
+	}
+	g.setDefinition(definition)
+	
+	return g
+}
+
+// Reload re-reads the workload file and swaps in its templates; containers
+// generated afterwards use them. If the file cannot be read or is invalid
+// the current templates stay in use and the error is returned.
+func (g *FileWorkloadGenerator) Reload() error {
+	if g.filename == "" {
+		return fmt.Errorf("workload was not loaded from a file")
+	}
+	
+	definition, err := LoadDefinition(g.filename)
+	if err != nil {
+		return err
+	}
+	
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.setDefinition(definition)
+	
+	return nil
+}
+
+// LoadDefinition reads and validates a workload file without creating a
+// generator for it
+func LoadDefinition(filename string) (WorkloadDefinition, error) {
 	var definition WorkloadDefinition
+	
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return definition, err
+	}
+	
 	if err := json.Unmarshal(data, &definition); err != nil {
-		return nil, err
+		return definition, err
+	}
+	
+	if err := validateDefinition(definition); err != nil {
+		return definition, fmt.Errorf("workload %s: %v", filename, err)
+	}
+	
+	return definition, nil
+}
+
+// validateDefinition rejects weights and resource ranges that cannot be
+// sampled from
+func validateDefinition(definition WorkloadDefinition) error {
+	if len(definition.Templates) == 0 {
+		return fmt.Errorf("no templates defined")
+	}
+	
+	totalWeight := 0
+	for _, template := range definition.Templates {
+		if template.Weight < 0 {
+			return fmt.Errorf("template %q has negative weight %d", template.Name, template.Weight)
+		}
+		if template.GroupSize < 0 {
+			return fmt.Errorf("template %q has negative group_size %d", template.Name, template.GroupSize)
+		}
+		if template.LimitFactor != 0 && template.LimitFactor < 1 {
+			return fmt.Errorf("template %q has limit_factor %g; limits must be 0 (none) or at least the requests", template.Name, template.LimitFactor)
+		}
+		if template.DisruptionCost < 0 || template.PDBMinAvailable < 0 {
+			return fmt.Errorf("template %q has a negative disruption_cost or pdb_min_available", template.Name)
+		}
+		for _, sidecar := range template.Sidecars {
+			if sidecar.CPU < 0 || sidecar.Memory < 0 || sidecar.Network < 0 || sidecar.IO < 0 || sidecar.Disk < 0 {
+				return fmt.Errorf("template %q: sidecar %q has a negative request", template.Name, sidecar.Name)
+			}
+		}
+		totalWeight += template.Weight
+		
+		if err := validatePercents(template); err != nil {
+			return err
+		}
+		
+		if _, err := container.NewUsageProfile(template.UsageProfile); err != nil {
+			return fmt.Errorf("template %q: %v", template.Name, err)
+		}
+		
+		ranges := []struct {
+			resource string
+			min, max float64
+		}{
+			{"cpu", template.CPUMin, template.CPUMax},
+			{"memory", template.MemoryMin, template.MemoryMax},
+			{"network", template.NetworkMin, template.NetworkMax},
+			{"io", template.IOMin, template.IOMax},
+			{"disk", template.DiskMin, template.DiskMax},
+			{"gpu_memory", template.GPUMemoryMin, template.GPUMemoryMax},
+			{"startup", template.StartupMin, template.StartupMax},
+			{"lifetime", template.LifetimeMin, template.LifetimeMax},
+		}
+		for _, r := range ranges {
+			if r.min < 0 {
+				return fmt.Errorf("template %q has negative %s_min %g", template.Name, r.resource, r.min)
+			}
+			if r.min > r.max {
+				return fmt.Errorf("template %q has %s_min %g greater than %s_max %g",
+					template.Name, r.resource, r.min, r.resource, r.max)
+			}
+		}
+	}
+	if totalWeight <= 0 {
+		return fmt.Errorf("no templates with positive weight")
+	}
+	
+	for containerType, limits := range definition.Replicas {
+		if limits.Min < 0 || limits.Max < 0 {
+			return fmt.Errorf("replicas of type %q must not be negative", containerType)
+		}
+		if limits.Max > 0 && limits.Min > limits.Max {
+			return fmt.Errorf("replicas of type %q: min %d exceeds max %d", containerType, limits.Min, limits.Max)
+		}
+		if limits.Min > 0 && !hasType(definition, containerType) {
+			return fmt.Errorf("replicas of type %q: no template with positive weight can replace its containers", containerType)
+		}
 	}
 	
-	templates := definition.Templates
+	return nil
+}
+
+func (g *FileWorkloadGenerator) setDefinition(definition WorkloadDefinition) {
+	templates := definition.Resolve(g.reference).Templates
 	weights := make([]int, len(templates))
 	totalWeight := 0
 	
@@ -65,23 +269,101 @@ func NewWorkloadFromFile(filename string) (*FileWorkloadGenerator, error) {
 		totalWeight += template.Weight
 	}
 	
-	rand.Seed(time.Now().UnixNano())
+	g.definition = definition
+	g.templates = templates
+	g.weights = weights
+	g.totalWeight = totalWeight
+}
+
+// TypeWeights returns the total template weight of each container type,
+// i.e. how often each type is generated relative to the others
+func (g *FileWorkloadGenerator) TypeWeights() map[string]float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
 	
-	return &FileWorkloadGenerator{
-		definition:  definition,
-		templates:   templates,
-		weights:     weights,
-		totalWeight: totalWeight,
-		count:       0,
-		maxCount:    10000, // Large number as default
-	}, nil
+	weights := make(map[string]float64)
+	for _, template := range g.templates {
+		weights[template.Type] += float64(template.Weight)
+	}
+	return weights
+}
+
+// SetReferenceNode resolves percentage requests against size from now on,
+// normally the smallest node of the cluster
+func (g *FileWorkloadGenerator) SetReferenceNode(size NodeSize) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	
+	g.reference = size
+	g.setDefinition(g.definition)
+}
+
+// ReplicaLimits returns the workload's instance limits by container type
+func (g *FileWorkloadGenerator) ReplicaLimits() map[string]ReplicaLimits {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	
+	return g.definition.Replicas
+}
+
+// NextOfType generates a container of the given type, drawn from that
+// type's templates by weight, e.g. to replace one that completed. It does
+// not count against the generator's limits; nil means no template has the
+// type.
+func (g *FileWorkloadGenerator) NextOfType(containerType string) *container.Container {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	
+	totalWeight := 0
+	for _, template := range g.templates {
+		if template.Type == containerType {
+			totalWeight += template.Weight
+		}
+	}
+	if totalWeight <= 0 {
+		return nil
+	}
+	
+	r := g.rng.Intn(totalWeight)
+	for _, template := range g.templates {
+		if template.Type != containerType {
+			continue
+		}
+		r -= template.Weight
+		if r < 0 {
+			return g.sample(template)
+		}
+	}
+	return nil
 }
 
 func (g *FileWorkloadGenerator) SetMaxCount(count int) {
 	g.maxCount = count
 }
 
+// SetSeed makes the generated container sequence reproducible
+func (g *FileWorkloadGenerator) SetSeed(seed int64) {
+	g.rng = rand.New(rand.NewSource(seed))
+}
+
+// SetMaxDuration stops generation once d has elapsed since this call; a
+// zero duration removes the time limit
+func (g *FileWorkloadGenerator) SetMaxDuration(d time.Duration) {
+	g.startTime = g.clock.Now()
+	g.maxDuration = d
+}
+
+// SetClock measures the time limit on c, e.g. a run's simulated clock,
+// counting from now on c
+func (g *FileWorkloadGenerator) SetClock(c clock.Clock) {
+	g.clock = c
+	g.startTime = c.Now()
+}
+
 func (g *FileWorkloadGenerator) HasNext() bool {
+	if g.maxDuration > 0 && g.clock.Now().Sub(g.startTime) >= g.maxDuration {
+		return false
+	}
 	return g.count < g.maxCount
 }
 
@@ -92,26 +374,52 @@ func (g *FileWorkloadGenerator) NextContainer() *container.Container {
 	
 	g.count++
 	
-	// Select a template based on weights
-	r := rand.Intn(g.totalWeight)
-	templateIndex := 0
-	for i, weight := range g.weights {
-		r -= weight
-		if r < 0 {
-			templateIndex = i
-			break
-		}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	
+	// Validation rejects these, but never let a bad distribution panic
+	if g.totalWeight <= 0 || len(g.templates) == 0 {
+		return nil
 	}
 	
-	template := g.templates[templateIndex]
+	// Finish an ordered group before drawing another template
+	var template ContainerTemplate
+	if g.groupNext > 0 && g.groupNext < g.group.GroupSize {
+		template = g.group
+	} else {
+		// Select a template based on weights
+		r := g.rng.Intn(g.totalWeight)
+		templateIndex := 0
+		for i, weight := range g.weights {
+			r -= weight
+			if r < 0 {
+				templateIndex = i
+				break
+			}
+		}
+		
+		template = g.templates[templateIndex]
+		g.groupNext = 0
+		if template.GroupSize > 1 {
+			g.group = template
+			g.groups++
+			g.groupName = fmt.Sprintf("%s-group-%d", template.Name, g.groups)
+		}
+	}
 	
-	// Generate random values within the template's ranges
-	cpu := template.CPUMin + rand.Float64()*(template.CPUMax-template.CPUMin)
-	memory := template.MemoryMin + rand.Float64()*(template.MemoryMax-template.MemoryMin)
-	network := template.NetworkMin + rand.Float64()*(template.NetworkMax-template.NetworkMin)
-	io := template.IOMin + rand.Float64()*(template.IOMax-template.IOMin)
+	c := g.sample(template)
+	if template.GroupSize > 1 {
+		c.SetOrdering(g.groupName, g.groupNext)
+		g.groupNext++
+	}
 	
-	return container.NewContainer(
+	return c
+}
+
+// instantiate creates a container of the template with the given requests
+// and durations in seconds
+func (template ContainerTemplate) instantiate(cpu, memory, network, io, disk, gpuMemory, startup, lifetime float64) *container.Container {
+	c := container.NewContainer(
 		template.Name,
 		template.Image,
 		cpu,
@@ -121,4 +429,57 @@ func (g *FileWorkloadGenerator) NextContainer() *container.Container {
 		template.Type,
 		template.Priority,
 	)
-}
\ No newline at end of file
+	c.SetDiskRequest(disk)
+	c.SetGPUMemoryRequest(gpuMemory)
+	c.SetStartupDuration(time.Duration(startup * float64(time.Second)))
+	c.SetLifetime(time.Duration(lifetime * float64(time.Second)))
+	c.SetSpreadKey(template.SpreadKey)
+	if template.DisruptionCost > 0 {
+		c.SetDisruption(template.DisruptionCost, template.PDBMinAvailable)
+	} else {
+		c.SetDisruption(1, template.PDBMinAvailable)
+	}
+	c.SetNUMAPinned(template.NUMAPinned)
+	c.SetNodeName(template.NodeName)
+	if template.LimitFactor > 0 {
+		c.SetLimits(cpu*template.LimitFactor, memory*template.LimitFactor)
+	}
+	for _, spec := range template.Sidecars {
+		sidecar := container.NewContainer(spec.Name, spec.Image, spec.CPU, spec.Memory, spec.Network, spec.IO, template.Type, template.Priority)
+		sidecar.SetDiskRequest(spec.Disk)
+		c.AddSidecar(sidecar)
+	}
+	c.SetImageSize(template.ImageSize)
+	// Validated when the definition was loaded
+	if profile, err := container.NewUsageProfile(template.UsageProfile); err == nil {
+		c.SetUsageProfile(profile)
+	}
+	
+	return c
+}
+
+// sample creates a container with random values within the template's
+// ranges; the caller holds g.mu
+func (g *FileWorkloadGenerator) sample(template ContainerTemplate) *container.Container {
+	cpu := template.CPUMin + g.rng.Float64()*(template.CPUMax-template.CPUMin)
+	memory := template.MemoryMin + g.rng.Float64()*(template.MemoryMax-template.MemoryMin)
+	network := template.NetworkMin + g.rng.Float64()*(template.NetworkMax-template.NetworkMin)
+	io := template.IOMin + g.rng.Float64()*(template.IOMax-template.IOMin)
+	disk := template.DiskMin + g.rng.Float64()*(template.DiskMax-template.DiskMin)
+	gpuMemory := template.GPUMemoryMin + g.rng.Float64()*(template.GPUMemoryMax-template.GPUMemoryMin)
+	startup := template.StartupMin + g.rng.Float64()*(template.StartupMax-template.StartupMin)
+	lifetime := template.LifetimeMin + g.rng.Float64()*(template.LifetimeMax-template.LifetimeMin)
+	
+	return template.instantiate(cpu, memory, network, io, disk, gpuMemory, startup, lifetime)
+}
+
+// hasType reports whether a template with positive weight generates
+// containers of containerType
+func hasType(definition WorkloadDefinition, containerType string) bool {
+	for _, template := range definition.Templates {
+		if template.Type == containerType && template.Weight > 0 {
+			return true
+		}
+	}
+	return false
+}
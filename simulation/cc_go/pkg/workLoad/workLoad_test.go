@@ -0,0 +1,57 @@
+// pkg/workLoad/workLoad_test.go - Workload definition validation tests
+package workLoad
+
+import (
+	"strings"
+	"testing"
+)
+
+func validTemplate() ContainerTemplate {
+	return ContainerTemplate{
+		Name: "web", Image: "nginx", Type: "web", Weight: 1,
+		CPUMin: 0.5, CPUMax: 1, MemoryMin: 128, MemoryMax: 256,
+		NetworkMin: 10, NetworkMax: 20, IOMin: 10, IOMax: 20,
+		LifetimeMin: 10, LifetimeMax: 30,
+	}
+}
+
+func TestValidateDefinition(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(d *WorkloadDefinition)
+		err    string // substring of the expected error; empty for valid
+	}{
+		{"valid", func(d *WorkloadDefinition) {}, ""},
+		{"equal min and max", func(d *WorkloadDefinition) { d.Templates[0].CPUMax = d.Templates[0].CPUMin }, ""},
+		{"no templates", func(d *WorkloadDefinition) { d.Templates = nil }, "no templates defined"},
+		{"inverted cpu range", func(d *WorkloadDefinition) { d.Templates[0].CPUMin = 2 }, "cpu_min 2 greater than cpu_max 1"},
+		{"inverted memory range", func(d *WorkloadDefinition) { d.Templates[0].MemoryMax = 64 }, "memory_min 128 greater than memory_max 64"},
+		{"inverted lifetime range", func(d *WorkloadDefinition) { d.Templates[0].LifetimeMin = 60 }, "lifetime_min 60 greater than lifetime_max 30"},
+		{"negative cpu min", func(d *WorkloadDefinition) { d.Templates[0].CPUMin = -1 }, "negative cpu_min"},
+		{"negative io min", func(d *WorkloadDefinition) { d.Templates[0].IOMin = -5 }, "negative io_min"},
+		{"negative disk min", func(d *WorkloadDefinition) { d.Templates[0].DiskMin = -1 }, "negative disk_min"},
+		{"negative weight", func(d *WorkloadDefinition) { d.Templates[0].Weight = -1 }, "negative weight"},
+		{"zero total weight", func(d *WorkloadDefinition) { d.Templates[0].Weight = 0 }, "no templates with positive weight"},
+		{"zero weight beside positive", func(d *WorkloadDefinition) {
+			idle := validTemplate()
+			idle.Name, idle.Weight = "idle", 0
+			d.Templates = append(d.Templates, idle)
+		}, ""},
+	}
+	
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			definition := WorkloadDefinition{Templates: []ContainerTemplate{validTemplate()}}
+			test.modify(&definition)
+			err := validateDefinition(definition)
+			switch {
+			case test.err == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case test.err != "" && err == nil:
+				t.Errorf("accepted, want an error containing %q", test.err)
+			case test.err != "" && !strings.Contains(err.Error(), test.err):
+				t.Errorf("error %q does not contain %q", err, test.err)
+			}
+		})
+	}
+}
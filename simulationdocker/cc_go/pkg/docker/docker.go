@@ -16,33 +16,38 @@ import (
 type DockerManager struct {
 	client  *client.Client
 	ctx     context.Context
+	cancel  context.CancelFunc // stops running monitors on Close
 }
 
 
 func NewDockerManager() (*DockerManager, error) {
-	ctx := context.Background()
+	ctx, cancel := context.WithCancel(context.Background())
 	
 	cli, err := client.NewClientWithOpts(
 		client.WithHost("tcp://host.docker.internal:2375"),
 		client.WithAPIVersionNegotiation(),
 	)
 	if err != nil {
+		cancel()
 		return nil, fmt.Errorf("failed to create Docker client: %v", err)
 	}
 	
 	_, err = cli.Ping(ctx)
 	if err != nil {
+		cancel()
 		return nil, fmt.Errorf("failed to connect to Docker daemon: %v", err)
 	}
 	
 	return &DockerManager{
 		client: cli,
 		ctx:    ctx,
+		cancel: cancel,
 	}, nil
 }
 
 
 func (m *DockerManager) Close() {
+	m.cancel()
 	m.client.Close()
 }
 
@@ -93,28 +98,43 @@ func (m *DockerManager) StopContainer(containerID string) error {
 }
 
 func (m *DockerManager) GetContainerStats(containerID string) (float64, float64, error) {
-	stats, err := m.client.ContainerStats(m.ctx, containerID, false)
+	statsJSON, err := m.readStats(containerID)
 	if err != nil {
 		return 0, 0, err
 	}
-	defer stats.Body.Close()
 	
-	// Parse stats
+	return cpuPercent(statsJSON), memoryMB(statsJSON.MemoryStats.Usage), nil
+}
+
+// readStats takes a single stats reading of the container
+func (m *DockerManager) readStats(containerID string) (types.StatsJSON, error) {
 	var statsJSON types.StatsJSON
-	if err := json.NewDecoder(stats.Body).Decode(&statsJSON); err != nil {
-		return 0, 0, err
+	stats, err := m.client.ContainerStats(m.ctx, containerID, false)
+	if err != nil {
+		return statsJSON, err
 	}
+	defer stats.Body.Close()
 	
-	// Calculate CPU usage percentage
-	cpuDelta := float64(statsJSON.CPUStats.CPUUsage.TotalUsage - statsJSON.PreCPUStats.CPUUsage.TotalUsage)
-	systemDelta := float64(statsJSON.CPUStats.SystemUsage - statsJSON.PreCPUStats.SystemUsage)
-	cpuPercent := 0.0
-	if systemDelta > 0.0 && cpuDelta > 0.0 {
-		cpuPercent = (cpuDelta / systemDelta) * float64(len(statsJSON.CPUStats.CPUUsage.PercpuUsage)) * 100.0
+	err = json.NewDecoder(stats.Body).Decode(&statsJSON)
+	return statsJSON, err
+}
+
+// cpuPercent is the container's CPU use since the previous reading, where
+// 100 is one core
+func cpuPercent(statsJSON types.StatsJSON) float64 {
+	cpuDelta := float64(statsJSON.CPUStats.CPUUsage.TotalUsage) - float64(statsJSON.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(statsJSON.CPUStats.SystemUsage) - float64(statsJSON.PreCPUStats.SystemUsage)
+	// cgroup v2 hosts leave the per-CPU usage empty
+	cpus := float64(statsJSON.CPUStats.OnlineCPUs)
+	if cpus == 0 {
+		cpus = float64(len(statsJSON.CPUStats.CPUUsage.PercpuUsage))
 	}
-	
-	// Memory usage in MB
-	memoryUsageMB := float64(statsJSON.MemoryStats.Usage) / 1024.0 / 1024.0
-	
-	return cpuPercent, memoryUsageMB, nil
+	if systemDelta <= 0.0 || cpuDelta <= 0.0 {
+		return 0
+	}
+	return (cpuDelta / systemDelta) * cpus * 100.0
+}
+
+func memoryMB(bytes uint64) float64 {
+	return float64(bytes) / 1024.0 / 1024.0
 }
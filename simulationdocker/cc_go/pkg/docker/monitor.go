@@ -0,0 +1,177 @@
+package docker
+
+import (
+	"encoding/csv"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+)
+
+// ContainerSample is the observed resource usage of a running container.
+// Network and block IO are cumulative since the container started. The last
+// sample of a container that stopped (or was removed) during monitoring
+// has Stopped set and no usage.
+type ContainerSample struct {
+	ContainerID     string
+	Time            time.Time
+	CPUPercent      float64 // 100 is one core
+	MemoryMB        float64
+	MemoryLimitMB   float64
+	NetworkRxBytes  uint64 // summed over the container's networks
+	NetworkTxBytes  uint64
+	BlockReadBytes  uint64
+	BlockWriteBytes uint64
+	Stopped         bool
+}
+
+// MonitorContainers samples the usage of every container each interval and
+// streams the samples. A container's stream ends with a Stopped sample once
+// it is no longer running; the channel is closed when every container has
+// stopped or the manager is closed.
+func (m *DockerManager) MonitorContainers(ids []string, interval time.Duration) <-chan ContainerSample {
+	samples := make(chan ContainerSample, len(ids))
+	
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			m.monitor(id, interval, samples)
+		}(id)
+	}
+	
+	go func() {
+		wg.Wait()
+		close(samples)
+	}()
+	
+	return samples
+}
+
+// monitor samples one container until it stops or the manager is closed
+func (m *DockerManager) monitor(id string, interval time.Duration, samples chan<- ContainerSample) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	
+	for {
+		if !m.running(id) {
+			select {
+			case samples <- ContainerSample{ContainerID: id, Time: time.Now(), Stopped: true}:
+			case <-m.ctx.Done():
+			}
+			return
+		}
+		
+		statsJSON, err := m.readStats(id)
+		if err != nil {
+			if m.ctx.Err() != nil {
+				return
+			}
+			log.Printf("Failed to read stats of container %s: %v", id, err)
+		} else {
+			select {
+			case samples <- newSample(id, statsJSON):
+			case <-m.ctx.Done():
+				return
+			}
+		}
+		
+		select {
+		case <-ticker.C:
+		case <-m.ctx.Done():
+			return
+		}
+	}
+}
+
+// running reports whether the container still exists and is running
+func (m *DockerManager) running(id string) bool {
+	info, err := m.client.ContainerInspect(m.ctx, id)
+	if err != nil {
+		if !client.IsErrNotFound(err) && m.ctx.Err() == nil {
+			log.Printf("Failed to inspect container %s: %v", id, err)
+		}
+		return false
+	}
+	return info.State != nil && info.State.Running
+}
+
+func newSample(id string, statsJSON types.StatsJSON) ContainerSample {
+	sample := ContainerSample{
+		ContainerID:   id,
+		Time:          statsJSON.Read,
+		CPUPercent:    cpuPercent(statsJSON),
+		MemoryMB:      memoryMB(statsJSON.MemoryStats.Usage),
+		MemoryLimitMB: memoryMB(statsJSON.MemoryStats.Limit),
+	}
+	if sample.Time.IsZero() {
+		sample.Time = time.Now()
+	}
+	
+	for _, network := range statsJSON.Networks {
+		sample.NetworkRxBytes += network.RxBytes
+		sample.NetworkTxBytes += network.TxBytes
+	}
+	for _, entry := range statsJSON.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(entry.Op) {
+		case "read":
+			sample.BlockReadBytes += entry.Value
+		case "write":
+			sample.BlockWriteBytes += entry.Value
+		}
+	}
+	
+	return sample
+}
+
+// SaveSamplesCSV writes every sample from the stream to filename as it
+// arrives, one row per sample, until the stream is closed. It returns the
+// number of usage samples written.
+func SaveSamplesCSV(filename string, samples <-chan ContainerSample) (int, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+	
+	header := []string{"Time", "ContainerID", "CPUPercent", "MemoryMB", "MemoryLimitMB",
+		"NetworkRxBytes", "NetworkTxBytes", "BlockReadBytes", "BlockWriteBytes", "Stopped"}
+	if err := writer.Write(header); err != nil {
+		return 0, err
+	}
+	
+	written := 0
+	for sample := range samples {
+		record := []string{
+			sample.Time.Format(time.RFC3339Nano),
+			sample.ContainerID,
+			strconv.FormatFloat(sample.CPUPercent, 'f', 2, 64),
+			strconv.FormatFloat(sample.MemoryMB, 'f', 2, 64),
+			strconv.FormatFloat(sample.MemoryLimitMB, 'f', 2, 64),
+			strconv.FormatUint(sample.NetworkRxBytes, 10),
+			strconv.FormatUint(sample.NetworkTxBytes, 10),
+			strconv.FormatUint(sample.BlockReadBytes, 10),
+			strconv.FormatUint(sample.BlockWriteBytes, 10),
+			strconv.FormatBool(sample.Stopped),
+		}
+		if err := writer.Write(record); err != nil {
+			return written, err
+		}
+		// Keep the file current so a long monitor can be followed
+		writer.Flush()
+		if !sample.Stopped {
+			written++
+		}
+	}
+	
+	return written, writer.Error()
+}